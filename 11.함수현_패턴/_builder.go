@@ -5,8 +5,9 @@ import (
 	"net/http"
 )
 
+// optgen 의 golden 테스트가 이 Config 로 Builder 와 Functional Options 를 생성한다
 type Config struct {
-	port int
+	port int `default:"8080" min:"1" max:"65535"`
 }
 
 /*							첫번째 방법 고전적인 Builder 패턴 적용방법 ✅ 								*/
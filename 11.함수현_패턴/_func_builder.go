@@ -2,7 +2,9 @@ package main
 
 import (
 	"errors"
+	"net"
 	"net/http"
+	"strconv"
 )
 
 type options struct {
@@ -17,6 +19,10 @@ func WithPort(port int) Option {
 			return errors.New("port must be positive")
 		}
 
+		if port == 0 {
+			return errors.New("port must be non-zero")
+		}
+
 		options.port = &port
 		return nil
 	}
@@ -25,13 +31,18 @@ func WithPort(port int) Option {
 func NewServer(addr string, opts ...Option) (*http.Server, error) {
 	options := &options{}
 
+	// 옵션은 한번씩만 적용한다
 	for _, opt := range opts {
 		if err := opt(options); err != nil {
 			return nil, err
 		}
+	}
 
-		opt(options)
+	port := 8080 // 포트를 지정하지 않았다면 Default Port
+	if options.port != nil {
+		port = *options.port
 	}
 
-	return nil, nil
+	// timeout, TLS, middleware 등 나머지 옵션은 server 패키지 참고
+	return &http.Server{Addr: net.JoinHostPort(addr, strconv.Itoa(port))}, nil
 }
@@ -7,6 +7,9 @@ package main
 - 메모리 효율성 (실제로 메모리를 할당하지 않는다) <=> empty slice는 메모리를 할당함
 - nil slice는 null 로 직렬화됨 <=> empty slice는 []로 직렬화됨
 - nil slice는 "데이터가 없음" 을 명확히 표현 <=> empty slice는 "빈 배열이 있음"
+
+API 클라이언트가 null 을 처리하지 못한다면 empty slice 를 만들어 돌려주지 말고
+직렬화할 때 jsonpolicy 로 정한다 (jsonpolicy:"nil-as-empty" 태그 또는 jsonpolicy.WithPolicy(jsonpolicy.NilAsEmpty))
 */
 func GetUserNilSlice() []string {
 	var s []string
@@ -1,15 +1,21 @@
 package main
 
+// messageSize 는 받는 message 하나의 크기다 (1 MiB)
+const messageSize = 1 << 20
+
 func receiveMessage() []byte {
-	var b []byte
-	return b
+	return make([]byte, messageSize)
 }
 
 func getMessageType(msg []byte) []byte {
 	return msg[:5]
 }
 
-func storageMessage(b []byte) {}
+var storage [][]byte
+
+func storageMessage(b []byte) {
+	storage = append(storage, b)
+}
 
 /*
 메모리 누수 ❌
@@ -24,6 +30,15 @@ func consumeMessages() {
 	}
 }
 
+/*
+analyzers/subslice 가 위의 storageMessage(getMessageType(msg)) 를 찾아서
+storageMessage(slices.Clone(getMessageType(msg))) 로 고치는 수정안을 준다.
+19_test.go 의 TestSubsliceRetention 이 실제로 남는 heap 을 보여준다.
+
+실제로 message 를 받아서 저장하는 loop 는 consumer 패키지를 쓴다.
+Source (메모리, 파일 tail, TCP) 의 buffer 를 Pool 의 buffer 로 복사해서 Sink 에 넘기므로 큰 buffer 를 붙잡지 않는다.
+*/
+
 /*
 메모리 누수 없음 ✅
 num 으로 길이를 지정해서
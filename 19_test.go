@@ -0,0 +1,57 @@
+package main
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/zkfmapf123/100/utils"
+)
+
+func heapAlloc() uint64 {
+	runtime.GC()
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	return m.HeapAlloc
+}
+
+/*
+5 byte 짜리 sub-slice 만 저장해도 1 MiB message 전체가 GC 되지 않는다.
+slices.Clone 으로 복사해서 저장하면 message 는 GC 된다.
+*/
+func TestSubsliceRetention(t *testing.T) {
+	const count = 64
+
+	retained := func(name string, keep func(msg []byte) []byte) uint64 {
+		storage = nil
+		defer func() { storage = nil }()
+
+		before := heapAlloc()
+		for range count {
+			storageMessage(keep(receiveMessage()))
+		}
+		after := heapAlloc()
+
+		utils.PrintMemory(name)
+		if after < before {
+			return 0
+		}
+		return after - before
+	}
+
+	bad := retained("sub-slice", getMessageType)
+	good := retained("copy", func(msg []byte) []byte {
+		return slices.Clone(getMessageType(msg))
+	})
+
+	t.Logf("%d messages: sub-slice retains %d KiB, copy retains %d KiB", count, bad/1024, good/1024)
+
+	if bad < count*messageSize*9/10 {
+		t.Errorf("sub-slices retained %d bytes, want about %d (the whole messages)", bad, count*messageSize)
+	}
+	if good > messageSize {
+		t.Errorf("copies retained %d bytes, want less than one message (%d)", good, messageSize)
+	}
+}
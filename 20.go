@@ -2,8 +2,13 @@ package main
 
 import (
 	"reflect"
+
+	"github.com/zkfmapf123/100/diff"
 )
 
+//go:generate go run github.com/zkfmapf123/100/eqgen/cmd/eqgen -out 20_equal.go
+
+//eqgen:equal
 type customer struct {
 	id string
 	op []float64
@@ -50,3 +55,25 @@ func (a customer) equals(b customer) bool {
 
 	return true
 }
+
+/*
+✅ equals 를 손으로 쓰지 않고 eqgen 으로 생성 (20_equal.go)
+필드가 늘어도 go generate 만 다시 하면 되고, 속도는 손으로 쓴 equals 와 같다 (20_test.go 벤치마크)
+*/
+func GeneratedEquals() bool {
+	c1 := customer{id: "x", op: []float64{1.}}
+	c2 := customer{id: "x", op: []float64{1.}}
+
+	return c1.Equal(c2)
+}
+
+/*
+✅ equals, GoodEquals 가 false 일 때 어디가 다른지는 diff 로 본다
+equals 처럼 nil 과 빈 op 는 같다고 본다
+
+	id: "x" != "y"
+	op[1]: 2 != 3
+*/
+func explainEquals(a, b customer) string {
+	return diff.Compare(a, b, diff.NilEqualsEmpty()).String()
+}
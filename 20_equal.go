@@ -0,0 +1,18 @@
+// Code generated by eqgen; DO NOT EDIT.
+
+package main
+
+import (
+	"slices"
+)
+
+// Equal 은 a 와 b 의 모든 필드를 비교한다
+func (a customer) Equal(b customer) bool {
+	if a.id != b.id {
+		return false
+	}
+	if !slices.Equal(a.op, b.op) {
+		return false
+	}
+	return true
+}
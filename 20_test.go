@@ -0,0 +1,103 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/zkfmapf123/100/diff"
+)
+
+func TestCustomerEqual(t *testing.T) {
+	base := customer{id: "x", op: []float64{1, 2}}
+
+	tests := []struct {
+		name string
+		b    customer
+		want bool
+	}{
+		{"same", customer{id: "x", op: []float64{1, 2}}, true},
+		{"id", customer{id: "y", op: []float64{1, 2}}, false},
+		{"op", customer{id: "x", op: []float64{1, 3}}, false},
+		{"op length", customer{id: "x", op: []float64{1}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 생성한 Equal, 손으로 쓴 equals, reflect.DeepEqual 이 모두 같은 답을 낸다
+			if got := base.Equal(tt.b); got != tt.want {
+				t.Errorf("Equal = %v, want %v", got, tt.want)
+			}
+			if got := base.equals(tt.b); got != tt.want {
+				t.Errorf("equals = %v, want %v", got, tt.want)
+			}
+			if got := reflect.DeepEqual(base, tt.b); got != tt.want {
+				t.Errorf("reflect.DeepEqual = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 다른 점: nil 과 빈 slice 는 같고, NaN 은 == 처럼 다르다
+	if !(customer{}).Equal(customer{op: []float64{}}) || reflect.DeepEqual(customer{}, customer{op: []float64{}}) {
+		t.Error("Equal should treat nil and empty op as equal, reflect.DeepEqual should not")
+	}
+	if nan := (customer{op: []float64{math.NaN()}}); nan.Equal(nan) {
+		t.Error("Equal treats NaN as equal to itself")
+	}
+}
+
+func TestExplainEquals(t *testing.T) {
+	a := customer{id: "x", op: []float64{1, 2}}
+	b := customer{id: "y", op: []float64{1, 3, 4}}
+
+	want := "id: \"x\" != \"y\"\nop[1]: 2 != 3\nop[2]: <missing> != 4\n"
+	if got := explainEquals(a, b); got != want {
+		t.Errorf("explainEquals =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := explainEquals(customer{id: "x"}, customer{id: "x", op: []float64{}}); got != "" {
+		t.Errorf("explainEquals of equal customers = %q", got)
+	}
+
+	// 테스트에서는 Assert 로 차이를 바로 보여준다
+	diff.Assert(t, a, customer{id: "x", op: []float64{1, 2.0000001}}, diff.FloatTolerance(1e-6))
+}
+
+func benchCustomers() (customer, customer) {
+	op := make([]float64, 100)
+	for i := range op {
+		op[i] = float64(i)
+	}
+
+	return customer{id: "customer-1", op: op}, customer{id: "customer-1", op: slices.Clone(op)}
+}
+
+var equalSink bool
+
+func BenchmarkEqualGenerated(b *testing.B) {
+	c1, c2 := benchCustomers()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		equalSink = c1.Equal(c2)
+	}
+}
+
+func BenchmarkEqualHandwritten(b *testing.B) {
+	c1, c2 := benchCustomers()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		equalSink = c1.equals(c2)
+	}
+}
+
+func BenchmarkEqualReflect(b *testing.B) {
+	c1, c2 := benchCustomers()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		equalSink = reflect.DeepEqual(c1, c2)
+	}
+}
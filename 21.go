@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/zkfmapf123/100/ledger"
+)
 
 type Account struct {
 	Balance int
@@ -15,6 +19,7 @@ var a = []Account{
 /*
 ❌ 실제 값이 바뀌지 않는다
 golang에서의 range는 값을 복제해서 사용한다. (실제값을 바꾸지 않음)
+analyzers/rangecopy 가 v.Balance += 1 을 찾아서 balanceB 처럼 a[i].Balance += 1 로 고치는 수정안을 준다.
 */
 func balanceA() {
 	for _, v := range a {
@@ -36,7 +41,33 @@ func balanceB() {
 하지만 슬라이스의 포인터를 반복한다면 -> CPU 연산 효율이 떨어진다...
 */
 func balanceC() {
-	// pointer...
+	for i := range a {
+		v := &a[i]
+		v.Balance += 1
+	}
+}
+
+/*
+❌ balanceA ~ C 는 모두 전역 a 를 잠금 없이 바꾼다. 여러 goroutine 이 부르면 data race 다.
+✅ ledger 는 계좌마다 잠그고, 이체는 두 계좌를 한번에 바꾸며, 모든 거래를 journal 에 남긴다.
+*/
+func balanceLedger() (int64, error) {
+	l := ledger.New(ledger.WithJournal(&ledger.MemoryJournal{}))
+	for i, acc := range a {
+		id := ledger.AccountID(fmt.Sprint(i))
+		if _, err := l.Open(id); err != nil {
+			return 0, err
+		}
+		if _, err := l.Deposit(id, int64(acc.Balance)+1); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := l.Transfer("2", "0", 500); err != nil {
+		return 0, err
+	}
+
+	return l.Total(), nil // 이체는 합을 바꾸지 않는다
 }
 
 /*
@@ -66,6 +97,7 @@ func range_ex1() {
 ✅ 만약, 배열의 대한 값을 바꿔야 한다면? -> 배열 포인터 활용
 배열크기가 크다면 아래 방법을 사용하는 것을 추천
 배열전체를 굳이 copy 하지 않아도 되기때문에 ...
+analyzers/rangecopy 는 -array-bytes (기본 1024 byte) 이상인 배열을 값으로 range 하면 range &a 를 제안한다.
 */
 func arrayBetterCode() {
 	a := [3]int{1, 2, 3}
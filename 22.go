@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+
+	"github.com/zkfmapf123/100/store"
 )
 
 type Customer struct {
@@ -13,14 +15,18 @@ type Store struct {
 	m map[string]*Customer
 }
 
-// ❌ loop내에서 사용하고있는 c 의 메모리주소를 참조하고있음
+/*
+❌ loop내에서 사용하고있는 c 의 메모리주소를 참조하고있음
+go1.21 까지는 c 가 하나뿐이라 모든 값이 마지막 원소를 가리키고, go1.22 부터는 원소의 복사본을 가리킨다 (cs 를 바꿔도 모른다).
+analyzers/loopptr 가 go.mod 의 go 버전에 맞춰 어느 쪽인지 알려준다.
+*/
 func (s *Store) storeCustomers_1(cs []Customer) {
 	for _, c := range cs {
 		s.m[c.ID] = &c
 	}
 }
 
-// ❌ current의 가상 메모리 공간을 참조하고있음
+// ❌ current의 가상 메모리 공간을 참조하고있음 (go1.22 부터는 &c 와 같아서 필요 없는 복사다)
 func (s *Store) storeCustomer_2(cs []Customer) {
 	for _, c := range cs {
 		current := c
@@ -28,7 +34,10 @@ func (s *Store) storeCustomer_2(cs []Customer) {
 	}
 }
 
-// ✅ cs[i] 의 실제 메모리 주소를 참조하고 있음
+/*
+✅ cs[i] 의 실제 메모리 주소를 참조하고 있음
+복사가 아니라 호출한 쪽의 slice 를 같이 쓰는 것이다. 호출한 쪽이 cs 를 바꾸면 s.m 의 값도 바뀐다 (loopptr 가 알려준다).
+*/
 func (s *Store) storeCustomers_3(cs []Customer) {
 	for i := range cs {
 
@@ -37,6 +46,28 @@ func (s *Store) storeCustomers_3(cs []Customer) {
 	}
 }
 
+/*
+✅ Store 는 잠금 없는 map 이고, 저장한 pointer 를 그대로 나눠 준다.
+store.Store 는 shard 마다 잠그고, 값으로 저장해서 (Customer) 돌려줄 때도 복사본을 준다.
+잔액 구간 index 로 찾을 수 있다.
+*/
+func storeCustomers_4(cs []Customer) *store.Store[string, Customer] {
+	s := store.New[string, Customer](store.WithIndex("balance", balanceRange))
+	for _, c := range cs {
+		s.Set(c.ID, c)
+	}
+
+	return s
+}
+
+func balanceRange(c Customer) string {
+	if c.Balance < 2 {
+		return "low"
+	}
+
+	return "high"
+}
+
 func _22() {
 	c := []Customer{
 		{ID: "1", Balance: 1.0},
@@ -60,6 +91,11 @@ func _22() {
 	s.storeCustomers_3(c)
 	fmt.Println(s)
 
+	// 4번방식
+	ss := storeCustomers_4(c)
+	high, _ := ss.Find("balance", "high")
+	fmt.Println(high)
+
 	for _, ccc := range c {
 		fmt.Printf("가상 메모리 주소 : %p\n", &ccc) // 가상 메모리 주소 loop 내에서
 	}
@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+
+	"github.com/zkfmapf123/100/config"
+	"github.com/zkfmapf123/100/lifecycle"
 )
 
 var (
-	config map[string]string
-	db     *Database
+	appConfig AppConfig
+	db        *Database
 )
 
+// map[string]string 대신 타입이 있는 설정 (port 는 11.함수현_패턴 요구사항처럼 양수만)
+type AppConfig struct {
+	Host string `config:"host" default:"localhost" required:"true"`
+	Port int    `config:"port" default:"5432" min:"1" max:"65535"`
+}
+
 type Database struct {
 	connection string
 }
@@ -18,12 +27,15 @@ func NewDatabase(conn string) *Database {
 	return &Database{connection: conn}
 }
 
+func (d *Database) Close() error {
+	d.connection = ""
+	return nil
+}
+
 // /////////////////////////////////////////////// Bad Pattern - init /////////////////////////////////////////////////
 func init() {
 	// 1. 복잡한 로직을 init에 넣는 것은 X
-	config = make(map[string]string)
-	config["host"] = "localhost"
-	config["port"] = "5432"
+	appConfig = AppConfig{Host: "localhost", Port: 5432}
 
 	// 2. 에러 처리가 어려움
 	db = NewDatabase("connection string")
@@ -33,37 +45,75 @@ func init() {
 }
 
 // /////////////////////////////////////////////// Good Pattern - init /////////////////////////////////////////////////
+// default < env(APP_HOST, APP_PORT) 순서로 읽고, 잘못된 값은 어디서 왔는지 에러로 돌려준다
 func setupConfig() error {
-	config = make(map[string]string)
-	config["host"] = "localhost"
-	config["port"] = "5432"
+	cfg, err := config.New[AppConfig](config.WithEnv("APP")).Load()
+	if err != nil {
+		return err
+	}
+
+	appConfig = cfg
 	return nil
 }
 
-func setupDatabase() error {
+func setupDatabase(ctx context.Context) error {
 	var err error
-	db, err = connectDatabase()
+	db, err = connectDatabase(ctx)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func connectDatabase() (*Database, error) {
-	return NewDatabase("connection string"), nil
+func connectDatabase(ctx context.Context) (*Database, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewDatabase(fmt.Sprintf("%s:%d", appConfig.Host, appConfig.Port)), nil
+}
+
+// database 컴포넌트 (config 이후에 시작, 종료 시 연결을 닫는다)
+func databaseComponent() lifecycle.Component {
+	return lifecycle.Component{
+		Name:      "database",
+		DependsOn: []string{"config"},
+		Start:     setupDatabase,
+		Stop: func(ctx context.Context) error {
+			return db.Close()
+		},
+	}
 }
 
 // 초기화 로직을 명시적으로 호출
-func GoodInitPattern() {
-	if err := setupConfig(); err != nil {
-		log.Fatal("설정 초기화 실패:", err)
+// 순서는 lifecycle 이 의존성으로 정하고, 실패하면 log.Fatal 대신 에러를 돌려준다
+func GoodInitPattern(ctx context.Context) error {
+	app := lifecycle.New()
+
+	components := []lifecycle.Component{
+		{
+			Name:  "config",
+			Start: func(ctx context.Context) error { return setupConfig() },
+		},
+		databaseComponent(),
+		{
+			Name:      "server",
+			DependsOn: []string{"database"},
+			Start: func(ctx context.Context) error {
+				fmt.Println("애플리케이션 시작")
+				return nil
+			},
+		},
 	}
 
-	if err := setupDatabase(); err != nil {
-		log.Fatal("데이터베이스 연결 실패:", err)
+	for _, c := range components {
+		if err := app.Register(c); err != nil {
+			return err
+		}
 	}
 
-	fmt.Println("애플리케이션 시작")
+	// SIGTERM 을 받거나 ctx 가 취소되면 server -> database -> config 순서로 종료한다
+	return app.Run(ctx)
 }
 
 /*
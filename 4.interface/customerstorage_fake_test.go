@@ -0,0 +1,354 @@
+// Code generated by fakegen; DO NOT EDIT.
+
+package main
+
+import (
+	"github.com/zkfmapf123/100/fakegen/fake"
+)
+
+// FakeCustomerStorage 는 CustomerStorage 의 fake 다. 모든 호출은 Recorder 에 기록된다.
+type FakeCustomerStorage struct {
+	fake.Recorder
+
+	getStubs             fake.Stubs[func(any) error]
+	getCusomterNameStubs fake.Stubs[func(string) error]
+	getUserIdStubs       fake.Stubs[func(int) error]
+	marshalStubs         fake.Stubs[func(any)]
+	setStubs             fake.Stubs[func(any) error]
+	setUserIdStubs       fake.Stubs[func(int) error]
+	setUserNameStubs     fake.Stubs[func(string) error]
+}
+
+var _ CustomerStorage = (*FakeCustomerStorage)(nil)
+
+func (f *FakeCustomerStorage) Get(arg0 any) error {
+	f.Record("Get", arg0)
+
+	if fn, ok := f.getStubs.Find(arg0); ok {
+		return fn(arg0)
+	}
+
+	var r0 error
+
+	return r0
+}
+
+// OnGet 은 인자가 matchers 와 맞을 때 Get 의 동작을 정한다 (생략한 인자는 모두 맞는다)
+func (f *FakeCustomerStorage) OnGet(matchers ...fake.Matcher) *FakeCustomerStorageGetStub {
+	return &FakeCustomerStorageGetStub{f: f, matchers: matchers}
+}
+
+type FakeCustomerStorageGetStub struct {
+	f        *FakeCustomerStorage
+	matchers []fake.Matcher
+}
+
+func (s *FakeCustomerStorageGetStub) Return(r0 error) {
+	s.f.getStubs.Add(s.matchers, func(any) error {
+		return r0
+	})
+}
+
+func (s *FakeCustomerStorageGetStub) Do(fn func(any) error) {
+	s.f.getStubs.Add(s.matchers, fn)
+}
+
+// FakeCustomerStorageGetArgs 는 Get 호출 한번의 인자다
+type FakeCustomerStorageGetArgs struct {
+	Arg0 any
+}
+
+// GetCalls 는 Get 이 받은 인자를 호출 순서대로 돌려준다
+func (f *FakeCustomerStorage) GetCalls() []FakeCustomerStorageGetArgs {
+	var out []FakeCustomerStorageGetArgs
+	for _, c := range f.CallsTo("Get") {
+		var args FakeCustomerStorageGetArgs
+		args.Arg0, _ = c.Args[0].(any)
+		out = append(out, args)
+	}
+	return out
+}
+
+func (f *FakeCustomerStorage) GetCusomterName(arg0 string) error {
+	f.Record("GetCusomterName", arg0)
+
+	if fn, ok := f.getCusomterNameStubs.Find(arg0); ok {
+		return fn(arg0)
+	}
+
+	var r0 error
+
+	return r0
+}
+
+// OnGetCusomterName 은 인자가 matchers 와 맞을 때 GetCusomterName 의 동작을 정한다 (생략한 인자는 모두 맞는다)
+func (f *FakeCustomerStorage) OnGetCusomterName(matchers ...fake.Matcher) *FakeCustomerStorageGetCusomterNameStub {
+	return &FakeCustomerStorageGetCusomterNameStub{f: f, matchers: matchers}
+}
+
+type FakeCustomerStorageGetCusomterNameStub struct {
+	f        *FakeCustomerStorage
+	matchers []fake.Matcher
+}
+
+func (s *FakeCustomerStorageGetCusomterNameStub) Return(r0 error) {
+	s.f.getCusomterNameStubs.Add(s.matchers, func(string) error {
+		return r0
+	})
+}
+
+func (s *FakeCustomerStorageGetCusomterNameStub) Do(fn func(string) error) {
+	s.f.getCusomterNameStubs.Add(s.matchers, fn)
+}
+
+// FakeCustomerStorageGetCusomterNameArgs 는 GetCusomterName 호출 한번의 인자다
+type FakeCustomerStorageGetCusomterNameArgs struct {
+	Arg0 string
+}
+
+// GetCusomterNameCalls 는 GetCusomterName 이 받은 인자를 호출 순서대로 돌려준다
+func (f *FakeCustomerStorage) GetCusomterNameCalls() []FakeCustomerStorageGetCusomterNameArgs {
+	var out []FakeCustomerStorageGetCusomterNameArgs
+	for _, c := range f.CallsTo("GetCusomterName") {
+		var args FakeCustomerStorageGetCusomterNameArgs
+		args.Arg0, _ = c.Args[0].(string)
+		out = append(out, args)
+	}
+	return out
+}
+
+func (f *FakeCustomerStorage) GetUserId(arg0 int) error {
+	f.Record("GetUserId", arg0)
+
+	if fn, ok := f.getUserIdStubs.Find(arg0); ok {
+		return fn(arg0)
+	}
+
+	var r0 error
+
+	return r0
+}
+
+// OnGetUserId 은 인자가 matchers 와 맞을 때 GetUserId 의 동작을 정한다 (생략한 인자는 모두 맞는다)
+func (f *FakeCustomerStorage) OnGetUserId(matchers ...fake.Matcher) *FakeCustomerStorageGetUserIdStub {
+	return &FakeCustomerStorageGetUserIdStub{f: f, matchers: matchers}
+}
+
+type FakeCustomerStorageGetUserIdStub struct {
+	f        *FakeCustomerStorage
+	matchers []fake.Matcher
+}
+
+func (s *FakeCustomerStorageGetUserIdStub) Return(r0 error) {
+	s.f.getUserIdStubs.Add(s.matchers, func(int) error {
+		return r0
+	})
+}
+
+func (s *FakeCustomerStorageGetUserIdStub) Do(fn func(int) error) {
+	s.f.getUserIdStubs.Add(s.matchers, fn)
+}
+
+// FakeCustomerStorageGetUserIdArgs 는 GetUserId 호출 한번의 인자다
+type FakeCustomerStorageGetUserIdArgs struct {
+	Arg0 int
+}
+
+// GetUserIdCalls 는 GetUserId 이 받은 인자를 호출 순서대로 돌려준다
+func (f *FakeCustomerStorage) GetUserIdCalls() []FakeCustomerStorageGetUserIdArgs {
+	var out []FakeCustomerStorageGetUserIdArgs
+	for _, c := range f.CallsTo("GetUserId") {
+		var args FakeCustomerStorageGetUserIdArgs
+		args.Arg0, _ = c.Args[0].(int)
+		out = append(out, args)
+	}
+	return out
+}
+
+func (f *FakeCustomerStorage) Marshal(arg0 any) {
+	f.Record("Marshal", arg0)
+
+	if fn, ok := f.marshalStubs.Find(arg0); ok {
+		fn(arg0)
+		return
+	}
+}
+
+// OnMarshal 은 인자가 matchers 와 맞을 때 Marshal 의 동작을 정한다 (생략한 인자는 모두 맞는다)
+func (f *FakeCustomerStorage) OnMarshal(matchers ...fake.Matcher) *FakeCustomerStorageMarshalStub {
+	return &FakeCustomerStorageMarshalStub{f: f, matchers: matchers}
+}
+
+type FakeCustomerStorageMarshalStub struct {
+	f        *FakeCustomerStorage
+	matchers []fake.Matcher
+}
+
+func (s *FakeCustomerStorageMarshalStub) Return() {
+	s.f.marshalStubs.Add(s.matchers, func(any) {
+	})
+}
+
+func (s *FakeCustomerStorageMarshalStub) Do(fn func(any)) {
+	s.f.marshalStubs.Add(s.matchers, fn)
+}
+
+// FakeCustomerStorageMarshalArgs 는 Marshal 호출 한번의 인자다
+type FakeCustomerStorageMarshalArgs struct {
+	Arg0 any
+}
+
+// MarshalCalls 는 Marshal 이 받은 인자를 호출 순서대로 돌려준다
+func (f *FakeCustomerStorage) MarshalCalls() []FakeCustomerStorageMarshalArgs {
+	var out []FakeCustomerStorageMarshalArgs
+	for _, c := range f.CallsTo("Marshal") {
+		var args FakeCustomerStorageMarshalArgs
+		args.Arg0, _ = c.Args[0].(any)
+		out = append(out, args)
+	}
+	return out
+}
+
+func (f *FakeCustomerStorage) Set(arg0 any) error {
+	f.Record("Set", arg0)
+
+	if fn, ok := f.setStubs.Find(arg0); ok {
+		return fn(arg0)
+	}
+
+	var r0 error
+
+	return r0
+}
+
+// OnSet 은 인자가 matchers 와 맞을 때 Set 의 동작을 정한다 (생략한 인자는 모두 맞는다)
+func (f *FakeCustomerStorage) OnSet(matchers ...fake.Matcher) *FakeCustomerStorageSetStub {
+	return &FakeCustomerStorageSetStub{f: f, matchers: matchers}
+}
+
+type FakeCustomerStorageSetStub struct {
+	f        *FakeCustomerStorage
+	matchers []fake.Matcher
+}
+
+func (s *FakeCustomerStorageSetStub) Return(r0 error) {
+	s.f.setStubs.Add(s.matchers, func(any) error {
+		return r0
+	})
+}
+
+func (s *FakeCustomerStorageSetStub) Do(fn func(any) error) {
+	s.f.setStubs.Add(s.matchers, fn)
+}
+
+// FakeCustomerStorageSetArgs 는 Set 호출 한번의 인자다
+type FakeCustomerStorageSetArgs struct {
+	Arg0 any
+}
+
+// SetCalls 는 Set 이 받은 인자를 호출 순서대로 돌려준다
+func (f *FakeCustomerStorage) SetCalls() []FakeCustomerStorageSetArgs {
+	var out []FakeCustomerStorageSetArgs
+	for _, c := range f.CallsTo("Set") {
+		var args FakeCustomerStorageSetArgs
+		args.Arg0, _ = c.Args[0].(any)
+		out = append(out, args)
+	}
+	return out
+}
+
+func (f *FakeCustomerStorage) SetUserId(arg0 int) error {
+	f.Record("SetUserId", arg0)
+
+	if fn, ok := f.setUserIdStubs.Find(arg0); ok {
+		return fn(arg0)
+	}
+
+	var r0 error
+
+	return r0
+}
+
+// OnSetUserId 은 인자가 matchers 와 맞을 때 SetUserId 의 동작을 정한다 (생략한 인자는 모두 맞는다)
+func (f *FakeCustomerStorage) OnSetUserId(matchers ...fake.Matcher) *FakeCustomerStorageSetUserIdStub {
+	return &FakeCustomerStorageSetUserIdStub{f: f, matchers: matchers}
+}
+
+type FakeCustomerStorageSetUserIdStub struct {
+	f        *FakeCustomerStorage
+	matchers []fake.Matcher
+}
+
+func (s *FakeCustomerStorageSetUserIdStub) Return(r0 error) {
+	s.f.setUserIdStubs.Add(s.matchers, func(int) error {
+		return r0
+	})
+}
+
+func (s *FakeCustomerStorageSetUserIdStub) Do(fn func(int) error) {
+	s.f.setUserIdStubs.Add(s.matchers, fn)
+}
+
+// FakeCustomerStorageSetUserIdArgs 는 SetUserId 호출 한번의 인자다
+type FakeCustomerStorageSetUserIdArgs struct {
+	Arg0 int
+}
+
+// SetUserIdCalls 는 SetUserId 이 받은 인자를 호출 순서대로 돌려준다
+func (f *FakeCustomerStorage) SetUserIdCalls() []FakeCustomerStorageSetUserIdArgs {
+	var out []FakeCustomerStorageSetUserIdArgs
+	for _, c := range f.CallsTo("SetUserId") {
+		var args FakeCustomerStorageSetUserIdArgs
+		args.Arg0, _ = c.Args[0].(int)
+		out = append(out, args)
+	}
+	return out
+}
+
+func (f *FakeCustomerStorage) SetUserName(arg0 string) error {
+	f.Record("SetUserName", arg0)
+
+	if fn, ok := f.setUserNameStubs.Find(arg0); ok {
+		return fn(arg0)
+	}
+
+	var r0 error
+
+	return r0
+}
+
+// OnSetUserName 은 인자가 matchers 와 맞을 때 SetUserName 의 동작을 정한다 (생략한 인자는 모두 맞는다)
+func (f *FakeCustomerStorage) OnSetUserName(matchers ...fake.Matcher) *FakeCustomerStorageSetUserNameStub {
+	return &FakeCustomerStorageSetUserNameStub{f: f, matchers: matchers}
+}
+
+type FakeCustomerStorageSetUserNameStub struct {
+	f        *FakeCustomerStorage
+	matchers []fake.Matcher
+}
+
+func (s *FakeCustomerStorageSetUserNameStub) Return(r0 error) {
+	s.f.setUserNameStubs.Add(s.matchers, func(string) error {
+		return r0
+	})
+}
+
+func (s *FakeCustomerStorageSetUserNameStub) Do(fn func(string) error) {
+	s.f.setUserNameStubs.Add(s.matchers, fn)
+}
+
+// FakeCustomerStorageSetUserNameArgs 는 SetUserName 호출 한번의 인자다
+type FakeCustomerStorageSetUserNameArgs struct {
+	Arg0 string
+}
+
+// SetUserNameCalls 는 SetUserName 이 받은 인자를 호출 순서대로 돌려준다
+func (f *FakeCustomerStorage) SetUserNameCalls() []FakeCustomerStorageSetUserNameArgs {
+	var out []FakeCustomerStorageSetUserNameArgs
+	for _, c := range f.CallsTo("SetUserName") {
+		var args FakeCustomerStorageSetUserNameArgs
+		args.Arg0, _ = c.Args[0].(string)
+		out = append(out, args)
+	}
+	return out
+}
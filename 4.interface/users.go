@@ -1,5 +1,7 @@
 package main
 
+//go:generate go run github.com/zkfmapf123/100/fakegen/cmd/fakegen -type CustomerStorage -out customerstorage_fake_test.go
+
 // 사용자 측에 인터페이스를 두어라...
 type CustomerStorage interface {
 	Get(any) error // ❌
@@ -12,3 +14,12 @@ type CustomerStorage interface {
 
 	Marshal(v any) // ✅ 보통 marshal 하거나, 애매한 매개변수는 any로 주자
 }
+
+// ✅ 사용자 측 인터페이스만 알면 되므로 테스트에서는 fake 를 넣으면 된다
+func renameCustomer(s CustomerStorage, id int, name string) error {
+	if err := s.GetUserId(id); err != nil {
+		return err
+	}
+
+	return s.SetUserName(name)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zkfmapf123/100/fakegen/fake"
+)
+
+func TestRenameCustomer(t *testing.T) {
+	s := &FakeCustomerStorage{}
+
+	if err := renameCustomer(s, 1, "leedonggyu"); err != nil {
+		t.Fatal(err)
+	}
+
+	s.AssertCalled(t, "GetUserId", fake.Eq(1))
+	s.AssertCalled(t, "SetUserName", fake.Func("has prefix lee", func(name string) bool {
+		return strings.HasPrefix(name, "lee")
+	}))
+	s.AssertOrder(t, "GetUserId", "SetUserName")
+	s.AssertNotCalled(t, "Set")
+
+	if calls := s.SetUserNameCalls(); len(calls) != 1 || calls[0].Arg0 != "leedonggyu" {
+		t.Errorf("SetUserNameCalls() = %v", calls)
+	}
+}
+
+func TestRenameCustomerNotFound(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	s := &FakeCustomerStorage{}
+	s.OnGetUserId().Return(nil)
+	s.OnGetUserId(fake.Eq(404)).Return(errNotFound)
+
+	if err := renameCustomer(s, 404, "x"); !errors.Is(err, errNotFound) {
+		t.Fatalf("renameCustomer(404) = %v, want %v", err, errNotFound)
+	}
+	s.AssertNotCalled(t, "SetUserName")
+
+	if err := renameCustomer(s, 1, "x"); err != nil {
+		t.Fatalf("renameCustomer(1) = %v", err)
+	}
+
+	if got := s.CallCount("GetUserId"); got != 2 {
+		t.Errorf("CallCount(GetUserId) = %d, want 2", got)
+	}
+}
+
+func TestFakeDo(t *testing.T) {
+	var names []string
+
+	s := &FakeCustomerStorage{}
+	s.OnSetUserName(fake.TypeOf[string]()).Do(func(name string) error {
+		names = append(names, name)
+		return nil
+	})
+
+	_ = s.SetUserName("a")
+	_ = s.SetUserName("b")
+
+	if strings.Join(names, ",") != "a,b" {
+		t.Errorf("names = %v", names)
+	}
+}
+
+// 실패해야 하는 assertion 이 실제로 실패하는지 확인한다
+type recordTB struct {
+	failed bool
+}
+
+func (r *recordTB) Helper()               {}
+func (r *recordTB) Errorf(string, ...any) { r.failed = true }
+
+func TestFakeAssertionsFail(t *testing.T) {
+	s := &FakeCustomerStorage{}
+	_ = s.SetUserName("x")
+	_ = s.GetUserId(1)
+
+	for name, assert := range map[string]func(fake.TB) bool{
+		"order":     func(tb fake.TB) bool { return s.AssertOrder(tb, "GetUserId", "SetUserName") },
+		"called":    func(tb fake.TB) bool { return s.AssertCalled(tb, "GetUserId", fake.Eq(2)) },
+		"notCalled": func(tb fake.TB) bool { return s.AssertNotCalled(tb, "SetUserName") },
+	} {
+		tb := &recordTB{}
+		if assert(tb) || !tb.failed {
+			t.Errorf("%s: assertion passed, want failure", name)
+		}
+	}
+}
@@ -1,7 +1,5 @@
 package main
 
-import "fmt"
-
 // 기본적인 GetKeys
 func GetKeys(m map[string]int) []string {
 	var keys []string
@@ -13,26 +11,18 @@ func GetKeys(m map[string]int) []string {
 	return keys
 }
 
-// 여러 타입을 받아야 한다면?
-func GetKeysOtherTypes(m any) ([]any, error) {
-	switch t := m.(type) {
-
-	default:
-		return nil, fmt.Errorf("unknown type : %T", t)
+/*
+여러 타입을 받아야 한다면?
 
-	case map[string]int:
-		var keys []any
-		for k := range t {
-			keys = append(keys, k)
-		}
-		return keys, nil
+❌ any 로 받아서 type switch 하는 방법 (GetKeysOtherTypes) 은 지웠다.
+  - map 타입마다 case 를 추가해야 하고, 돌려주는 []any 는 다시 타입 단언을 해야 한다
+  - case 만 있고 내용이 빠진 map[int]string 은 아무 에러없이 nil, nil 을 돌려줬다
 
-	case map[int]string:
-		// ...
-	}
+✅ collections.Keys 를 쓰면 어떤 map 이든 타입이 유지된 key 를 받는다 (5_test.go 에 벤치마크)
 
-	return nil, nil
-}
+	keys := collections.Keys(map[int]string{1: "a"}) // []int
+	keys := collections.SortedKeys(m)                // 순서가 필요하면
+*/
 
 // ---------------------------- 제네릭을 사용한다면? ✅ ----------------------------
 // Type을 제한해야 한다면
@@ -42,6 +32,7 @@ type ComparableType interface {
 	~int | ~string
 }
 
+// 제약을 comparable 로 풀고 용량을 미리 잡은 버전이 collections.Keys 다
 func GetKeysUseGeneric[K ComparableType, V any](m map[K]V) []K {
 	var keys []K
 	for k := range m {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/zkfmapf123/100/collections"
+)
+
+func keysInput() map[string]int {
+	m := make(map[string]int, 100000)
+	for i := range 100000 {
+		m[strconv.Itoa(i)] = i
+	}
+
+	return m
+}
+
+/*
+GetKeys, GetKeysUseGeneric 은 용량을 잡지 않아 append 마다 다시 할당한다
+collections.Keys 는 len(m) 만큼 잡아두므로 할당이 한번이다
+*/
+func BenchmarkGetKeys(b *testing.B) {
+	m := keysInput()
+
+	// GC 통계 초기화
+	runtime.GC()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		GetKeys(m)
+	}
+}
+
+func BenchmarkGetKeysUseGeneric(b *testing.B) {
+	m := keysInput()
+
+	// GC 통계 초기화
+	runtime.GC()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		GetKeysUseGeneric(m)
+	}
+}
+
+func BenchmarkCollectionsKeys(b *testing.B) {
+	m := keysInput()
+
+	// GC 통계 초기화
+	runtime.GC()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		collections.Keys(m)
+	}
+}
@@ -0,0 +1,775 @@
+package anyapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+/*
+4.interface/provider.go 의 C.Get, C.Set 처럼 any 를 주고받는 exported API 를 찾는다.
+
+	func (c *C) Set(id string, v any) error   // ❌ 호출하는 곳은 string, int 만 넘긴다
+	func (c *C) Get(id string) (any, error)   // ❌ 호출하는 곳은 string 으로만 꺼낸다
+
+패키지 안의 호출을 모아서, any 파라미터에 넘기는 구체 타입(또는 any 결과를 type assertion 하는 타입)이
+-max-types 개 이하일 때만 보고하고, 타입별 메서드(SetString, SetInt)나 제네릭 시그니처를 제안한다.
+테스트 파일의 호출은 세지 않는다.
+
+호출은 fact 로 다른 패키지에 넘긴다. 후보를 import 해서 부르는 패키지는 선언한 패키지와
+그 사이 패키지들의 호출에 자기 호출을 더해서, 자기의 첫 호출 위치에 보고한다.
+users.go 의 Marshal(v any) 처럼 애매한 매개변수는 -allow 로 제외한다.
+diff.Compare 처럼 reflect 로 바로 넘기는 파라미터는 보고하지 않고,
+호출하는 패키지의 unexported 타입처럼 선언한 패키지에서 쓸 수 없는 타입으로는 제네릭 시그니처를 만들지 않는다.
+*/
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	c := &checker{maxTypes: 3, allow: nameList{"Marshal": true, "Unmarshal": true}}
+
+	a := &analysis.Analyzer{
+		Name:      "anyapi",
+		Doc:       "reports exported functions and interface methods using any where callers only use a few concrete types",
+		Requires:  []*analysis.Analyzer{inspect.Analyzer},
+		Run:       c.run,
+		FactTypes: []analysis.Fact{new(usageFact), new(callersFact)},
+	}
+
+	a.Flags.IntVar(&c.maxTypes, "max-types", c.maxTypes, "report only when call sites use at most this many concrete types")
+	a.Flags.Var(c.allow, "allow", "comma separated function names (Name or Type.Name) allowed to use any")
+
+	return a
+}
+
+type nameList map[string]bool
+
+func (l nameList) String() string {
+	names := make([]string, 0, len(l))
+	for n := range l {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ",")
+}
+
+func (l nameList) Set(s string) error {
+	for _, n := range strings.Split(s, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			l[n] = true
+		}
+	}
+
+	return nil
+}
+
+type checker struct {
+	maxTypes int
+	allow    nameList
+}
+
+// usage 는 any 파라미터(또는 결과) 하나에 대해 호출하는 곳이 쓴 타입이다 (fact 로 넘기므로 필드를 export 한다)
+type usage struct {
+	Types   map[string]bool
+	Dynamic bool // 호출하는 쪽도 any 라서 타입을 알 수 없다
+	Hidden  bool // 선언한 패키지에서 이름을 쓸 수 없는 타입이 있다 (호출하는 패키지의 타입 등)
+	Sites   int
+}
+
+func newUsage() *usage {
+	return &usage{Types: map[string]bool{}}
+}
+
+// add 는 pkg (선언한 패키지) 기준으로 t 를 기록한다
+func (u *usage) add(t types.Type, pkg *types.Package) {
+	u.Sites++
+	if t == nil || types.IsInterface(t) {
+		u.Dynamic = true
+		return
+	}
+
+	t = types.Default(t)
+	u.Hidden = u.Hidden || !visible(t, pkg)
+	u.Types[types.TypeString(t, qualifier(pkg))] = true
+}
+
+func (u *usage) merge(o *usage) {
+	u.Sites += o.Sites
+	u.Dynamic = u.Dynamic || o.Dynamic
+	u.Hidden = u.Hidden || o.Hidden
+	for t := range o.Types {
+		u.Types[t] = true
+	}
+}
+
+func (u *usage) sorted() []string {
+	out := make([]string, 0, len(u.Types))
+	for t := range u.Types {
+		out = append(out, t)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+// usageFact 는 exported 후보의 호출 정보다. 선언한 패키지가 자기 호출로 남기고,
+// 다른 패키지는 여기에 자기 호출을 더해서 판단한다.
+type usageFact struct {
+	Module  string // 같은 모듈의 호출만 더한다 (표준 라이브러리는 빠진다)
+	Params  map[int]*usage
+	Results map[int]*usage
+}
+
+func (*usageFact) AFact() {}
+
+func (f *usageFact) String() string {
+	var parts []string
+	for _, m := range []map[int]*usage{f.Params, f.Results} {
+		idx := make([]int, 0, len(m))
+		for i := range m {
+			idx = append(idx, i)
+		}
+		sort.Ints(idx)
+
+		ps := make([]string, 0, len(idx))
+		for _, i := range idx {
+			u := m[i]
+			ts := strings.Join(u.sorted(), "|")
+			if u.Dynamic {
+				ts = "dynamic"
+			}
+			ps = append(ps, fmt.Sprintf("%d:%s", i, ts))
+		}
+		parts = append(parts, "["+strings.Join(ps, " ")+"]")
+	}
+
+	return "usage" + strings.Join(parts, "")
+}
+
+// callersFact 는 패키지가 다른 패키지의 후보를 부른 정보다 (key 는 types.Func.FullName).
+// 더 아래의 패키지가 이것까지 더하므로 import 경로를 따라 호출이 쌓인다.
+type callersFact struct {
+	Funcs map[string]*usageFact
+}
+
+func (*callersFact) AFact() {}
+
+func (f *callersFact) String() string { return fmt.Sprintf("callers(%d funcs)", len(f.Funcs)) }
+
+// candidate 는 any 를 쓰는 exported 함수 또는 인터페이스 메서드다
+type candidate struct {
+	fn      *types.Func
+	params  map[int]*usage
+	results map[int]*usage
+
+	// 다른 패키지의 후보는 이 패키지의 첫 호출에 보고한다
+	foreign bool
+	pos     ast.Node
+	local   int // 이 패키지의 (테스트가 아닌) 호출 수
+}
+
+func (c *candidate) fact(module string) *usageFact {
+	return &usageFact{Module: module, Params: c.params, Results: c.results}
+}
+
+// modulePath 는 패키지가 속한 모듈이다 (GOPATH 모드나 go vet 처럼 모듈을 알려주지 않는 driver 에서는 "")
+func modulePath(pass *analysis.Pass) string {
+	if pass.Module == nil {
+		return ""
+	}
+
+	return pass.Module.Path
+}
+
+// external 은 표준 라이브러리나 module cache 의 패키지인지 본다. 이런 패키지의 API 는 바꿀 수 없으므로 fact 를 남기지 않는다.
+func external(pass *analysis.Pass) bool {
+	if len(pass.Files) == 0 {
+		return true
+	}
+
+	name := pass.Fset.File(pass.Files[0].Pos()).Name()
+	goroot := filepath.Join(build.Default.GOROOT, "src") + string(filepath.Separator)
+
+	return strings.HasPrefix(name, goroot) || strings.Contains(name, string(filepath.Separator)+filepath.Join("pkg", "mod")+string(filepath.Separator))
+}
+
+func (c *candidate) merge(f *usageFact) {
+	for i, u := range f.Params {
+		if c.params[i] != nil {
+			c.params[i].merge(u)
+		}
+	}
+	for i, u := range f.Results {
+		if c.results[i] != nil {
+			c.results[i].merge(u)
+		}
+	}
+}
+
+func (c *checker) newCandidate(fn *types.Func) *candidate {
+	if !fn.Exported() || c.allowed(fn) {
+		return nil
+	}
+
+	sig := fn.Type().(*types.Signature)
+	cand := &candidate{fn: fn, params: map[int]*usage{}, results: map[int]*usage{}}
+	for i := 0; i < sig.Params().Len(); i++ {
+		if isAny(sig.Params().At(i).Type()) {
+			cand.params[i] = newUsage()
+		}
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		if isAny(sig.Results().At(i).Type()) {
+			cand.results[i] = newUsage()
+		}
+	}
+
+	if len(cand.params)+len(cand.results) == 0 {
+		return nil
+	}
+
+	return cand
+}
+
+func (c *checker) run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	candidates := map[*types.Func]*candidate{}
+	var order []*candidate
+
+	addCandidate := func(id *ast.Ident, body *ast.BlockStmt) {
+		fn, ok := pass.TypesInfo.Defs[id].(*types.Func)
+		if !ok {
+			return
+		}
+
+		cand := c.newCandidate(fn)
+		if cand == nil {
+			return
+		}
+
+		// reflect 로 바로 넘기는 any 는 어떤 타입이든 받으려는 것이다 (diff.Compare)
+		for i := range reflected(pass, fn, body) {
+			delete(cand.params, i)
+		}
+		if len(cand.params)+len(cand.results) == 0 {
+			return
+		}
+
+		cand.pos = id
+		candidates[fn] = cand
+		order = append(order, cand)
+	}
+
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.TypeSpec)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Recv != nil && !exportedRecv(pass, n) {
+				return
+			}
+			addCandidate(n.Name, n.Body)
+		case *ast.TypeSpec:
+			iface, ok := n.Type.(*ast.InterfaceType)
+			if !ok || !n.Name.IsExported() {
+				return
+			}
+			for _, m := range iface.Methods.List {
+				for _, id := range m.Names {
+					addCandidate(id, nil)
+				}
+			}
+		}
+	})
+
+	// foreign 은 fact 가 있는 다른 패키지의 후보다. 선언한 패키지와 import 한 패키지들의 호출에서 시작한다.
+	foreign := func(fn *types.Func) *candidate {
+		var fact usageFact
+		if fn.Pkg() == pass.Pkg || !pass.ImportObjectFact(fn, &fact) || fact.Module != modulePath(pass) {
+			return nil
+		}
+
+		cand := c.newCandidate(fn)
+		if cand == nil {
+			return nil
+		}
+		cand.foreign = true
+		// 선언한 패키지가 후보에서 뺀 파라미터는 여기서도 뺀다
+		for i := range cand.params {
+			if fact.Params[i] == nil {
+				delete(cand.params, i)
+			}
+		}
+		cand.merge(&fact)
+
+		for _, pf := range pass.AllPackageFacts() {
+			if cf, ok := pf.Fact.(*callersFact); ok && cf.Funcs[fn.FullName()] != nil {
+				cand.merge(cf.Funcs[fn.FullName()])
+			}
+		}
+
+		candidates[fn] = cand
+		order = append(order, cand)
+		return cand
+	}
+
+	insp.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		// 테스트는 예시 값 하나로 부르는 경우가 많으므로 근거로 쓰지 않는다
+		call := n.(*ast.CallExpr)
+		if strings.HasSuffix(pass.Fset.File(call.Pos()).Name(), "_test.go") {
+			return true
+		}
+
+		fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if fn == nil {
+			return true
+		}
+
+		cand := candidates[fn]
+		if cand == nil {
+			if cand = foreign(fn); cand == nil {
+				return true
+			}
+		}
+
+		if cand.foreign && cand.local == 0 {
+			cand.pos = call
+		}
+		cand.local++
+
+		for i, u := range cand.params {
+			if i < len(call.Args) && !call.Ellipsis.IsValid() {
+				u.add(pass.TypesInfo.TypeOf(call.Args[i]), fn.Pkg())
+			}
+		}
+
+		for i, u := range cand.results {
+			assertedTypes(pass, call, i, len(cand.results) == 1 && cand.fn.Type().(*types.Signature).Results().Len() == 1, stack, u, fn.Pkg())
+		}
+		return true
+	})
+
+	callers := &callersFact{Funcs: map[string]*usageFact{}}
+	ext := external(pass)
+	for _, cand := range order {
+		switch {
+		case !cand.foreign && ext:
+		case !cand.foreign:
+			pass.ExportObjectFact(cand.fn, cand.fact(modulePath(pass)))
+		case cand.local > 0:
+			callers.Funcs[cand.fn.FullName()] = cand.fact(modulePath(pass))
+		}
+	}
+	if len(callers.Funcs) > 0 {
+		pass.ExportPackageFact(callers)
+	}
+
+	for _, cand := range order {
+		if cand.foreign && cand.local == 0 {
+			continue
+		}
+		c.report(pass, cand, qualifier(cand.fn.Pkg()))
+	}
+
+	return nil, nil
+}
+
+func (c *checker) allowed(fn *types.Func) bool {
+	if c.allow[fn.Name()] {
+		return true
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return c.allow[named.Obj().Name()+"."+fn.Name()]
+	}
+
+	return false
+}
+
+func exportedRecv(pass *analysis.Pass, fn *ast.FuncDecl) bool {
+	t := pass.TypesInfo.TypeOf(fn.Recv.List[0].Type)
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Exported()
+}
+
+func isAny(t types.Type) bool {
+	iface, ok := t.(*types.Interface)
+	if !ok {
+		// any 는 alias 이므로 Unalias 로 풀어서 본다
+		iface, ok = types.Unalias(t).(*types.Interface)
+	}
+
+	return ok && iface.Empty()
+}
+
+/*
+assertedTypes 는 any 결과를 어떤 타입으로 꺼내는지 모은다
+
+	c.Get(id).(string)          // 바로 type assertion
+	v, err := c.Get(id)         // 변수에 받은 뒤 v.(string) 또는 switch v.(type)
+*/
+func assertedTypes(pass *analysis.Pass, call *ast.CallExpr, index int, single bool, stack []ast.Node, u *usage, pkg *types.Package) {
+	parent := stack[len(stack)-2]
+
+	if ta, ok := parent.(*ast.TypeAssertExpr); ok && single && ta.Type != nil {
+		u.add(pass.TypesInfo.TypeOf(ta.Type), pkg)
+		return
+	}
+
+	assign, ok := parent.(*ast.AssignStmt)
+	if !ok || len(assign.Rhs) != 1 || index >= len(assign.Lhs) {
+		u.add(nil, pkg)
+		return
+	}
+
+	id, ok := assign.Lhs[index].(*ast.Ident)
+	if !ok {
+		u.add(nil, pkg)
+		return
+	}
+
+	v, _ := pass.TypesInfo.ObjectOf(id).(*types.Var)
+	if v == nil {
+		// _ 로 버리는 결과는 판단에서 뺀다
+		return
+	}
+
+	var scope ast.Node
+	for i := len(stack) - 1; i >= 0; i-- {
+		if _, ok := stack[i].(*ast.BlockStmt); ok {
+			scope = stack[i]
+			break
+		}
+	}
+
+	found := false
+	ast.Inspect(scope, func(n ast.Node) bool {
+		ta, ok := n.(*ast.TypeAssertExpr)
+		if !ok {
+			return true
+		}
+
+		x, ok := ast.Unparen(ta.X).(*ast.Ident)
+		if !ok || pass.TypesInfo.Uses[x] != v {
+			return true
+		}
+
+		found = true
+		if ta.Type != nil {
+			u.add(pass.TypesInfo.TypeOf(ta.Type), pkg)
+			return true
+		}
+
+		// switch v.(type) 의 case 타입들
+		for _, sw := range enclosingTypeSwitches(scope, ta) {
+			for _, clause := range sw.Body.List {
+				for _, e := range clause.(*ast.CaseClause).List {
+					u.add(pass.TypesInfo.TypeOf(e), pkg)
+				}
+			}
+		}
+		return true
+	})
+
+	if !found {
+		u.add(nil, pkg)
+	}
+}
+
+func enclosingTypeSwitches(root ast.Node, ta *ast.TypeAssertExpr) []*ast.TypeSwitchStmt {
+	var found []*ast.TypeSwitchStmt
+	ast.Inspect(root, func(n ast.Node) bool {
+		sw, ok := n.(*ast.TypeSwitchStmt)
+		if !ok {
+			return true
+		}
+
+		ast.Inspect(sw.Assign, func(n ast.Node) bool {
+			if n == ta {
+				found = append(found, sw)
+			}
+			return true
+		})
+		return true
+	})
+
+	return found
+}
+
+func (c *checker) report(pass *analysis.Pass, cand *candidate, qf types.Qualifier) {
+	sig := cand.fn.Type().(*types.Signature)
+
+	var (
+		problems []string
+		generic  = map[int]string{} // any 파라미터 index 별 타입 제약 (string | int)
+		all      []string           // 파라미터와 결과에서 쓰인 모든 타입
+		hidden   bool               // 선언한 패키지에서 쓸 수 없는 타입이 섞여 있다
+	)
+
+	for i := 0; i < sig.Params().Len(); i++ {
+		u := cand.params[i]
+		if u == nil || u.Dynamic || u.Sites == 0 || len(u.Types) > c.maxTypes {
+			continue
+		}
+
+		ts := u.sorted()
+		problems = append(problems, fmt.Sprintf("parameter %s is only passed %s", paramName(sig.Params().At(i), i), strings.Join(ts, ", ")))
+		if !u.Hidden {
+			generic[i] = strings.Join(ts, " | ")
+		}
+		hidden = hidden || u.Hidden
+		all = append(all, ts...)
+	}
+
+	for i := 0; i < sig.Results().Len(); i++ {
+		u := cand.results[i]
+		if u == nil || u.Dynamic || u.Sites == 0 || len(u.Types) == 0 || len(u.Types) > c.maxTypes {
+			continue
+		}
+
+		ts := u.sorted()
+		problems = append(problems, fmt.Sprintf("result %d is only asserted to %s", i, strings.Join(ts, ", ")))
+		hidden = hidden || u.Hidden
+		all = append(all, ts...)
+	}
+
+	if len(problems) == 0 {
+		return
+	}
+
+	// 메서드는 타입 파라미터를 가질 수 없으므로 타입별 메서드를 제안한다
+	var suggestion string
+	switch {
+	case len(problems) == 1 && len(all) == 1 && !hidden:
+		suggestion = fmt.Sprintf("use %s instead of any", all[0])
+	case isInterfaceMethod(sig):
+		suggestion = fmt.Sprintf("use typed methods (%s) or make the interface generic", typedNames(cand.fn.Name(), all))
+	case sig.Recv() != nil:
+		suggestion = fmt.Sprintf("use typed methods (%s)", typedNames(cand.fn.Name(), all))
+	case len(generic) == 0:
+		suggestion = fmt.Sprintf("use typed functions (%s)", typedNames(cand.fn.Name(), all))
+	default:
+		suggestion = fmt.Sprintf("use typed functions (%s) or a generic signature %s", typedNames(cand.fn.Name(), all), genericSignature(cand.fn, generic, qf))
+	}
+
+	name := cand.fn.Name()
+	if cand.foreign {
+		name = qualifiedName(cand.fn)
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     cand.pos.Pos(),
+		End:     cand.pos.End(),
+		Message: fmt.Sprintf("%s uses any but %s; %s", name, strings.Join(problems, " and "), suggestion),
+	})
+}
+
+// reflected 는 body 안에서 reflect 패키지 함수에 그대로 넘기는 any 파라미터의 index 다
+func reflected(pass *analysis.Pass, fn *types.Func, body *ast.BlockStmt) map[int]bool {
+	out := map[int]bool{}
+	if body == nil {
+		return out
+	}
+
+	params := fn.Type().(*types.Signature).Params()
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		callee, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if callee == nil || callee.Pkg() == nil || callee.Pkg().Path() != "reflect" {
+			return true
+		}
+
+		for _, arg := range call.Args {
+			id, ok := ast.Unparen(arg).(*ast.Ident)
+			if !ok {
+				continue
+			}
+			for i := 0; i < params.Len(); i++ {
+				if pass.TypesInfo.Uses[id] == params.At(i) {
+					out[i] = true
+				}
+			}
+		}
+		return true
+	})
+
+	return out
+}
+
+/*
+visible 은 선언한 패키지 pkg 에서 t 를 이름으로 쓸 수 있는지 본다
+
+	main.customer      // ❌ unexported, 게다가 pkg 가 import 할 수 없다 (import cycle)
+	[]byte, *Customer  // ✅
+	time.Duration      // ✅ pkg 가 (간접적으로라도) import 하는 패키지의 exported 타입
+*/
+func visible(t types.Type, pkg *types.Package) bool {
+	switch t := types.Unalias(t).(type) {
+	case *types.Basic:
+		return true
+	case *types.Pointer:
+		return visible(t.Elem(), pkg)
+	case *types.Slice:
+		return visible(t.Elem(), pkg)
+	case *types.Array:
+		return visible(t.Elem(), pkg)
+	case *types.Chan:
+		return visible(t.Elem(), pkg)
+	case *types.Map:
+		return visible(t.Key(), pkg) && visible(t.Elem(), pkg)
+	case *types.Named:
+		obj := t.Obj()
+		if obj.Pkg() != nil && obj.Pkg() != pkg && (!obj.Exported() || !imports(pkg, obj.Pkg())) {
+			return false
+		}
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			if !visible(t.TypeArgs().At(i), pkg) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// struct, func 같은 타입 literal 은 제약으로 쓰지 않는다
+	return false
+}
+
+// imports 는 pkg 가 dep 를 직접 또는 간접적으로 import 하는지 본다
+func imports(pkg, dep *types.Package) bool {
+	seen := map[*types.Package]bool{}
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, imp := range p.Imports() {
+			if imp == dep {
+				return true
+			}
+			if !seen[imp] {
+				seen[imp] = true
+				queue = append(queue, imp)
+			}
+		}
+	}
+
+	return false
+}
+
+// qualifier 는 선언한 패키지의 타입은 그대로, 다른 패키지의 타입은 패키지 이름을 붙여 쓴다 (main.customer)
+func qualifier(pkg *types.Package) types.Qualifier {
+	return func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		return p.Name()
+	}
+}
+
+// qualifiedName 은 다른 패키지의 함수를 a.Store, a.C.Set 처럼 쓴다
+func qualifiedName(fn *types.Func) string {
+	name := fn.Name()
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		t := recv.Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			name = named.Obj().Name() + "." + name
+		}
+	}
+
+	return fn.Pkg().Name() + "." + name
+}
+
+func isInterfaceMethod(sig *types.Signature) bool {
+	return sig.Recv() != nil && types.IsInterface(sig.Recv().Type())
+}
+
+func paramName(v *types.Var, i int) string {
+	if v.Name() == "" || v.Name() == "_" {
+		return fmt.Sprintf("#%d", i)
+	}
+
+	return v.Name()
+}
+
+// typedNames 는 Set + [string int] -> SetString, SetInt 를 만든다
+func typedNames(name string, ts []string) string {
+	seen := map[string]bool{}
+	var names []string
+	for _, t := range ts {
+		t = strings.TrimLeft(t, "*[]")
+		if i := strings.LastIndex(t, "."); i >= 0 {
+			t = t[i+1:]
+		}
+
+		n := name + strings.ToUpper(t[:1]) + t[1:]
+		if !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// genericSignature 는 타입을 좁힐 수 있는 any 파라미터 (constraints 의 index) 만 타입 파라미터로 바꾼 시그니처를 만든다
+func genericSignature(fn *types.Func, constraints map[int]string, qf types.Qualifier) string {
+	sig := fn.Type().(*types.Signature)
+
+	var (
+		tparams []string
+		params  []string
+	)
+	for i := 0; i < sig.Params().Len(); i++ {
+		p := sig.Params().At(i)
+		typ := types.TypeString(p.Type(), qf)
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			typ = "..." + types.TypeString(p.Type().(*types.Slice).Elem(), qf)
+		}
+
+		if c, ok := constraints[i]; ok {
+			tp := "T"
+			if len(constraints) > 1 {
+				tp = fmt.Sprintf("T%d", len(tparams)+1)
+			}
+			tparams = append(tparams, tp+" "+c)
+			typ = tp
+		}
+
+		params = append(params, strings.TrimSpace(p.Name()+" "+typ))
+	}
+
+	results := strings.TrimPrefix(types.TypeString(types.NewSignatureType(nil, nil, nil, nil, sig.Results(), false), qf), "func()")
+
+	return fmt.Sprintf("%s[%s](%s)%s", fn.Name(), strings.Join(tparams, ", "), strings.Join(params, ", "), results)
+}
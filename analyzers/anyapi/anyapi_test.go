@@ -0,0 +1,13 @@
+package anyapi_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/zkfmapf123/100/analyzers/anyapi"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), anyapi.Analyzer, "a", "b")
+}
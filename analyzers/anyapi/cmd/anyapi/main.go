@@ -0,0 +1,12 @@
+// anyapi 는 anyapi analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/anyapi"
+)
+
+func main() {
+	singlechecker.Main(anyapi.Analyzer)
+}
@@ -0,0 +1,107 @@
+package a
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+type C struct {
+	m map[string]any
+}
+
+// ❌ 호출하는 곳은 string, int 만 넘긴다
+func (c *C) Set(id string, v any) error { // want Set:`usage\[1:int\|string\]\[\]` `Set uses any but parameter v is only passed int, string; use typed methods \(SetInt, SetString\)`
+	c.m[id] = v
+	return nil
+}
+
+// ❌ 호출하는 곳은 string 으로만 꺼낸다
+func (c *C) Get(id string) (any, error) { // want Get:`usage\[\]\[0:string\]` `Get uses any but result 0 is only asserted to string; use string instead of any`
+	return c.m[id], nil
+}
+
+// ✅ 타입별 메서드
+func (c *C) SetUserId(id int) error {
+	c.m["user"] = id
+	return nil
+}
+
+// ❌ 함수는 제네릭 시그니처를 제안한다
+func Store(key string, v any) { // want Store:`usage\[1:\[\]byte\|float64\]\[\]` `Store uses any but parameter v is only passed \[\]byte, float64; use typed functions \(StoreByte, StoreFloat64\) or a generic signature Store\[T \[\]byte \| float64\]\(key string, v T\)`
+}
+
+// 호출하는 쪽도 any 를 넘기므로 타입을 좁힐 수 없다
+func Forward(v any) { // want Forward:`usage\[0:dynamic\]\[\]`
+	Store("k", []byte{})
+}
+
+func Log(v any) {} // want Log:`usage\[0:dynamic\]\[\]`
+
+// 사용자 측 인터페이스 (users.go)
+type CustomerStorage interface {
+	Get(any) error // want Get:`usage\[0:\*Customer\|int\]\[\]` `Get uses any but parameter #0 is only passed \*Customer, int; use typed methods \(GetCustomer, GetInt\) or make the interface generic`
+
+	GetUserId(int) error
+
+	Marshal(v any) // Marshal 은 -allow 기본값으로 제외된다
+}
+
+type Customer struct{}
+
+// ❌ 타입을 좁힐 수 있는 b 만 타입 파라미터가 된다 (a 는 any 를 넘기는 곳이 있다)
+func Pair(a, b any) { // want Pair:`usage\[0:dynamic 1:int\|string\]\[\]` `Pair uses any but parameter b is only passed int, string; use typed functions \(PairInt, PairString\) or a generic signature Pair\[T int \| string\]\(a any, b T\)`
+}
+
+// 이 패키지에서는 테스트에서만 부르므로 보고하지 않는다. b 패키지의 호출로 판단한다.
+func Encode(v any) error { // want Encode:`usage\[0:\]\[\]`
+	return nil
+}
+
+// b 패키지에서만 b 의 unexported 타입으로 부른다. a 에서는 그 타입을 쓸 수 없으므로 제네릭 시그니처는 제안하지 않는다.
+func Put(v any) { // want Put:`usage\[0:\]\[\]`
+}
+
+// ✅ reflect 로 넘기는 any 는 어떤 타입이든 받으려는 것이다 (diff.Compare)
+func Kind(v any) string {
+	return reflect.ValueOf(v).Kind().String()
+}
+
+// unexported 는 대상이 아니다
+func store(v any) {}
+
+func use(c *C, s CustomerStorage) {
+	c.Set("name", "kim")
+	c.Set("age", 10)
+	c.Set("nick", "k")
+
+	n, _ := c.Get("name")
+	name := n.(string)
+	v, err := c.Get("nick")
+	if err == nil {
+		switch v.(type) {
+		case string:
+		}
+	}
+
+	Store("a", []byte("x"))
+	Store("b", 1.5)
+
+	var anything any = name
+	Log(anything)
+	Forward(anything)
+	Log(1)
+
+	s.Get(1)
+	s.Get(&Customer{})
+	s.Marshal(1)
+
+	store(1)
+
+	Pair(anything, 1)
+	Pair(1, "x")
+
+	_ = Kind(1)
+
+	b, _ := json.Marshal(v)
+	_ = b
+}
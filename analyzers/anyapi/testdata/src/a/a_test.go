@@ -0,0 +1,9 @@
+package a
+
+import "testing"
+
+// 테스트의 호출은 근거로 쓰지 않는다
+func TestEncode(t *testing.T) {
+	Encode(struct{}{})
+	Store("t", "test")
+}
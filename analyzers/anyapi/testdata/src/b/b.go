@@ -0,0 +1,21 @@
+package b // want package:`callers\(5 funcs\)`
+
+import "a"
+
+// 다른 패키지의 호출도 선언한 패키지의 호출과 합쳐서 판단한다
+func use(c *a.C) {
+	a.Store("x", 2.5) // want `a.Store uses any but parameter v is only passed \[\]byte, float64; use typed functions \(StoreByte, StoreFloat64\) or a generic signature Store\[T \[\]byte \| float64\]\(key string, v T\)`
+
+	a.Encode(1) // want `a.Encode uses any but parameter v is only passed int; use int instead of any`
+
+	// a 안에서 string, int 를 넘기고 여기서 bool 을 넘기므로 세 타입이 된다
+	c.Set("ok", true) // want `a.C.Set uses any but parameter v is only passed bool, int, string; use typed methods \(SetBool, SetInt, SetString\)`
+
+	a.Log("x")
+
+	a.Put(point{}) // want `a.Put uses any but parameter v is only passed b.point, int; use typed functions \(PutPoint, PutInt\)`
+	a.Put(1)
+	a.Kind(point{})
+}
+
+type point struct{ x, y int }
@@ -0,0 +1,12 @@
+// copylen 는 copylen analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/copylen"
+)
+
+func main() {
+	singlechecker.Main(copylen.Analyzer)
+}
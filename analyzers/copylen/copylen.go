@@ -0,0 +1,772 @@
+package copylen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+
+	"github.com/zkfmapf123/100/analyzers/internal/passutil"
+)
+
+/*
+18.go 의 badCopy 처럼 길이가 0 (또는 src 보다 짧은) 인 dst 에 copy 하는 코드를 찾는다.
+
+	src := []int{0, 1, 2}
+	var dst []int       // ❌ len(dst) == 0
+	copy(dst, src)      //    아무것도 복사하지 않고, 복사한 개수도 버린다
+
+	dst := make([]int, len(src)) // ✅ goodCopy 로 바꾸는 수정안
+	dst := slices.Clone(src)     // ✅ 또는 slices.Clone
+
+함수마다 control flow graph 위에서 지역 slice 변수의 길이 (상수) 를 전파한다.
+- var s []T, nil, []T{a, b}, make([]T, n), s[i:j], append(s, ...) 의 길이를 안다
+- 분기가 합쳐질 때 길이가 다르면 모르는 것으로 본다
+- 주소를 꺼내거나 (&s) 함수 리터럴 안에서 대입하는 변수는 추적하지 않는다
+*/
+var Analyzer = &analysis.Analyzer{
+	Name:     "copylen",
+	Doc:      "reports copy calls whose destination provably has length zero or is shorter than the source",
+	Requires: []*analysis.Analyzer{inspect.Analyzer, ctrlflow.Analyzer},
+	Run:      run,
+}
+
+const (
+	fixMake  = "allocate the destination with make"
+	fixClone = "use slices.Clone"
+)
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	cfgs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}, func(n ast.Node) {
+		var (
+			g    *cfg.CFG
+			body *ast.BlockStmt
+		)
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			g, body = cfgs.FuncDecl(fn), fn.Body
+		case *ast.FuncLit:
+			g, body = cfgs.FuncLit(fn), fn.Body
+		}
+
+		if g == nil || body == nil {
+			return
+		}
+
+		f := &function{pass: pass, node: n, body: body, untracked: untracked(pass, body)}
+		f.check(g)
+	})
+
+	return nil, nil
+}
+
+// lengths 는 블록 위치에서 길이를 아는 변수들이다 (없는 변수는 모른다)
+type lengths map[*types.Var]int64
+
+// join 은 두 경로에서 같은 길이인 변수만 남긴다
+func join(a, b lengths) lengths {
+	out := lengths{}
+	for v, n := range a {
+		if m, ok := b[v]; ok && m == n {
+			out[v] = n
+		}
+	}
+
+	return out
+}
+
+func equal(a, b lengths) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for v, n := range a {
+		if m, ok := b[v]; !ok || m != n {
+			return false
+		}
+	}
+
+	return true
+}
+
+type function struct {
+	pass      *analysis.Pass
+	node      ast.Node
+	body      *ast.BlockStmt
+	untracked map[*types.Var]bool
+}
+
+// check 는 블록별 시작 길이를 고정점까지 구한 뒤 copy 호출을 검사한다
+func (f *function) check(g *cfg.CFG) {
+	if len(g.Blocks) == 0 {
+		return
+	}
+
+	in := make([]lengths, len(g.Blocks))
+	in[0] = lengths{}
+
+	work := []*cfg.Block{g.Blocks[0]}
+	for len(work) > 0 {
+		b := work[0]
+		work = work[1:]
+
+		out := f.transferBlock(b, in[b.Index], nil)
+		for _, succ := range b.Succs {
+			next := out
+			if in[succ.Index] != nil {
+				next = join(in[succ.Index], out)
+				if equal(next, in[succ.Index]) {
+					continue
+				}
+			}
+			in[succ.Index] = next
+			work = append(work, succ)
+		}
+	}
+
+	for _, b := range g.Blocks {
+		if in[b.Index] != nil {
+			f.transferBlock(b, in[b.Index], f.checkCopy)
+		}
+	}
+}
+
+func (f *function) transferBlock(b *cfg.Block, in lengths, visit func(*ast.CallExpr, lengths)) lengths {
+	state := make(lengths, len(in))
+	for v, n := range in {
+		state[v] = n
+	}
+
+	for _, node := range b.Nodes {
+		if visit != nil {
+			f.copyCalls(node, func(call *ast.CallExpr) { visit(call, state) })
+		}
+		f.transfer(node, state)
+	}
+
+	return state
+}
+
+// copyCalls 는 node 안의 copy 호출을 찾는다 (중첩 함수와 하위 블록은 따로 검사한다)
+func (f *function) copyCalls(node ast.Node, fn func(*ast.CallExpr)) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit, *ast.BlockStmt:
+			return false
+		case *ast.CallExpr:
+			if passutil.IsBuiltin(f.pass, n.Fun, "copy") && len(n.Args) == 2 {
+				fn(n)
+			}
+		}
+		return true
+	})
+}
+
+func (f *function) transfer(node ast.Node, state lengths) {
+	switch n := node.(type) {
+	case *ast.ValueSpec:
+		if len(n.Values) == 0 {
+			for _, name := range n.Names {
+				if v := f.tracked(name); v != nil {
+					state[v] = 0
+				}
+			}
+			return
+		}
+		f.assign(identExprs(n.Names), n.Values, state)
+	case *ast.AssignStmt:
+		if n.Tok == token.DEFINE || n.Tok == token.ASSIGN {
+			f.assign(n.Lhs, n.Rhs, state)
+		}
+	}
+}
+
+func (f *function) assign(lhs, rhs []ast.Expr, state lengths) {
+	if len(lhs) != len(rhs) {
+		for _, l := range lhs {
+			if v := f.tracked(l); v != nil {
+				delete(state, v)
+			}
+		}
+		return
+	}
+
+	// a, b = b, a 처럼 오른쪽을 모두 계산한 뒤 대입한다
+	ns := make([]int64, len(rhs))
+	known := make([]bool, len(rhs))
+	for i, r := range rhs {
+		ns[i], known[i] = f.length(r, state)
+	}
+
+	for i, l := range lhs {
+		v := f.tracked(l)
+		if v == nil {
+			continue
+		}
+		if known[i] {
+			state[v] = ns[i]
+		} else {
+			delete(state, v)
+		}
+	}
+}
+
+// tracked 는 길이를 추적할 지역 slice 변수다
+func (f *function) tracked(e ast.Expr) *types.Var {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	v, _ := f.pass.TypesInfo.ObjectOf(id).(*types.Var)
+	if v == nil || f.untracked[v] || v.Pos() < f.node.Pos() || v.Pos() > f.node.End() {
+		return nil
+	}
+	if _, ok := v.Type().Underlying().(*types.Slice); !ok {
+		return nil
+	}
+
+	return v
+}
+
+/*
+length 는 식의 길이를 계산한다
+
+	nil, var s []T          ->  0
+	[]T{a, b}, "ab"         ->  2
+	make([]T, n)            ->  n (상수일 때)
+	s[i:j]                  ->  j - i
+	append(s, a, b)         ->  len(s) + 2
+*/
+func (f *function) length(e ast.Expr, state lengths) (int64, bool) {
+	e = ast.Unparen(e)
+	info := f.pass.TypesInfo
+
+	tv := info.Types[e]
+	if tv.IsNil() {
+		return 0, true
+	}
+	if tv.Value != nil && tv.Value.Kind() == constant.String {
+		return int64(len(constant.StringVal(tv.Value))), true
+	}
+	if t, ok := arrayType(tv.Type); ok {
+		return t.Len(), true
+	}
+
+	switch e := e.(type) {
+	case *ast.Ident:
+		if v := f.tracked(e); v != nil {
+			n, ok := state[v]
+			return n, ok
+		}
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			if _, keyed := elt.(*ast.KeyValueExpr); keyed {
+				return 0, false
+			}
+		}
+		return int64(len(e.Elts)), true
+	case *ast.SliceExpr:
+		low, ok := int64(0), true
+		if e.Low != nil {
+			low, ok = constInt(f.pass, e.Low)
+		}
+		if !ok {
+			return 0, false
+		}
+
+		var high int64
+		if e.High != nil {
+			high, ok = constInt(f.pass, e.High)
+		} else {
+			high, ok = f.length(e.X, state)
+		}
+		if !ok || high < low {
+			return 0, false
+		}
+		return high - low, true
+	case *ast.CallExpr:
+		return f.callLength(e, state)
+	}
+
+	return 0, false
+}
+
+func (f *function) callLength(call *ast.CallExpr, state lengths) (int64, bool) {
+	// []byte("abc"), []T(s) 같은 변환
+	if tv := f.pass.TypesInfo.Types[call.Fun]; tv.IsType() {
+		if len(call.Args) == 1 {
+			return f.length(call.Args[0], state)
+		}
+		return 0, false
+	}
+
+	switch {
+	case passutil.IsBuiltin(f.pass, call.Fun, "make"):
+		if len(call.Args) < 2 {
+			return 0, false
+		}
+		return constInt(f.pass, call.Args[1])
+	case passutil.IsBuiltin(f.pass, call.Fun, "append"):
+		if len(call.Args) == 0 {
+			return 0, false
+		}
+
+		n, ok := f.length(call.Args[0], state)
+		if !ok {
+			return 0, false
+		}
+
+		if call.Ellipsis.IsValid() {
+			m, ok := f.length(call.Args[1], state)
+			return n + m, ok
+		}
+		return n + int64(len(call.Args)-1), true
+	}
+
+	return 0, false
+}
+
+func (f *function) checkCopy(call *ast.CallExpr, state lengths) {
+	dst, src := call.Args[0], call.Args[1]
+
+	dstLen, ok := f.length(dst, state)
+	if !ok {
+		return
+	}
+
+	srcLen, srcKnown := f.length(src, state)
+	if srcKnown && srcLen <= dstLen {
+		return
+	}
+	// src 길이를 모르면 dst 가 비어있을 때만 확실하다
+	if !srcKnown && dstLen != 0 {
+		return
+	}
+
+	path, _ := astutil.PathEnclosingInterval(passutil.File(f.pass, call), call.Pos(), call.End())
+	ignored := resultIgnored(path)
+
+	var msg string
+	if dstLen == 0 {
+		msg = fmt.Sprintf("%s copies nothing: %s has length 0", types.ExprString(call), types.ExprString(dst))
+	} else {
+		msg = fmt.Sprintf("%s copies %d of %d elements: %s has length %d", types.ExprString(call), dstLen, srcLen, types.ExprString(dst), dstLen)
+	}
+	if ignored {
+		msg += " and the result is ignored"
+	}
+
+	typ := f.typeString(f.pass.TypesInfo.TypeOf(dst))
+	diag := analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: msg + fmt.Sprintf("; use make(%s, len(%s))", typ, types.ExprString(src)),
+	}
+
+	if v := f.tracked(dst); v != nil {
+		diag.SuggestedFixes = f.fixes(path, call, v, ignored)
+	}
+
+	f.pass.Report(diag)
+}
+
+// resultIgnored 는 copy 의 반환값 (복사한 개수) 을 버리는지 본다
+func resultIgnored(path []ast.Node) bool {
+	if len(path) < 2 {
+		return false
+	}
+
+	switch parent := path[1].(type) {
+	case *ast.ExprStmt:
+		return true
+	case *ast.AssignStmt:
+		if len(parent.Lhs) == 1 {
+			id, ok := parent.Lhs[0].(*ast.Ident)
+			return ok && id.Name == "_"
+		}
+	}
+
+	return false
+}
+
+/*
+fixes 는 dst 의 선언을 goodCopy 처럼 바꾸는 수정안을 만든다
+
+	var dst []int               dst := make([]int, len(src))
+	copy(dst, src)        ->    copy(dst, src)
+
+	var dst []int               dst := slices.Clone(src)
+	copy(dst, src)        ->    (copy 를 지운다, 반환값을 버리고 타입이 같을 때)
+
+dst 선언이 copy 와 같은 블록에 있고, 선언 이후 다른 대입이나 사용이 없어야 한다.
+*/
+func (f *function) fixes(path []ast.Node, call *ast.CallExpr, v *types.Var, ignored bool) []analysis.SuggestedFix {
+	block, stmt := enclosingStmt(path)
+	if block == nil {
+		return nil
+	}
+
+	src := call.Args[1]
+	decl, typ := f.findDecl(block, stmt, v)
+	if decl == nil || !pure(src) || !f.stableSince(src, decl, call) || f.assignedElsewhere(v, decl) {
+		return nil
+	}
+
+	// 선언과 copy 사이에 dst 를 쓰면 길이가 바뀐 뒤의 동작이 달라진다
+	if f.usedBetween(v, decl.End(), call.Pos()) {
+		return nil
+	}
+
+	if typ == "" {
+		typ = f.typeString(v.Type())
+	}
+
+	fixes := []analysis.SuggestedFix{{
+		Message: fixMake,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     decl.Pos(),
+			End:     decl.End(),
+			NewText: fmt.Appendf(nil, "%s := make(%s, len(%s))", v.Name(), typ, types.ExprString(src)),
+		}},
+	}}
+
+	if ignored && stmt == path[1] {
+		if clone := f.cloneFix(block, decl, stmt, v, src); clone != nil {
+			fixes = append(fixes, *clone)
+		}
+	}
+
+	return fixes
+}
+
+func (f *function) cloneFix(block *ast.BlockStmt, decl ast.Node, stmt ast.Stmt, v *types.Var, src ast.Expr) *analysis.SuggestedFix {
+	if !types.Identical(f.pass.TypesInfo.TypeOf(src), v.Type()) {
+		return nil
+	}
+
+	file := passutil.File(f.pass, stmt)
+	if fv := f.pass.TypesInfo.FileVersions[file]; fv != "" && version.Compare(fv, "go1.21") < 0 {
+		return nil
+	}
+
+	name, importEdit, ok := passutil.ImportSlices(f.pass, file, stmt.Pos())
+	if !ok {
+		return nil
+	}
+
+	// copy 문장이 있는 줄을 (뒤의 주석까지) 지운다
+	tf := f.pass.Fset.File(stmt.Pos())
+	line := tf.Line(stmt.Pos())
+	if tf.Line(stmt.End()) != line || f.shareLine(block, stmt, line) {
+		return nil
+	}
+
+	end := token.Pos(tf.Base() + tf.Size())
+	if line < tf.LineCount() {
+		end = tf.LineStart(line + 1)
+	}
+
+	edits := []analysis.TextEdit{
+		{
+			Pos:     decl.Pos(),
+			End:     decl.End(),
+			NewText: fmt.Appendf(nil, "%s := %s.Clone(%s)", v.Name(), name, types.ExprString(src)),
+		},
+		{Pos: tf.LineStart(line), End: end},
+	}
+	if importEdit != nil {
+		edits = append(edits, *importEdit)
+	}
+
+	return &analysis.SuggestedFix{Message: fixClone, TextEdits: edits}
+}
+
+// shareLine 은 같은 줄에 stmt 말고 다른 문장이 있는지 본다
+func (f *function) shareLine(block *ast.BlockStmt, stmt ast.Stmt, line int) bool {
+	for _, s := range block.List {
+		if s != stmt && (f.pass.Fset.Position(s.Pos()).Line == line || f.pass.Fset.Position(s.End()).Line == line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enclosingStmt 는 copy 호출을 감싸는 가장 안쪽 블록과 그 블록 바로 아래의 문장이다
+func enclosingStmt(path []ast.Node) (*ast.BlockStmt, ast.Stmt) {
+	for i := 1; i < len(path); i++ {
+		switch n := path[i].(type) {
+		case *ast.FuncLit:
+			return nil, nil
+		case *ast.BlockStmt:
+			stmt, _ := path[i-1].(ast.Stmt)
+			return n, stmt
+		}
+	}
+
+	return nil, nil
+}
+
+// findDecl 은 stmt 보다 앞에서 v 를 선언한 문장과 (있으면) 선언에 쓴 타입이다
+func (f *function) findDecl(block *ast.BlockStmt, stmt ast.Stmt, v *types.Var) (ast.Stmt, string) {
+	for _, s := range block.List {
+		if s == stmt {
+			break
+		}
+
+		switch s := s.(type) {
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE || len(s.Lhs) != 1 || len(s.Rhs) != 1 {
+				continue
+			}
+			if id, ok := s.Lhs[0].(*ast.Ident); ok && f.pass.TypesInfo.Defs[id] == v {
+				return f.fresh(s, s.Rhs[0])
+			}
+		case *ast.DeclStmt:
+			gen, ok := s.Decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+				continue
+			}
+
+			spec := gen.Specs[0].(*ast.ValueSpec)
+			if len(spec.Names) != 1 || f.pass.TypesInfo.Defs[spec.Names[0]] != v {
+				continue
+			}
+
+			switch {
+			case spec.Type != nil:
+				return s, f.render(spec.Type)
+			case len(spec.Values) == 1:
+				return f.fresh(s, spec.Values[0])
+			}
+		}
+	}
+
+	return nil, ""
+}
+
+/*
+fresh 는 새로 할당하는 초기값일 때만 decl 과 그 타입 표현식을 돌려준다.
+dst := arr[1:3] 처럼 다른 배열을 가리키면 make 로 바꾸면 안된다.
+
+	[]T{}, make([]T, n)  ->  []T
+	nil                  ->  "" (변수의 타입을 쓴다)
+*/
+func (f *function) fresh(decl ast.Stmt, init ast.Expr) (ast.Stmt, string) {
+	switch e := ast.Unparen(init).(type) {
+	case *ast.CompositeLit:
+		if e.Type != nil {
+			return decl, f.render(e.Type)
+		}
+	case *ast.CallExpr:
+		if passutil.IsBuiltin(f.pass, e.Fun, "make") {
+			return decl, f.render(e.Args[0])
+		}
+	default:
+		if f.pass.TypesInfo.Types[e].IsNil() {
+			return decl, ""
+		}
+	}
+
+	return nil, ""
+}
+
+// assignedElsewhere 는 함수 안에서 v 를 decl 말고 다른 곳에서 대입하는지 본다
+func (f *function) assignedElsewhere(v *types.Var, decl ast.Stmt) bool {
+	found := false
+	ast.Inspect(f.body, func(n ast.Node) bool {
+		if a, ok := n.(*ast.AssignStmt); ok && ast.Stmt(a) != decl {
+			for _, l := range a.Lhs {
+				if id, ok := l.(*ast.Ident); ok && f.pass.TypesInfo.ObjectOf(id) == v {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+
+	return found
+}
+
+func (f *function) usedBetween(v *types.Var, from, to token.Pos) bool {
+	found := false
+	ast.Inspect(f.body, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Pos() > from && id.Pos() < to && f.pass.TypesInfo.Uses[id] == v {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
+
+// stableSince 는 src 의 변수가 decl 보다 먼저 선언되었고 decl 과 copy 사이에서 대입되지 않는지 본다.
+// 수정안은 len(src) 를 decl 위치에서 계산한다.
+func (f *function) stableSince(src ast.Expr, decl ast.Stmt, call *ast.CallExpr) bool {
+	vars := map[types.Object]bool{}
+	ok := true
+	ast.Inspect(src, func(n ast.Node) bool {
+		id, isIdent := n.(*ast.Ident)
+		if !isIdent {
+			return true
+		}
+
+		obj := f.pass.TypesInfo.Uses[id]
+		if obj == nil || obj.Parent() == f.pass.Pkg.Scope() || !obj.Pos().IsValid() {
+			return true
+		}
+		if obj.Pos() > decl.Pos() {
+			ok = false
+		}
+		vars[obj] = true
+		return ok
+	})
+	if !ok {
+		return false
+	}
+
+	ast.Inspect(f.body, func(n ast.Node) bool {
+		a, isAssign := n.(*ast.AssignStmt)
+		if !isAssign || a.Pos() < decl.End() || a.Pos() > call.Pos() {
+			return ok
+		}
+
+		for _, l := range a.Lhs {
+			if id, isIdent := l.(*ast.Ident); isIdent && vars[f.pass.TypesInfo.ObjectOf(id)] {
+				ok = false
+			}
+		}
+		return ok
+	})
+
+	return ok
+}
+
+/*
+untracked 는 body 가 아닌 곳에서 바뀔 수 있어 길이를 추적하지 않는 변수다
+
+	p := &s                      // 포인터로 바뀔 수 있다
+	func() { s = nil }()         // 함수 리터럴 안에서 대입
+	for _, s := range xss {}     // range 변수
+*/
+func untracked(pass *analysis.Pass, body *ast.BlockStmt) map[*types.Var]bool {
+	out := map[*types.Var]bool{}
+	mark := func(e ast.Expr) {
+		if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+			if v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok {
+				out[v] = true
+			}
+		}
+	}
+
+	var walk func(n ast.Node, inLit bool)
+	walk = func(n ast.Node, inLit bool) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				walk(n.Body, true)
+				return false
+			case *ast.UnaryExpr:
+				if n.Op == token.AND {
+					mark(n.X)
+				}
+			case *ast.RangeStmt:
+				if n.Key != nil {
+					mark(n.Key)
+				}
+				if n.Value != nil {
+					mark(n.Value)
+				}
+			case *ast.AssignStmt:
+				if inLit {
+					for _, l := range n.Lhs {
+						mark(l)
+					}
+				}
+			}
+			return true
+		})
+	}
+	walk(body, false)
+
+	return out
+}
+
+// pure 는 식을 선언 위치로 옮겨도 같은 값인지 본다 (변수, 필드, 상수만 허용)
+func pure(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.SelectorExpr:
+		return pure(x.X)
+	case *ast.ParenExpr:
+		return pure(x.X)
+	case *ast.StarExpr:
+		return pure(x.X)
+	}
+
+	return false
+}
+
+func (f *function) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == f.pass.Pkg {
+			return ""
+		}
+		return p.Name()
+	})
+}
+
+func (f *function) render(e ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, f.pass.Fset, e); err != nil {
+		return types.ExprString(e)
+	}
+
+	return buf.String()
+}
+
+func identExprs(ids []*ast.Ident) []ast.Expr {
+	out := make([]ast.Expr, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, id)
+	}
+
+	return out
+}
+
+func constInt(pass *analysis.Pass, e ast.Expr) (int64, bool) {
+	tv := pass.TypesInfo.Types[e]
+	if tv.Value == nil {
+		return 0, false
+	}
+
+	return constant.Int64Val(constant.ToInt(tv.Value))
+}
+
+// arrayType 은 배열 (또는 배열 포인터) 타입이다. 길이가 타입에 있다.
+func arrayType(t types.Type) (*types.Array, bool) {
+	if t == nil {
+		return nil, false
+	}
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	a, ok := t.Underlying().(*types.Array)
+	return a, ok
+}
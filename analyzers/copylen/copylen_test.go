@@ -0,0 +1,13 @@
+package copylen_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/zkfmapf123/100/analyzers/copylen"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), copylen.Analyzer, "a")
+}
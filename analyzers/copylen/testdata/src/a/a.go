@@ -0,0 +1,134 @@
+package a
+
+import "fmt"
+
+// ❌ 18.go 의 badCopy
+func badCopy() []int {
+	src := []int{0, 1, 2}
+	var dst []int
+
+	copy(dst, src) // want `copy\(dst, src\) copies nothing: dst has length 0 and the result is ignored; use make\(\[\]int, len\(src\)\)`
+	return dst
+}
+
+// ✅ 18.go 의 goodCopy
+func goodCopy() []int {
+	src := []int{0, 1, 2}
+	dst := make([]int, len(src))
+
+	copy(dst, src)
+	return dst
+}
+
+func shorter() []string {
+	src := []string{"a", "b", "c"}
+	dst := make([]string, 2)
+
+	copy(dst, src) // want `copy\(dst, src\) copies 2 of 3 elements: dst has length 2 and the result is ignored; use make\(\[\]string, len\(src\)\)`
+	return dst
+}
+
+// 반환값을 쓰면 slices.Clone 으로 바꾸지 않는다
+func counted(src []byte) int {
+	dst := []byte{}
+
+	n := copy(dst, src) // want `copy\(dst, src\) copies nothing: dst has length 0; use make\(\[\]byte, len\(src\)\)`
+	fmt.Println(dst)
+	return n
+}
+
+func fromString() []byte {
+	var b []byte
+
+	copy(b, "hello") // want `copy\(b, "hello"\) copies nothing: b has length 0 and the result is ignored; use make\(\[\]byte, len\("hello"\)\)`
+	return b
+}
+
+// 두 분기 모두 길이가 0 이다
+func bothBranches(src []int, small bool) []int {
+	var dst []int
+	if small {
+		dst = nil
+	} else {
+		dst = src[:0]
+	}
+
+	_ = copy(dst, src) // want `copy\(dst, src\) copies nothing: dst has length 0 and the result is ignored`
+	return dst
+}
+
+func subslice() {
+	var arr [4]int
+	src := []int{1, 2, 3}
+	dst := arr[1:3]
+
+	copy(dst, src) // want `copy\(dst, src\) copies 2 of 3 elements: dst has length 2`
+
+	grown := append(dst, 0)
+	copy(grown, src)
+}
+
+// ✅ 분기에 따라 길이가 다르다
+func maybe(src []int, ok bool) []int {
+	var dst []int
+	if ok {
+		dst = make([]int, len(src))
+	}
+
+	copy(dst, src)
+	return dst
+}
+
+// ✅ loop 안에서 늘어난다
+func grown(src []int) []int {
+	var dst []int
+	for range src {
+		dst = append(dst, 0)
+	}
+
+	copy(dst, src)
+	return dst
+}
+
+// ✅ 다시 할당한다
+func reassigned(src []int) []int {
+	var dst []int
+	dst = make([]int, len(src))
+
+	copy(dst, src)
+	return dst
+}
+
+// ✅ 주소를 꺼내면 추적하지 않는다
+func pointer(src []int, fill func(*[]int)) []int {
+	var dst []int
+	fill(&dst)
+
+	copy(dst, src)
+	return dst
+}
+
+// ✅ src 도 비어있다
+func empty() []int {
+	var src, dst []int
+
+	copy(dst, src)
+	return dst
+}
+
+// ✅ 함수 리터럴 안에서 바뀐다
+func closure(src []int) []int {
+	var dst []int
+	alloc := func() { dst = make([]int, len(src)) }
+	alloc()
+
+	copy(dst, src)
+	return dst
+}
+
+func literal(src []int) {
+	go func() {
+		var dst []int
+		copy(dst, src) // want `copy\(dst, src\) copies nothing`
+	}()
+}
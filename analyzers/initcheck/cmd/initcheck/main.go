@@ -0,0 +1,12 @@
+// initcheck 는 initcheck analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/initcheck"
+)
+
+func main() {
+	singlechecker.Main(initcheck.Analyzer)
+}
@@ -0,0 +1,58 @@
+/*
+initorder 는 모듈 안의 패키지가 어떤 순서로 초기화되는지와
+각 init 함수가 하는 일을 출력한다.
+
+	go run ./analyzers/initcheck/cmd/initorder ./...
+	go run ./analyzers/initcheck/cmd/initorder -std ./...
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/zkfmapf123/100/analyzers/initcheck"
+)
+
+func main() {
+	std := flag.Bool("std", false, "include packages outside the main module")
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedModule | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		log.Fatal("패키지 로드 실패:", err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		os.Exit(1)
+	}
+
+	inModule := func(p *packages.Package) bool {
+		return *std || (p.Module != nil && p.Module.Main)
+	}
+
+	for i, pi := range initcheck.Report(pkgs, inModule) {
+		fmt.Printf("%3d. %s\n", i+1, pi.Package.PkgPath)
+
+		for _, fn := range pi.Inits {
+			fmt.Printf("       init %s\n", fn.Position)
+			for _, f := range fn.Findings {
+				fmt.Printf("         - %s: %s\n", f.Category, f.What)
+			}
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package initcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+/*
+3.go 의 init 처럼 부수효과가 있는 init 함수를 찾는다.
+
+init 함수가 하는 일을 아래 분류로 나누고, -allow 로 허용하지 않은 분류가 있으면 보고한다.
+
+  - io      : 파일, 환경변수, 표준 입출력 등 os/io 패키지 호출
+  - network : net, database/sql 호출과 DB/커넥션 생성자 (*sql.DB, net.Conn 등을 돌려주거나 NewDatabase, NewRedisClient, Open*, Connect*, Dial*)
+  - global  : 패키지 전역 변수 변경 (http.DefaultClient 처럼 다른 패키지의 전역 변수도)
+  - print   : fmt.Print*, log.Print*, println
+  - panic   : panic, log.Fatal*, os.Exit
+*/
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	c := &checker{allow: categorySet{}}
+
+	a := &analysis.Analyzer{
+		Name:     "initcheck",
+		Doc:      "reports init functions that perform I/O, network/DB construction, global mutation, printing or panics",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      c.run,
+	}
+
+	a.Flags.Var(c.allow, "allow", "comma separated categories allowed in init: io,network,global,print,panic")
+
+	return a
+}
+
+// Category 는 init 함수가 하는 일의 분류다
+type Category int
+
+const (
+	IO Category = iota
+	Network
+	Global
+	Print
+	Panic
+
+	numCategories
+)
+
+var categoryNames = [numCategories]struct{ key, label string }{
+	IO:      {"io", "I/O"},
+	Network: {"network", "network/DB construction"},
+	Global:  {"global", "global mutation"},
+	Print:   {"print", "printing"},
+	Panic:   {"panic", "panic/exit"},
+}
+
+func (c Category) String() string {
+	return categoryNames[c].label
+}
+
+type categorySet map[Category]bool
+
+func (s categorySet) String() string {
+	var keys []string
+	for c := Category(0); c < numCategories; c++ {
+		if s[c] {
+			keys = append(keys, categoryNames[c].key)
+		}
+	}
+
+	return strings.Join(keys, ",")
+}
+
+func (s categorySet) Set(v string) error {
+	for _, key := range strings.Split(v, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		found := false
+		for c := Category(0); c < numCategories; c++ {
+			if categoryNames[c].key == key {
+				s[c], found = true, true
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("unknown init category %q", key)
+		}
+	}
+
+	return nil
+}
+
+// Finding 은 init 함수 안에서 분류가 처음 발견된 위치다
+type Finding struct {
+	Category Category
+	Pos      token.Pos
+	What     string
+}
+
+type checker struct {
+	allow categorySet
+}
+
+func (c *checker) run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		fn := n.(*ast.FuncDecl)
+		if !IsInit(fn) {
+			return
+		}
+
+		var (
+			labels  []string
+			related []analysis.RelatedInformation
+		)
+		for _, f := range Classify(pass.TypesInfo, pass.Pkg, fn) {
+			if c.allow[f.Category] {
+				continue
+			}
+
+			labels = append(labels, f.Category.String())
+			related = append(related, analysis.RelatedInformation{
+				Pos:     f.Pos,
+				Message: fmt.Sprintf("%s: %s", f.Category, f.What),
+			})
+		}
+
+		if len(labels) == 0 {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     fn.Name.Pos(),
+			End:     fn.Name.End(),
+			Message: fmt.Sprintf("init performs %s; call an explicit setup function instead", strings.Join(labels, ", ")),
+			Related: related,
+		})
+	})
+
+	return nil, nil
+}
+
+// IsInit 은 패키지 초기화 함수인지 확인한다
+func IsInit(fn *ast.FuncDecl) bool {
+	return fn.Recv == nil && fn.Name.Name == "init" && fn.Body != nil
+}
+
+// Classify 는 init 함수 본문을 훑어서 분류별로 처음 발견된 위치를 돌려준다 (분류 순서대로)
+func Classify(info *types.Info, pkg *types.Package, fn *ast.FuncDecl) []Finding {
+	var found [numCategories]*Finding
+	mark := func(c Category, pos token.Pos, what string) {
+		if found[c] == nil {
+			found[c] = &Finding{Category: c, Pos: pos, What: what}
+		}
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if c, what, ok := classifyCall(info, pkg, n); ok {
+				mark(c, n.Pos(), what)
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if v := globalRoot(info, pkg, lhs); v != nil {
+					mark(Global, lhs.Pos(), varName(pkg, v))
+				}
+			}
+		case *ast.IncDecStmt:
+			if v := globalRoot(info, pkg, n.X); v != nil {
+				mark(Global, n.X.Pos(), varName(pkg, v))
+			}
+		}
+		return true
+	})
+
+	var findings []Finding
+	for _, f := range found {
+		if f != nil {
+			findings = append(findings, *f)
+		}
+	}
+
+	return findings
+}
+
+var (
+	ioPackages = map[string]bool{
+		"os": true, "io": true, "io/ioutil": true, "io/fs": true, "bufio": true,
+	}
+
+	// filepath.Join 같은 문자열 계산은 빼고 파일 시스템을 읽는 함수만 I/O 로 본다
+	ioFuncs = map[string]map[string]bool{
+		"path/filepath": {"Walk": true, "WalkDir": true, "Glob": true, "EvalSymlinks": true, "Abs": true},
+	}
+
+	networkPackages = map[string]bool{
+		"net": true, "net/http": true, "net/rpc": true, "net/smtp": true, "database/sql": true, "crypto/tls": true,
+	}
+
+	// 생성자가 돌려주면 외부 자원으로 보는 타입
+	resourceTypes = map[string]bool{
+		"database/sql.DB": true, "database/sql.Conn": true, "net/http.Client": true, "crypto/tls.Conn": true,
+		"net.Conn": true, "net.Listener": true, "net.PacketConn": true,
+	}
+
+	// NewDatabase, NewRedisClient 처럼 이름으로 외부 자원을 만드는 생성자를 알아본다 (camel case 단어 단위)
+	resourceVerbs = map[string]bool{"open": true, "connect": true, "dial": true}
+	resourceNouns = map[string]bool{"db": true, "database": true, "conn": true, "connection": true, "client": true, "session": true}
+)
+
+/*
+resourceConstructor 는 외부 자원을 만드는 생성자인지 본다
+
+	func NewStore() *sql.DB          // ✅ 돌려주는 타입
+	func NewDatabase(string) *Database, OpenLedger(), DialRedis() // ✅ 이름
+	func NewFeedback() *Feedback     // ❌ "db" 는 단어가 아니다
+	func NewPool(n int) *Pool        // ❌ worker pool
+*/
+func resourceConstructor(fn *types.Func) bool {
+	results := fn.Type().(*types.Signature).Results()
+	for i := 0; i < results.Len(); i++ {
+		t := results.At(i).Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		if named, ok := types.Unalias(t).(*types.Named); ok && named.Obj().Pkg() != nil &&
+			resourceTypes[named.Obj().Pkg().Path()+"."+named.Obj().Name()] {
+			return true
+		}
+	}
+
+	words := camelWords(fn.Name())
+	if resourceVerbs[words[0]] {
+		return true
+	}
+	if words[0] != "new" {
+		return false
+	}
+	for _, w := range words[1:] {
+		if resourceNouns[w] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// camelWords 는 NewHTTPClient 를 new, http, client 로 나눈다
+func camelWords(name string) []string {
+	var words []string
+	rs := []rune(name)
+	start := 0
+	for i := 1; i < len(rs); i++ {
+		upper := unicode.IsUpper(rs[i])
+		prevUpper := unicode.IsUpper(rs[i-1])
+		nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+		if upper && (!prevUpper || nextLower) {
+			words = append(words, strings.ToLower(string(rs[start:i])))
+			start = i
+		}
+	}
+
+	return append(words, strings.ToLower(string(rs[start:])))
+}
+
+func classifyCall(info *types.Info, pkg *types.Package, call *ast.CallExpr) (Category, string, bool) {
+	switch fn := typeutil.Callee(info, call).(type) {
+	case *types.Builtin:
+		switch fn.Name() {
+		case "panic":
+			return Panic, "panic", true
+		case "print", "println":
+			return Print, fn.Name(), true
+		}
+	case *types.Func:
+		if fn.Pkg() == nil {
+			return 0, "", false
+		}
+
+		path, name := fn.Pkg().Path(), fn.Name()
+		qualified := fn.Pkg().Name() + "." + name
+
+		switch {
+		case path == "fmt" && strings.HasPrefix(name, "Print"):
+			return Print, qualified, true
+		case path == "fmt" && strings.HasPrefix(name, "Fprint"):
+			return IO, qualified, true
+		case path == "log" && (strings.HasPrefix(name, "Fatal") || strings.HasPrefix(name, "Panic")):
+			return Panic, qualified, true
+		case path == "log" && strings.HasPrefix(name, "Print"):
+			return Print, qualified, true
+		case path == "os" && name == "Exit":
+			return Panic, qualified, true
+		case ioPackages[path], ioFuncs[path][name]:
+			return IO, qualified, true
+		case networkPackages[path]:
+			return Network, qualified, true
+		case (fn.Pkg() == pkg || !isStd(path)) && resourceConstructor(fn):
+			return Network, name, true
+		}
+	}
+
+	return 0, "", false
+}
+
+// globalRoot 는 config["host"], cfg.port 처럼 대입 대상의 뿌리가 패키지 전역 변수인지 확인한다
+func globalRoot(info *types.Info, pkg *types.Package, e ast.Expr) *types.Var {
+	for {
+		switch x := ast.Unparen(e).(type) {
+		case *ast.IndexExpr:
+			e = x.X
+		case *ast.SelectorExpr:
+			if qualified(info, x) {
+				return importedVar(info, x)
+			}
+			e = x.X
+		case *ast.StarExpr:
+			e = x.X
+		case *ast.Ident:
+			v, ok := info.Uses[x].(*types.Var)
+			if !ok || v.Pkg() != pkg || v.Parent() != pkg.Scope() {
+				return nil
+			}
+			return v
+		default:
+			return nil
+		}
+	}
+}
+
+// qualified 는 http.DefaultClient 처럼 다른 패키지 이름으로 고른 식인지 본다
+func qualified(info *types.Info, sel *ast.SelectorExpr) bool {
+	id, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	_, ok = info.Uses[id].(*types.PkgName)
+	return ok
+}
+
+// importedVar 는 qualified 식이 가리키는 다른 패키지의 전역 변수다 (함수, 상수면 nil)
+func importedVar(info *types.Info, sel *ast.SelectorExpr) *types.Var {
+	v, ok := info.Uses[sel.Sel].(*types.Var)
+	if !ok || v.Pkg() == nil || v.Pkg().Scope().Lookup(v.Name()) != v {
+		return nil
+	}
+
+	return v
+}
+
+// varName 은 다른 패키지의 전역 변수를 http.DefaultClient 처럼 쓴다
+func varName(pkg *types.Package, v *types.Var) string {
+	if v.Pkg() == pkg {
+		return v.Name()
+	}
+
+	return v.Pkg().Name() + "." + v.Name()
+}
+
+func isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
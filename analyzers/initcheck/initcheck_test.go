@@ -0,0 +1,38 @@
+package initcheck_test
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/zkfmapf123/100/analyzers/initcheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), initcheck.Analyzer, "a")
+}
+
+func TestInitOrder(t *testing.T) {
+	var (
+		fmtPkg = &packages.Package{PkgPath: "fmt"}
+		db     = &packages.Package{PkgPath: "example.com/app/db", Imports: map[string]*packages.Package{"fmt": fmtPkg}}
+		config = &packages.Package{PkgPath: "example.com/app/config"}
+		app    = &packages.Package{PkgPath: "example.com/app", Imports: map[string]*packages.Package{
+			"example.com/app/db":     db,
+			"example.com/app/config": config,
+		}}
+	)
+
+	var got []string
+	for _, p := range initcheck.InitOrder([]*packages.Package{app}) {
+		got = append(got, p.PkgPath)
+	}
+
+	// import path 순으로 보되, import 가 모두 끝난 패키지부터 초기화한다
+	want := []string{"example.com/app/config", "fmt", "example.com/app/db", "example.com/app"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("InitOrder = %v, want %v", got, want)
+	}
+}
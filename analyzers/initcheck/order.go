@@ -0,0 +1,121 @@
+package initcheck
+
+import (
+	"go/ast"
+	"path/filepath"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// PackageInit 은 초기화 순서 리포트의 한 줄이다
+type PackageInit struct {
+	Package *packages.Package
+	Inits   []InitFunc
+}
+
+// InitFunc 는 패키지 안의 init 함수 하나와 그 분류다
+type InitFunc struct {
+	Position string
+	Findings []Finding
+}
+
+/*
+InitOrder 는 Go 명세의 패키지 초기화 순서를 그대로 계산한다.
+
+import path 로 정렬한 패키지 목록에서, import 한 패키지가 모두 초기화된
+첫번째 패키지를 하나씩 골라 초기화한다 (Go 1.21 부터 정의된 순서).
+같은 패키지 안의 init 은 파일 이름 순서, 파일 안에서는 선언 순서로 실행된다.
+*/
+func InitOrder(roots []*packages.Package) []*packages.Package {
+	var all []*packages.Package
+	packages.Visit(roots, nil, func(p *packages.Package) {
+		all = append(all, p)
+	})
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].PkgPath < all[j].PkgPath
+	})
+
+	done := make(map[*packages.Package]bool, len(all))
+	order := make([]*packages.Package, 0, len(all))
+
+	for len(order) < len(all) {
+		progressed := false
+
+		for _, p := range all {
+			if done[p] || !importsDone(p, done) {
+				continue
+			}
+
+			done[p] = true
+			order = append(order, p)
+			progressed = true
+			break
+		}
+
+		// import cycle 은 컴파일 단계에서 막히지만, 무한루프는 피한다
+		if !progressed {
+			break
+		}
+	}
+
+	return order
+}
+
+func importsDone(p *packages.Package, done map[*packages.Package]bool) bool {
+	for _, imp := range p.Imports {
+		if !done[imp] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Report 는 초기화 순서대로 패키지와 그 init 함수 분류를 돌려준다.
+// filter 가 false 를 돌려주는 패키지는 순서 계산에만 쓰이고 결과에서는 빠진다.
+func Report(roots []*packages.Package, filter func(*packages.Package) bool) []PackageInit {
+	var report []PackageInit
+
+	for _, p := range InitOrder(roots) {
+		if filter != nil && !filter(p) {
+			continue
+		}
+
+		report = append(report, PackageInit{Package: p, Inits: initFuncs(p)})
+	}
+
+	return report
+}
+
+func initFuncs(p *packages.Package) []InitFunc {
+	if p.TypesInfo == nil {
+		return nil
+	}
+
+	files := make([]*ast.File, len(p.Syntax))
+	copy(files, p.Syntax)
+
+	// go build 는 파일 이름 순서로 컴파일러에 넘긴다
+	sort.Slice(files, func(i, j int) bool {
+		return filepath.Base(p.Fset.File(files[i].Pos()).Name()) < filepath.Base(p.Fset.File(files[j].Pos()).Name())
+	})
+
+	var inits []InitFunc
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !IsInit(fn) {
+				continue
+			}
+
+			inits = append(inits, InitFunc{
+				Position: p.Fset.Position(fn.Pos()).String(),
+				Findings: Classify(p.TypesInfo, p.Types, fn),
+			})
+		}
+	}
+
+	return inits
+}
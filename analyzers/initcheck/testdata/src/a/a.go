@@ -0,0 +1,95 @@
+package a
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+var (
+	config map[string]string
+	db     *Database
+)
+
+type Database struct {
+	connection string
+}
+
+func NewDatabase(conn string) *Database {
+	return &Database{connection: conn}
+}
+
+// 3.go 의 init 과 같은 형태
+func init() { // want `init performs network/DB construction, global mutation, printing; call an explicit setup function instead`
+	config = make(map[string]string)
+	config["host"] = "localhost"
+	config["port"] = "5432"
+
+	db = NewDatabase("connection string")
+
+	fmt.Println("데이터베이스 초기화 완료")
+}
+
+func init() { // want `init performs I/O, panic/exit`
+	if _, err := os.ReadFile("config.json"); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// 지역 변수만 다루는 init 은 보고하지 않는다
+func init() {
+	local := map[string]string{}
+	local["host"] = "localhost"
+	_ = local
+}
+
+// 경로 계산은 파일 시스템을 건드리지 않는다
+func init() {
+	dir := filepath.Join("etc", "app")
+	_ = filepath.Base(dir)
+}
+
+func init() { // want `init performs I/O`
+	_, _ = filepath.Glob("*.json")
+}
+
+type Feedback struct{}
+
+func NewFeedback() *Feedback { return &Feedback{} }
+
+type Pool struct{ workers int }
+
+func NewPool(n int) *Pool { return &Pool{workers: n} }
+
+// 이름이 아니라 돌려주는 타입으로 DB 생성자임을 안다
+func NewStore() (*sql.DB, error) { return nil, nil }
+
+// ✅ 이름에 db, pool 이 들어 있어도 외부 자원이 아니다
+func init() {
+	f := NewFeedback()
+	p := NewPool(4)
+	_, _ = f, p
+}
+
+func init() { // want `init performs network/DB construction`
+	s, _ := NewStore()
+	_ = s
+}
+
+// 다른 패키지의 전역 변수도 바꾸는 것이다
+func init() { // want `init performs global mutation`
+	http.DefaultClient = &http.Client{}
+}
+
+func init() { // want `init performs global mutation`
+	http.DefaultClient.Timeout = 0
+}
+
+func setupConfig() error {
+	config = make(map[string]string)
+	fmt.Println("setup")
+	return nil
+}
@@ -0,0 +1,72 @@
+// Package passutil 은 여러 analyzer 가 같이 쓰는 analysis.Pass 도우미다
+package passutil
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// File 은 n 이 들어 있는 파일이다
+func File(pass *analysis.Pass, n ast.Node) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= n.Pos() && n.Pos() < file.FileEnd {
+			return file
+		}
+	}
+
+	return nil
+}
+
+// IsBuiltin 은 fun 이 name 이라는 builtin 함수 (make, append, copy ...) 인지 본다
+func IsBuiltin(pass *analysis.Pass, fun ast.Expr, name string) bool {
+	id, ok := ast.Unparen(fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	b, ok := pass.TypesInfo.Uses[id].(*types.Builtin)
+	return ok && b.Name() == name
+}
+
+// ImportSlices 는 pos 에서 slices 패키지를 쓸 이름과 (없으면) import 를 추가하는 편집을 돌려준다
+func ImportSlices(pass *analysis.Pass, file *ast.File, pos token.Pos) (string, *analysis.TextEdit, bool) {
+	for _, spec := range file.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path != "slices" {
+			continue
+		}
+
+		switch {
+		case spec.Name == nil:
+			return "slices", nil, true
+		case spec.Name.Name == "_" || spec.Name.Name == ".":
+			return "", nil, false
+		default:
+			return spec.Name.Name, nil, true
+		}
+	}
+
+	// 지역 이름 slices 가 패키지를 가리면 쓸 수 없다
+	if scope := pass.Pkg.Scope().Innermost(pos); scope != nil {
+		if _, obj := scope.LookupParent("slices", pos); obj != nil {
+			return "", nil, false
+		}
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		if gen.Lparen.IsValid() {
+			return "slices", &analysis.TextEdit{Pos: gen.Lparen + 1, End: gen.Lparen + 1, NewText: []byte("\n\t\"slices\"")}, true
+		}
+		return "slices", &analysis.TextEdit{Pos: gen.End(), End: gen.End(), NewText: []byte("\nimport \"slices\"")}, true
+	}
+
+	return "slices", &analysis.TextEdit{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte("\n\nimport \"slices\"")}, true
+}
@@ -0,0 +1,12 @@
+// loopptr 는 loopptr analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/loopptr"
+)
+
+func main() {
+	singlechecker.Main(loopptr.Analyzer)
+}
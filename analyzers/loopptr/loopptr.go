@@ -0,0 +1,533 @@
+package loopptr
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"go/version"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+/*
+22.go 처럼 반복문 안에서 만든 pointer 를 map, slice, 필드에 저장하는 코드를 찾는다.
+
+	for _, c := range cs {
+		s.m[c.ID] = &c      // storeCustomers_1
+	}
+	for _, c := range cs {
+		current := c
+		s.m[c.ID] = &current // storeCustomer_2
+	}
+	for i := range cs {
+		s.m[cs[i].ID] = &cs[i] // storeCustomers_3
+	}
+
+go1.22 부터 반복문 변수는 반복마다 새로 만들어진다. 같은 코드라도 go.mod 의 go 버전 (또는 파일의 //go:build go1.N) 에 따라 뜻이 다르다.
+
+	go1.21 이하  &c 는 모든 반복이 같이 쓰는 변수 하나다. 저장한 pointer 가 모두 마지막 원소를 가리킨다 -> c := c 로 복사하는 수정안
+	go1.22 이상  &c 는 그 반복의 원소 복사본이다. 버그는 아니지만 cs 를 바꾸지 못한다는 것을 알려준다.
+	             current := c 는 이제 필요 없는 복사다 (go1.21 이하에서는 이것이 올바른 방법이라 보고하지 않는다)
+
+&cs[i] 는 복사가 아니라 호출한 쪽의 slice 원소 자체를 가리킨다. cs 가 파라미터이면 버전과 상관없이 보고한다.
+c := &cs[i] 처럼 반복문 안의 지역 변수를 거쳐서 저장해도 찾는다.
+*/
+var Analyzer = &analysis.Analyzer{
+	Name:     "loopptr",
+	Doc:      "reports pointers to loop variables, their copies and caller slice elements stored into maps, slices or fields, explaining the Go version's loop variable semantics",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// perIteration 은 반복문 변수가 반복마다 새로 만들어지는 첫 버전이다
+const perIteration = "go1.22"
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	fixed := map[*types.Var]bool{}
+	insp.WithStack([]ast.Node{(*ast.RangeStmt)(nil), (*ast.ForStmt)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if push {
+			c := &loopCheck{pass: pass, loop: n, params: params(pass, stack), fixed: fixed}
+			c.check()
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+// loopVar 는 반복문 머리에서 := 로 만든 변수다
+type loopVar struct {
+	v *types.Var
+	// value 는 range 의 값 변수인지다 (원소의 복사본)
+	value bool
+}
+
+type loopCheck struct {
+	pass   *analysis.Pass
+	loop   ast.Node
+	body   *ast.BlockStmt
+	x      ast.Expr // range 대상 (for 문이면 nil)
+	vars   map[*types.Var]loopVar
+	params map[*types.Var]bool
+
+	// locals 는 본문에서 x := e 로 한번만 정한 지역 변수와 그 값이다
+	locals map[*types.Var]ast.Expr
+	fixed  map[*types.Var]bool
+}
+
+// store 는 pointer 가 저장되는 곳 하나다
+type store struct {
+	value ast.Expr
+	sink  ast.Expr
+	// appended 는 append(sink, value) 인지다
+	appended bool
+	// nested 는 본문 안의 다른 반복문 안에서 저장하는지다
+	nested bool
+}
+
+func (c *loopCheck) check() {
+	c.vars = map[*types.Var]loopVar{}
+	switch loop := c.loop.(type) {
+	case *ast.RangeStmt:
+		c.body, c.x = loop.Body, loop.X
+		if loop.Tok == token.DEFINE {
+			c.addVar(loop.Key, false)
+			c.addVar(loop.Value, true)
+		}
+	case *ast.ForStmt:
+		c.body = loop.Body
+		if init, ok := loop.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+			for _, lhs := range init.Lhs {
+				c.addVar(lhs, false)
+			}
+		}
+	}
+
+	c.locals = locals(c.pass, c.body)
+
+	for _, s := range c.stores() {
+		c.checkStore(s)
+	}
+}
+
+func (c *loopCheck) addVar(e ast.Expr, value bool) {
+	id, ok := e.(*ast.Ident)
+	if !ok || id.Name == "_" {
+		return
+	}
+
+	if v, ok := c.pass.TypesInfo.Defs[id].(*types.Var); ok {
+		c.vars[v] = loopVar{v: v, value: value}
+	}
+}
+
+// stores 는 본문에서 map, slice, 배열 원소, 필드에 쓰거나 append 하는 값을 모은다
+func (c *loopCheck) stores() []store {
+	var out []store
+
+	var stack []ast.Node
+	loops := 0
+	ast.Inspect(c.body, func(n ast.Node) bool {
+		if n == nil {
+			switch stack[len(stack)-1].(type) {
+			case *ast.RangeStmt, *ast.ForStmt:
+				loops--
+			}
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+
+		switch n := n.(type) {
+		case *ast.RangeStmt, *ast.ForStmt:
+			loops++
+		case *ast.AssignStmt:
+			if n.Tok != token.ASSIGN || len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+
+			for i, lhs := range n.Lhs {
+				rhs := ast.Unparen(n.Rhs[i])
+				if call, ok := rhs.(*ast.CallExpr); ok && isAppend(c.pass, call) {
+					for _, arg := range call.Args[1:] {
+						out = append(out, store{value: arg, sink: lhs, appended: true, nested: loops > 0})
+					}
+					continue
+				}
+				if sink(c.pass, lhs) {
+					out = append(out, store{value: rhs, sink: lhs, nested: loops > 0})
+				}
+			}
+		}
+		return true
+	})
+
+	return out
+}
+
+func (c *loopCheck) checkStore(s store) {
+	origin, via := c.origin(s.value)
+	addr, ok := origin.(*ast.UnaryExpr)
+	if !ok || addr.Op != token.AND {
+		return
+	}
+
+	lang, where := c.version(s.value.Pos())
+	shared := version.Compare(lang, perIteration) < 0
+
+	root := rootVar(c.pass, addr.X)
+	if lv, ok := c.vars[root]; ok {
+		c.reportLoopVar(s, addr, via, lv, lang, where, shared)
+		return
+	}
+
+	if copied := c.copyOf(root); copied != nil {
+		if !shared {
+			c.report(s, addr, via, fmt.Sprintf("%s is a copy of the range variable %s, so writes through it do not change %s; with %s (%s) %s is already per-iteration and the copy is redundant",
+				root.Name(), copied.Name(), render(c.pass, c.x), lang, where, copied.Name()))
+		}
+		return
+	}
+
+	if base := c.callerSlice(addr.X); base != nil && !s.nested {
+		c.report(s, addr, via, fmt.Sprintf("it aliases the caller's slice %s: writes through either one show in the other, and %s keeps the backing array of %s alive; store a copy if the caller may reuse %s",
+			base.Name(), render(c.pass, s.sink), base.Name(), base.Name()))
+	}
+}
+
+func (c *loopCheck) reportLoopVar(s store, addr *ast.UnaryExpr, via *ast.Ident, lv loopVar, lang, where string, shared bool) {
+	name := lv.v.Name()
+
+	if shared {
+		diag := c.diagnostic(s, addr, via, fmt.Sprintf("%s is the loop variable shared by all iterations with %s (%s), so every stored pointer ends up at its last value; copy it in each iteration (%s := %s)",
+			name, lang, where, name, name))
+
+		if !c.fixed[lv.v] && !assigned(c.pass, c.body, lv.v) {
+			c.fixed[lv.v] = true
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   fmt.Sprintf("copy %s in each iteration", name),
+				TextEdits: []analysis.TextEdit{c.copyEdit(name)},
+			}}
+		}
+		c.pass.Report(diag)
+		return
+	}
+
+	// go1.22 이상에서 key, for 문 변수의 주소는 그 반복의 값일 뿐이다. 원소 복사본만 알려준다.
+	if !lv.value || c.x == nil {
+		return
+	}
+
+	c.report(s, addr, via, fmt.Sprintf("with %s (%s) the range variable %s is per-iteration, so each pointer is a separate copy of an element of %s and writes through it do not change %s; store &%s[i] to share the element",
+		lang, where, name, render(c.pass, c.x), render(c.pass, c.x), render(c.pass, c.x)))
+}
+
+func (c *loopCheck) report(s store, addr *ast.UnaryExpr, via *ast.Ident, detail string) {
+	c.pass.Report(c.diagnostic(s, addr, via, detail))
+}
+
+// diagnostic 은 "<값> is stored in <곳>; <detail>" 이다. 지역 변수를 거쳤으면 "c (&cs[i]) is stored in ..." 처럼 쓴다.
+func (c *loopCheck) diagnostic(s store, addr *ast.UnaryExpr, via *ast.Ident, detail string) analysis.Diagnostic {
+	value := render(c.pass, addr)
+	if via != nil {
+		value = via.Name + " (" + value + ")"
+	}
+
+	verb := "is stored in"
+	if s.appended {
+		verb = "is appended to"
+	}
+
+	return analysis.Diagnostic{
+		Pos:     s.value.Pos(),
+		End:     s.value.End(),
+		Message: fmt.Sprintf("%s %s %s; %s", value, verb, render(c.pass, s.sink), detail),
+	}
+}
+
+// copyEdit 은 본문 맨 앞에 name := name 을 넣는다 (go1.22 전의 반복마다 복사하는 방법)
+func (c *loopCheck) copyEdit(name string) analysis.TextEdit {
+	indent := "\t"
+	if len(c.body.List) > 0 {
+		pos := c.pass.Fset.Position(c.body.List[0].Pos())
+		indent = strings.Repeat("\t", pos.Column-1)
+	}
+
+	return analysis.TextEdit{
+		Pos:     c.body.Lbrace + 1,
+		End:     c.body.Lbrace + 1,
+		NewText: []byte("\n" + indent + name + " := " + name),
+	}
+}
+
+/*
+origin 은 저장하는 값이 처음 만들어진 식이다
+
+	s.m[k] = &c         -> &c
+	p := &cs[i]
+	s.m[k] = p          -> &cs[i], via p
+*/
+func (c *loopCheck) origin(e ast.Expr) (ast.Expr, *ast.Ident) {
+	e = ast.Unparen(e)
+
+	var via *ast.Ident
+	for range 4 {
+		id, ok := e.(*ast.Ident)
+		if !ok {
+			break
+		}
+		v, ok := c.pass.TypesInfo.Uses[id].(*types.Var)
+		if !ok {
+			break
+		}
+		init, ok := c.locals[v]
+		if !ok {
+			break
+		}
+		if via == nil {
+			via = id
+		}
+		e = ast.Unparen(init)
+	}
+
+	return e, via
+}
+
+// copyOf 는 v 가 본문에서 v := c 로 range 값 변수 c 를 복사한 지역 변수이면 c 를 돌려준다
+func (c *loopCheck) copyOf(v *types.Var) *types.Var {
+	init, ok := c.locals[v]
+	if !ok {
+		return nil
+	}
+
+	id, ok := ast.Unparen(init).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	src, ok := c.pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok || !c.vars[src].value {
+		return nil
+	}
+
+	return src
+}
+
+// callerSlice 는 e 가 cs[i] 이고 cs 가 함수의 slice 파라미터이면 cs 를 돌려준다
+func (c *loopCheck) callerSlice(e ast.Expr) *types.Var {
+	idx, ok := ast.Unparen(e).(*ast.IndexExpr)
+	if !ok {
+		return nil
+	}
+
+	id, ok := ast.Unparen(idx.X).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	v, ok := c.pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok || !c.params[v] {
+		return nil
+	}
+	if _, ok := v.Type().Underlying().(*types.Slice); !ok {
+		return nil
+	}
+
+	return v
+}
+
+/*
+version 은 pos 가 있는 파일의 go 버전과 그 출처다.
+파일의 //go:build go1.N 이 go.mod 와 다르면 그쪽을 따른다 (FileVersions).
+go.mod 가 없으면 (GOPATH) 이 analyzer 를 빌드한 toolchain 의 버전으로 본다.
+*/
+func (c *loopCheck) version(pos token.Pos) (lang, where string) {
+	module := version.Lang(c.pass.Pkg.GoVersion())
+
+	for _, f := range c.pass.Files {
+		if f.FileStart <= pos && pos <= f.FileEnd {
+			if file := version.Lang(c.pass.TypesInfo.FileVersions[f]); file != "" && file != module {
+				return file, "//go:build"
+			}
+			break
+		}
+	}
+
+	if module != "" {
+		return module, "go.mod"
+	}
+
+	return version.Lang(runtime.Version()), "toolchain default"
+}
+
+// params 는 반복문을 감싸는 가장 가까운 함수의 파라미터다 (receiver 포함)
+func params(pass *analysis.Pass, stack []ast.Node) map[*types.Var]bool {
+	var lists []*ast.FieldList
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			lists = append(lists, fn.Recv, fn.Type.Params)
+		case *ast.FuncLit:
+			lists = append(lists, fn.Type.Params)
+		default:
+			continue
+		}
+		break
+	}
+
+	out := map[*types.Var]bool{}
+	for _, list := range lists {
+		if list == nil {
+			continue
+		}
+		for _, field := range list.List {
+			for _, name := range field.Names {
+				if v, ok := pass.TypesInfo.Defs[name].(*types.Var); ok {
+					out[v] = true
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// locals 는 body 에서 x := e (또는 var x = e) 로 정하고 다시 쓰지 않는 지역 변수다
+func locals(pass *analysis.Pass, body *ast.BlockStmt) map[*types.Var]ast.Expr {
+	out := map[*types.Var]ast.Expr{}
+	define := func(lhs []*ast.Ident, rhs []ast.Expr) {
+		if len(lhs) != len(rhs) {
+			return
+		}
+		for i, id := range lhs {
+			if v, ok := pass.TypesInfo.Defs[id].(*types.Var); ok {
+				out[v] = rhs[i]
+			}
+		}
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE {
+				return true
+			}
+			var lhs []*ast.Ident
+			for _, e := range n.Lhs {
+				id, ok := e.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				lhs = append(lhs, id)
+			}
+			define(lhs, n.Rhs)
+		case *ast.ValueSpec:
+			define(n.Names, n.Values)
+		}
+		return true
+	})
+
+	for v := range out {
+		if assigned(pass, body, v) {
+			delete(out, v)
+		}
+	}
+
+	return out
+}
+
+// sink 는 map, slice, 배열 원소나 struct 필드에 쓰는 식인지 본다
+func sink(pass *analysis.Pass, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.IndexExpr:
+		switch pass.TypesInfo.TypeOf(e.X).Underlying().(type) {
+		case *types.Map, *types.Slice, *types.Array, *types.Pointer:
+			return true
+		}
+	case *ast.SelectorExpr:
+		sel, ok := pass.TypesInfo.Selections[e]
+		return ok && sel.Kind() == types.FieldVal
+	}
+
+	return false
+}
+
+func isAppend(pass *analysis.Pass, call *ast.CallExpr) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok || len(call.Args) < 2 || call.Ellipsis.IsValid() {
+		return false
+	}
+
+	b, ok := pass.TypesInfo.Uses[id].(*types.Builtin)
+	return ok && b.Name() == "append"
+}
+
+// assigned 는 body 에서 v (또는 그 필드, 원소) 에 다시 쓰는지 본다
+func assigned(pass *analysis.Pass, body *ast.BlockStmt, v *types.Var) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				break
+			}
+			for _, lhs := range n.Lhs {
+				if rootVar(pass, lhs) == v {
+					found = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if rootVar(pass, n.X) == v {
+				found = true
+			}
+		}
+		return !found
+	})
+
+	return found
+}
+
+// rootVar 는 &c, &c.ID, &c.arr[0] 처럼 주소를 가져가는 값의 변수다 (pointer 를 따라가면 nil)
+func rootVar(pass *analysis.Pass, e ast.Expr) *types.Var {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		v, _ := pass.TypesInfo.Uses[e].(*types.Var)
+		return v
+	case *ast.SelectorExpr:
+		sel, ok := pass.TypesInfo.Selections[e]
+		if !ok || sel.Kind() != types.FieldVal || sel.Indirect() {
+			return nil
+		}
+		return rootVar(pass, e.X)
+	case *ast.IndexExpr:
+		if _, ok := pass.TypesInfo.TypeOf(e.X).Underlying().(*types.Array); !ok {
+			return nil
+		}
+		return rootVar(pass, e.X)
+	}
+
+	return nil
+}
+
+func render(pass *analysis.Pass, n ast.Node) string {
+	if n == nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, pass.Fset, n); err != nil {
+		return strconv.Quote(fmt.Sprint(n))
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,20 @@
+package loopptr_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/zkfmapf123/100/analyzers/loopptr"
+)
+
+// 반복문 변수의 뜻은 go.mod 의 go 버전에 따라 다르므로 testdata 는 GOPATH 가 아니라 버전이 다른 module 두개다
+
+func TestSharedLoopVariables(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, filepath.Join(analysistest.TestData(), "old"), loopptr.Analyzer, "./...")
+}
+
+func TestPerIterationLoopVariables(t *testing.T) {
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "new"), loopptr.Analyzer, "./...")
+}
@@ -0,0 +1,54 @@
+package new
+
+type Customer struct {
+	ID      string
+	Balance float64
+}
+
+type Store struct {
+	m    map[string]*Customer
+	list []*Customer
+}
+
+// 22.go 의 storeCustomers_1: go1.22 부터는 원소의 복사본이다
+func (s *Store) storeCustomers_1(cs []Customer) {
+	for _, c := range cs {
+		s.m[c.ID] = &c // want `&c is stored in s.m\[c.ID\]; with go1.24 \(go.mod\) the range variable c is per-iteration, so each pointer is a separate copy of an element of cs and writes through it do not change cs; store &cs\[i\] to share the element`
+	}
+}
+
+// 22.go 의 storeCustomer_2: go1.22 부터 current 는 필요 없는 복사다
+func (s *Store) storeCustomer_2(cs []Customer) {
+	for _, c := range cs {
+		current := c
+		s.m[c.ID] = &current // want `&current is stored in s.m\[c.ID\]; current is a copy of the range variable c, so writes through it do not change cs; with go1.24 \(go.mod\) c is already per-iteration and the copy is redundant`
+	}
+}
+
+// 22.go 의 storeCustomers_3: 버전과 상관없이 호출한 쪽의 slice 를 가리킨다
+func (s *Store) storeCustomers_3(cs []Customer) {
+	for i := range cs {
+		s.list = append(s.list, &cs[i]) // want `&cs\[i\] is appended to s.list; it aliases the caller's slice cs`
+	}
+}
+
+// 안쪽 반복문의 저장은 안쪽 반복문에서 한번만 보고한다
+func nested(groups [][]Customer, cs []Customer, s *Store) {
+	for range groups {
+		for i := range cs {
+			s.m[cs[i].ID] = &cs[i] // want `&cs\[i\] is stored in s.m\[cs\[i\].ID\]; it aliases the caller's slice cs`
+		}
+	}
+}
+
+// go1.22 부터 key, for 문 변수의 주소는 그 반복의 값이라 문제가 없다
+func indexes(n int) []*int {
+	ptrs := make([]*int, n)
+	for i := 0; i < n; i++ {
+		ptrs[i] = &i
+	}
+	for i := range ptrs {
+		ptrs[i] = &i
+	}
+	return ptrs
+}
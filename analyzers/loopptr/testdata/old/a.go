@@ -0,0 +1,81 @@
+package old
+
+type Customer struct {
+	ID      string
+	Balance float64
+	Tags    [2]string
+}
+
+type Store struct {
+	m    map[string]*Customer
+	last *Customer
+	all  []*Customer
+	tags []*string
+}
+
+// 22.go 의 storeCustomers_1: go1.21 에서는 모든 pointer 가 같은 c 를 가리킨다
+func (s *Store) storeCustomers_1(cs []Customer) {
+	for _, c := range cs {
+		s.m[c.ID] = &c // want `&c is stored in s.m\[c.ID\]; c is the loop variable shared by all iterations with go1.21 \(go.mod\), so every stored pointer ends up at its last value; copy it in each iteration \(c := c\)`
+	}
+}
+
+// ✅ go1.21 에서는 반복마다 복사하는 것이 올바른 방법이다
+func (s *Store) storeCustomer_2(cs []Customer) {
+	for _, c := range cs {
+		current := c
+		s.m[c.ID] = &current
+	}
+}
+
+func (s *Store) storeCustomers_3(cs []Customer) {
+	for i := range cs {
+
+		c := &cs[i]
+		s.m[c.ID] = c // want `c \(&cs\[i\]\) is stored in s.m\[c.ID\]; it aliases the caller's slice cs: writes through either one show in the other, and s.m\[c.ID\] keeps the backing array of cs alive; store a copy if the caller may reuse cs`
+	}
+}
+
+// 수정안은 변수마다 한번만 넣는다
+func (s *Store) several(cs []Customer) {
+	for _, c := range cs {
+		s.all = append(s.all, &c)           // want `&c is appended to s.all; c is the loop variable shared`
+		s.last = &c                         // want `&c is stored in s.last; c is the loop variable shared`
+		s.tags = append(s.tags, &c.Tags[0]) // want `&c.Tags\[0\] is appended to s.tags; c is the loop variable shared`
+	}
+}
+
+func indexes(n int) []*int {
+	ptrs := make([]*int, n)
+	for i := 0; i < n; i++ {
+		ptrs[i] = &i // want `&i is stored in ptrs\[i\]; i is the loop variable shared by all iterations with go1.21 \(go.mod\)`
+	}
+	return ptrs
+}
+
+// 본문에서 i 를 바꾸면 i := i 로 복사할 수 없으므로 수정안이 없다
+func skipOdd(n int) []*int {
+	var ptrs []*int
+	for i := 0; i < n; i++ {
+		p := &i
+		ptrs = append(ptrs, p) // want `p \(&i\) is appended to ptrs; i is the loop variable shared`
+		i++
+	}
+	return ptrs
+}
+
+// 저장하지 않으면 보고하지 않는다
+func print(cs []Customer) {
+	for _, c := range cs {
+		p := &c
+		_ = p.ID
+	}
+}
+
+// 반복문 밖의 slice, 지역 배열은 호출한 쪽의 slice 가 아니다
+func local(s *Store) {
+	cs := []Customer{{ID: "1"}}
+	for i := range cs {
+		s.m[cs[i].ID] = &cs[i]
+	}
+}
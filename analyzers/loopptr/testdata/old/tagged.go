@@ -0,0 +1,10 @@
+//go:build go1.22
+
+package old
+
+// 파일의 //go:build 가 go.mod 보다 앞선 버전이면 그 버전을 따른다
+func (s *Store) tagged(cs []Customer) {
+	for _, c := range cs {
+		s.m[c.ID] = &c // want `&c is stored in s.m\[c.ID\]; with go1.22 \(//go:build\) the range variable c is per-iteration`
+	}
+}
@@ -0,0 +1,12 @@
+// nestedif 는 nestedif analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/nestedif"
+)
+
+func main() {
+	singlechecker.Main(nestedif.Analyzer)
+}
@@ -0,0 +1,408 @@
+package nestedif
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"maps"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+/*
+2.go 의 BadIfPattern 처럼 if/else 가 깊게 중첩된 함수를 찾는다.
+
+- if 중첩 깊이가 임계값을 넘으면 보고한다 (else if 는 같은 깊이로 본다)
+- 함수 안에서 가장 깊은 블록의 들여쓰기(happy path indentation) 도 같이 알려준다
+- if cond { ...; return } else { ... } 형태는 GoodIfPattern 처럼 early return 으로 바꾸는 수정안을 제공한다
+
+임계값은 -max-depth 로 전체를, -package-depth 로 패키지별로 지정한다.
+
+	-package-depth 'github.com/zkfmapf123/100=3,github.com/zkfmapf123/100/legacy/...=5'
+*/
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	c := &checker{maxDepth: 2, packageDepth: depthMap{}}
+
+	a := &analysis.Analyzer{
+		Name:     "nestedif",
+		Doc:      "reports functions whose if/else nesting exceeds a configurable depth",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      c.run,
+	}
+
+	a.Flags.IntVar(&c.maxDepth, "max-depth", c.maxDepth, "maximum allowed if/else nesting depth")
+	a.Flags.Var(c.packageDepth, "package-depth", "comma separated pkgpath=depth overrides, pkgpath may end with /...")
+
+	return a
+}
+
+// depthMap 은 패키지 경로별 임계값이다 (flag.Value)
+type depthMap map[string]int
+
+func (m depthMap) String() string {
+	parts := make([]string, 0, len(m))
+	for pkg, depth := range m {
+		parts = append(parts, fmt.Sprintf("%s=%d", pkg, depth))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Set 은 이전 값을 모두 바꾼다 (잘못된 값이면 그대로 둔다)
+func (m depthMap) Set(s string) error {
+	next := depthMap{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		pkg, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return fmt.Errorf("invalid package depth %q: want pkgpath=depth", part)
+		}
+
+		depth, err := strconv.Atoi(v)
+		if err != nil || depth < 1 {
+			return fmt.Errorf("invalid depth for %s: %q must be a positive integer", pkg, v)
+		}
+
+		next[pkg] = depth
+	}
+
+	clear(m)
+	maps.Copy(m, next)
+
+	return nil
+}
+
+// lookup 은 가장 구체적인(긴) 패턴의 임계값을 돌려준다
+func (m depthMap) lookup(pkgPath string) (int, bool) {
+	best, depth := -1, 0
+	for pattern, d := range m {
+		prefix, wildcard := strings.CutSuffix(pattern, "/...")
+
+		matched := pattern == pkgPath
+		if wildcard {
+			matched = pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/")
+		}
+
+		if matched && len(pattern) > best {
+			best, depth = len(pattern), d
+		}
+	}
+
+	return depth, best >= 0
+}
+
+type checker struct {
+	maxDepth     int
+	packageDepth depthMap
+}
+
+func (c *checker) run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	limit := c.maxDepth
+	if d, ok := c.packageDepth.lookup(pass.Pkg.Path()); ok {
+		limit = d
+	}
+
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		fn := n.(*ast.FuncDecl)
+		if fn.Body == nil {
+			return
+		}
+
+		m := measure(fn.Body)
+		if m.ifDepth <= limit {
+			return
+		}
+
+		diag := analysis.Diagnostic{
+			Pos: fn.Name.Pos(),
+			End: fn.Name.End(),
+			Message: fmt.Sprintf("%s has if/else nesting depth %d (max %d), happy path is indented %d levels",
+				fn.Name.Name, m.ifDepth, limit, m.indent),
+		}
+
+		if edits := guardClauseEdits(pass, fn.Body); len(edits) > 0 {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Replace else branches after return with guard clauses",
+				TextEdits: edits,
+			}}
+		}
+
+		pass.Report(diag)
+	})
+
+	return nil, nil
+}
+
+type metrics struct {
+	ifDepth int
+	indent  int
+}
+
+// measure 는 함수 본문의 if 중첩 깊이와 가장 깊은 블록 들여쓰기를 잰다
+func measure(body *ast.BlockStmt) metrics {
+	var m metrics
+
+	var walkIf func(s *ast.IfStmt, ifDepth, indent int)
+	var walk func(n ast.Node, ifDepth, indent int)
+
+	walkIf = func(s *ast.IfStmt, ifDepth, indent int) {
+		m.ifDepth = max(m.ifDepth, ifDepth)
+		walk(s.Body, ifDepth, indent)
+
+		switch e := s.Else.(type) {
+		case *ast.IfStmt:
+			// else if 는 같은 깊이의 분기다
+			walkIf(e, ifDepth, indent)
+		case *ast.BlockStmt:
+			walk(e, ifDepth, indent)
+		}
+	}
+
+	walk = func(n ast.Node, ifDepth, indent int) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			switch s := n.(type) {
+			case *ast.IfStmt:
+				walkIf(s, ifDepth+1, indent+1)
+				return false
+			case *ast.ForStmt:
+				walk(s.Body, ifDepth, indent+1)
+				return false
+			case *ast.RangeStmt:
+				walk(s.Body, ifDepth, indent+1)
+				return false
+			case *ast.CaseClause:
+				m.indent = max(m.indent, indent+1)
+				for _, stmt := range s.Body {
+					walk(stmt, ifDepth, indent+1)
+				}
+				return false
+			case *ast.CommClause:
+				m.indent = max(m.indent, indent+1)
+				for _, stmt := range s.Body {
+					walk(stmt, ifDepth, indent+1)
+				}
+				return false
+			case *ast.FuncLit:
+				walk(s.Body, 0, indent+1)
+				return false
+			case *ast.BlockStmt:
+				if len(s.List) > 0 {
+					m.indent = max(m.indent, indent)
+				}
+			}
+			return true
+		})
+	}
+
+	walk(body, 0, 0)
+	return m
+}
+
+// guardClauseEdits 는 if cond { ...; return } else { ... } 를 찾아
+// else 블록을 if 뒤로 꺼내는 편집을 만든다
+func guardClauseEdits(pass *analysis.Pass, body *ast.BlockStmt) []analysis.TextEdit {
+	var edits []analysis.TextEdit
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+
+		for _, stmt := range block.List {
+			s, ok := stmt.(*ast.IfStmt)
+			if !ok || !isGuardCandidate(s) {
+				continue
+			}
+
+			if redeclares(pass, block, s) {
+				continue
+			}
+
+			edit, ok := hoistElse(pass, s)
+			if !ok {
+				continue
+			}
+
+			edits = append(edits, edit)
+		}
+		return true
+	})
+
+	return dropNested(edits)
+}
+
+func isGuardCandidate(s *ast.IfStmt) bool {
+	// init 절의 변수는 else 블록 밖으로 나가면 스코프를 벗어난다
+	if s.Init != nil {
+		return false
+	}
+
+	if _, ok := s.Else.(*ast.BlockStmt); !ok {
+		return false
+	}
+
+	return len(s.Body.List) > 0 && isTerminating(s.Body.List[len(s.Body.List)-1])
+}
+
+func isTerminating(s ast.Stmt) bool {
+	switch s := s.(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return s.Tok != token.FALLTHROUGH
+	case *ast.ExprStmt:
+		call, ok := s.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		id, ok := call.Fun.(*ast.Ident)
+		return ok && id.Name == "panic"
+	}
+
+	return false
+}
+
+// redeclares 는 else 블록이 선언하는 이름이 if 를 감싼 블록에도 있는지 본다.
+// 꺼내면 같은 scope 에서 다시 선언하게 되거나 (no new variables), 뒤의 코드가 다른 변수를 가리키게 된다.
+func redeclares(pass *analysis.Pass, block *ast.BlockStmt, s *ast.IfStmt) bool {
+	// 함수 본문은 따로 scope 가 없고 파라미터와 같은 함수 scope 를 쓴다
+	scope := pass.TypesInfo.Scopes[block]
+	if scope == nil {
+		scope = pass.Pkg.Scope().Innermost(block.Lbrace)
+	}
+
+	names := declared(s.Else.(*ast.BlockStmt))
+	if len(names) == 0 {
+		return false
+	}
+
+	for name := range names {
+		if scope != nil && scope.Lookup(name) != nil {
+			return true
+		}
+	}
+
+	// 바깥 scope 의 같은 이름을 if 뒤에서 쓰고 있으면 꺼낸 선언이 그것을 가린다
+	found := false
+	for _, stmt := range block.List {
+		if stmt.Pos() <= s.Pos() {
+			continue
+		}
+
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && names[id.Name] {
+				found = true
+			}
+			return !found
+		})
+	}
+
+	return found
+}
+
+// declared 는 블록 바로 아래에서 선언하는 이름이다
+func declared(block *ast.BlockStmt) map[string]bool {
+	names := map[string]bool{}
+	add := func(id *ast.Ident) {
+		if id.Name != "_" {
+			names[id.Name] = true
+		}
+	}
+
+	for _, stmt := range block.List {
+		switch stmt := stmt.(type) {
+		case *ast.AssignStmt:
+			if stmt.Tok != token.DEFINE {
+				continue
+			}
+			for _, lhs := range stmt.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok {
+					add(id)
+				}
+			}
+		case *ast.DeclStmt:
+			for _, spec := range stmt.Decl.(*ast.GenDecl).Specs {
+				switch spec := spec.(type) {
+				case *ast.ValueSpec:
+					for _, id := range spec.Names {
+						add(id)
+					}
+				case *ast.TypeSpec:
+					add(spec.Name)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// hoistElse 는 "} else { body }" 를 "}\nbody" 로 바꾼다.
+// 한 줄짜리 else 도 if 와 같은 들여쓰기의 새 줄에서 시작한다.
+func hoistElse(pass *analysis.Pass, s *ast.IfStmt) (analysis.TextEdit, bool) {
+	els := s.Else.(*ast.BlockStmt)
+
+	tf := pass.Fset.File(s.Pos())
+	src, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return analysis.TextEdit{}, false
+	}
+
+	start := tf.Offset(s.Pos())
+	line := src[tf.Offset(tf.LineStart(tf.Line(s.Pos()))):start]
+	indent := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+
+	inner := bytes.TrimRight(src[tf.Offset(els.Lbrace)+1:tf.Offset(els.Rbrace)], " \t\n")
+	lines := bytes.Split(inner, []byte("\n"))
+
+	var out bytes.Buffer
+	if first := bytes.TrimSpace(lines[0]); len(first) > 0 {
+		out.WriteByte('\n')
+		out.Write(indent)
+		out.Write(first)
+	}
+	for _, l := range lines[1:] {
+		out.WriteByte('\n')
+		out.Write(bytes.TrimPrefix(l, []byte("\t")))
+	}
+
+	return analysis.TextEdit{
+		Pos:     s.Body.Rbrace + 1,
+		End:     els.Rbrace + 1,
+		NewText: out.Bytes(),
+	}, true
+}
+
+// dropNested 는 다른 편집 범위 안에 들어가는 편집을 버린다 (겹치는 편집 방지)
+func dropNested(edits []analysis.TextEdit) []analysis.TextEdit {
+	kept := edits[:0]
+	for i, e := range edits {
+		nested := false
+		for j, o := range edits {
+			if i != j && o.Pos <= e.Pos && e.End <= o.End {
+				nested = true
+				break
+			}
+		}
+
+		if !nested {
+			kept = append(kept, e)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,32 @@
+package nestedif_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/zkfmapf123/100/analyzers/nestedif"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), nestedif.Analyzer, "a")
+}
+
+func TestPackageDepth(t *testing.T) {
+	old := nestedif.Analyzer.Flags.Lookup("package-depth").Value.String()
+	t.Cleanup(func() { nestedif.Analyzer.Flags.Set("package-depth", old) })
+
+	if err := nestedif.Analyzer.Flags.Set("package-depth", "b=3,other/...=5"); err != nil {
+		t.Fatal(err)
+	}
+
+	analysistest.Run(t, analysistest.TestData(), nestedif.Analyzer, "b")
+}
+
+func TestPackageDepthInvalid(t *testing.T) {
+	for _, v := range []string{"b", "b=x", "b=0"} {
+		if err := nestedif.Analyzer.Flags.Set("package-depth", v); err == nil {
+			t.Errorf("Set(%q) = nil, want error", v)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package a
+
+import "fmt"
+
+func BadIfPattern(num int) { // want `BadIfPattern has if/else nesting depth 3 \(max 2\), happy path is indented 3 levels`
+	if num > 10 {
+		if num > 20 {
+			if num > 30 {
+				fmt.Println(30)
+			} else {
+				fmt.Println(20)
+			}
+		} else {
+			fmt.Println(10)
+		}
+	} else {
+		fmt.Println(0)
+	}
+}
+
+func GoodIfPattern(num int) {
+	if num > 10 {
+		return
+	}
+
+	if num > 20 {
+		return
+	}
+
+	if num > 30 {
+		return
+	}
+}
+
+// else if 체인은 같은 깊이로 센다
+func ElseIfChain(num int) string {
+	if num > 30 {
+		return "30"
+	} else if num > 20 {
+		return "20"
+	} else if num > 10 {
+		return "10"
+	}
+
+	return "0"
+}
+
+func ReturnElse(items []int) int { // want `ReturnElse has if/else nesting depth 3 \(max 2\), happy path is indented 4 levels`
+	total := 0
+	for _, it := range items {
+		if it > 0 {
+			if it > 100 {
+				fmt.Println("too big")
+				return -1
+			} else {
+				// 정상 경로
+				if it%2 == 0 {
+					total += it
+				}
+				total++
+			}
+		}
+	}
+
+	return total
+}
+
+// init 절이 있으면 else 를 밖으로 꺼낼 수 없으므로 수정안이 없다
+func WithInit(m map[string]int) int { // want `WithInit has if/else nesting depth 3`
+	if len(m) > 0 {
+		if v, ok := m["a"]; ok {
+			return v
+		} else {
+			if v > 0 {
+				return 0
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,56 @@
+package a
+
+import "fmt"
+
+// gofmt 을 거치지 않은 한 줄짜리 else 도 새 줄로 꺼낸다 (이 파일은 일부러 gofmt 하지 않았다)
+func OneLineElse(num int) { // want `OneLineElse has if/else nesting depth 3 \(max 2\)`
+	if num > 10 {
+		if num > 20 {
+			if num > 30 {
+				return
+			} else { fmt.Println(30) }
+		}
+	}
+}
+
+// else 에서 선언한 이름이 바깥 블록에도 있으면 꺼낼 수 없다
+func Redeclared(num int) int { // want `Redeclared has if/else nesting depth 3 \(max 2\)`
+	if num > 10 {
+		n := num * 2
+		if num > 20 {
+			if num > 30 {
+				return 0
+			}
+		}
+		if n > 100 {
+			return n
+		} else {
+			n := n / 2
+			fmt.Println(n)
+		}
+	}
+
+	return num
+}
+
+// 바깥의 num 을 가리던 선언을 꺼내면 if 뒤의 num 이 다른 변수가 된다
+func Shadowed(num int) int { // want `Shadowed has if/else nesting depth 3 \(max 2\)`
+	for range 3 {
+		if num > 20 {
+			if num > 30 {
+				if num > 40 {
+					return 0
+				}
+			}
+		}
+		if num > 100 {
+			return num
+		} else {
+			num := 1
+			fmt.Println(num)
+		}
+		num++
+	}
+
+	return num
+}
@@ -0,0 +1,26 @@
+package b
+
+import "fmt"
+
+// b 패키지는 -package-depth 로 임계값을 3 으로 올렸다
+func BadIfPattern(num int) {
+	if num > 10 {
+		if num > 20 {
+			if num > 30 {
+				fmt.Println(30)
+			}
+		}
+	}
+}
+
+func TooDeep(num int) { // want `TooDeep has if/else nesting depth 4 \(max 3\)`
+	if num > 10 {
+		if num > 20 {
+			if num > 30 {
+				if num > 40 {
+					fmt.Println(40)
+				}
+			}
+		}
+	}
+}
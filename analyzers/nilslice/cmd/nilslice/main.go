@@ -0,0 +1,12 @@
+// nilslice 는 nilslice analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/nilslice"
+)
+
+func main() {
+	singlechecker.Main(nilslice.Analyzer)
+}
@@ -0,0 +1,523 @@
+package nilslice
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+/*
+16.go, 17.go 의 nil / empty slice 실수를 찾는다.
+
+	func GetUserEmptySlice() []string {
+		return []string{}          // ❌ 할당이 필요없는 빈 slice -> return nil
+	}
+
+	if s != nil { use(s[0]) }     // ❌ 빈 non-nil slice 를 놓친다 -> len(s) != 0
+
+JSON 으로 직렬화하는 값은 null 과 [] 가 다르므로 -exempt-json 을 켜면 보고하지 않는다.
+같은 패키지 안에서 결과가 json.Marshal, json.MarshalIndent, (*json.Encoder).Encode 로 들어가는 함수의 빈 slice 반환,
+그리고 그렇게 직렬화하는 변수나 json 태그가 있는 필드의 nil 비교가 대상이다.
+*/
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	c := &checker{}
+
+	a := &analysis.Analyzer{
+		Name:     "nilslice",
+		Doc:      "reports functions returning freshly allocated empty slices and nil comparisons where len(s) == 0 is meant",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      c.run,
+	}
+
+	a.Flags.BoolVar(&c.exemptJSON, "exempt-json", false, "do not report empty slice returns in functions whose results are JSON-encoded in the package, nor nil comparisons of JSON-encoded slices")
+
+	return a
+}
+
+type checker struct {
+	exemptJSON bool
+}
+
+var jsonEncoders = map[string]bool{
+	"encoding/json.Marshal":           true,
+	"encoding/json.MarshalIndent":     true,
+	"(*encoding/json.Encoder).Encode": true,
+}
+
+func (c *checker) run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	var encoded map[*types.Func]bool
+	var encodedVars map[*types.Var]bool
+	if c.exemptJSON {
+		encoded, encodedVars = jsonEncoded(pass, insp)
+	}
+
+	insp.WithStack([]ast.Node{(*ast.ReturnStmt)(nil), (*ast.BinaryExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch n := n.(type) {
+		case *ast.ReturnStmt:
+			fn, sig := enclosingFunc(pass, stack)
+			if sig == nil || (fn != nil && encoded[fn]) {
+				return true
+			}
+			checkReturn(pass, n, sig)
+		case *ast.BinaryExpr:
+			if c.exemptJSON && jsonSlice(pass, n, encodedVars) {
+				return true
+			}
+			checkNilCompare(pass, n, stack)
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+// enclosingFunc 는 return 이 속한 함수다 (함수 리터럴이면 fn 은 nil)
+func enclosingFunc(pass *analysis.Pass, stack []ast.Node) (*types.Func, *types.Signature) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch f := stack[i].(type) {
+		case *ast.FuncLit:
+			sig, _ := pass.TypesInfo.TypeOf(f).(*types.Signature)
+			return nil, sig
+		case *ast.FuncDecl:
+			fn, _ := pass.TypesInfo.Defs[f.Name].(*types.Func)
+			if fn == nil {
+				return nil, nil
+			}
+			return fn, fn.Type().(*types.Signature)
+		}
+	}
+
+	return nil, nil
+}
+
+func checkReturn(pass *analysis.Pass, ret *ast.ReturnStmt, sig *types.Signature) {
+	if len(ret.Results) != sig.Results().Len() {
+		return
+	}
+
+	for i, e := range ret.Results {
+		// any 결과에 nil 을 넣으면 nil interface 가 되므로 slice 결과만 본다
+		if _, ok := sig.Results().At(i).Type().Underlying().(*types.Slice); !ok {
+			continue
+		}
+
+		if !emptySlice(pass, e) {
+			continue
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     e.Pos(),
+			End:     e.End(),
+			Message: fmt.Sprintf("%s allocates an empty slice; return nil instead", types.ExprString(e)),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "return nil",
+				TextEdits: []analysis.TextEdit{{Pos: e.Pos(), End: e.End(), NewText: []byte("nil")}},
+			}},
+		})
+	}
+}
+
+// emptySlice 는 []T{} 또는 make([]T, 0) 이다
+func emptySlice(pass *analysis.Pass, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.CompositeLit:
+		_, ok := pass.TypesInfo.TypeOf(e).Underlying().(*types.Slice)
+		return ok && len(e.Elts) == 0
+	case *ast.CallExpr:
+		id, ok := ast.Unparen(e.Fun).(*ast.Ident)
+		if !ok || len(e.Args) != 2 {
+			return false
+		}
+		if b, ok := pass.TypesInfo.Uses[id].(*types.Builtin); !ok || b.Name() != "make" {
+			return false
+		}
+		tv := pass.TypesInfo.Types[e.Args[1]]
+		return tv.Value != nil && tv.Value.String() == "0"
+	}
+
+	return false
+}
+
+/*
+checkNilCompare 는 s == nil, s != nil 이 빈 slice 검사로 쓰인 곳만 보고한다.
+nil 과 빈 slice 를 일부러 구분하는 곳 (ast.CaseClause.List == nil 은 default, 테스트의 nil / [] 확인) 이 많으므로
+결과가 분명히 "비어있는지" 를 뜻할 때만 보고하고, len 으로 바꾸는 수정안은 내지 않는다.
+
+	if s != nil { use(s[0]) }          // 빈 slice 면 panic
+	if s == nil { return }; use(s[0])  // 위와 같다
+	func isEmpty(s []T) bool { return s == nil }
+
+같은 조건에서 len(s) 도 보면 (s == nil || len(s) != 0) 둘을 구분하는 것이므로 보고하지 않는다.
+*/
+func checkNilCompare(pass *analysis.Pass, be *ast.BinaryExpr, stack []ast.Node) {
+	if be.Op != token.EQL && be.Op != token.NEQ {
+		return
+	}
+
+	s, other := be.X, be.Y
+	if isNil(pass, s) {
+		s, other = other, s
+	}
+	if !isNil(pass, other) {
+		return
+	}
+
+	if _, ok := pass.TypesInfo.TypeOf(s).Underlying().(*types.Slice); !ok {
+		return
+	}
+
+	// 다른 패키지 타입의 필드는 nil 이 뜻을 가질 수 있다 (ast.CaseClause.List == nil 은 default)
+	if foreignField(pass, s) {
+		return
+	}
+
+	name := types.ExprString(s)
+
+	// be 를 감싸는 괄호, &&, || 를 올라간다
+	top := ast.Node(be)
+	logical := token.ILLEGAL
+	i := len(stack) - 2
+	for ; i >= 0; i-- {
+		switch p := stack[i].(type) {
+		case *ast.ParenExpr:
+			top = p
+			continue
+		case *ast.BinaryExpr:
+			if p.Op == token.LAND || p.Op == token.LOR {
+				if logical != token.ILLEGAL && logical != p.Op {
+					return
+				}
+				logical = p.Op
+				top = p
+				continue
+			}
+		}
+		break
+	}
+	if i < 0 || callsLen(top, name) {
+		return
+	}
+
+	if !emptinessCheck(stack[:i+1], top, be.Op, logical, name) {
+		return
+	}
+
+	op := "=="
+	if be.Op == token.NEQ {
+		op = "!="
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     be.Pos(),
+		End:     be.End(),
+		Message: fmt.Sprintf("%s does not treat an empty non-nil slice as empty; use len(%s) %s 0", types.ExprString(be), name, op),
+	})
+}
+
+// emptinessCheck 는 조건 cond 가 s 가 비어있는지를 묻는 것인지 본다. stack 의 마지막이 cond 를 가진 문장이다.
+func emptinessCheck(stack []ast.Node, cond ast.Node, op, logical token.Token, s string) bool {
+	switch parent := stack[len(stack)-1].(type) {
+	case *ast.ReturnStmt:
+		for i := len(stack) - 2; i >= 0; i-- {
+			if fn, ok := stack[i].(*ast.FuncDecl); ok {
+				return strings.Contains(strings.ToLower(fn.Name.Name), "empty")
+			}
+		}
+	case *ast.IfStmt:
+		if parent.Cond != cond {
+			return false
+		}
+
+		// s != nil (&& ...) 이면 본문에서 s 가 비어있지 않다고 본다
+		if op == token.NEQ && logical != token.LOR {
+			return indexes(parent.Body, s)
+		}
+		if op == token.EQL && logical != token.LAND {
+			if parent.Else != nil && indexes(parent.Else, s) {
+				return true
+			}
+			return terminates(parent.Body) && indexes(following(stack, parent), s)
+		}
+	}
+
+	return false
+}
+
+func foreignField(pass *analysis.Pass, e ast.Expr) bool {
+	sel, ok := ast.Unparen(e).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	s, ok := pass.TypesInfo.Selections[sel]
+	if !ok || s.Kind() != types.FieldVal {
+		return false
+	}
+
+	return s.Obj().Pkg() != pass.Pkg
+}
+
+// callsLen 은 n 안에 len(s) 가 있는지 본다
+func callsLen(n ast.Node, s string) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && len(call.Args) == 1 {
+			if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "len" && types.ExprString(call.Args[0]) == s {
+				found = true
+			}
+		}
+		return !found
+	})
+
+	return found
+}
+
+// indexes 는 n 에서 s[i], s[i:j] 로 원소가 있다고 보고 접근하는지 본다
+func indexes(n ast.Node, s string) bool {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return false
+	}
+
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch e := n.(type) {
+		case *ast.IndexExpr:
+			found = types.ExprString(e.X) == s
+		case *ast.SliceExpr:
+			found = types.ExprString(e.X) == s && e.Low != nil
+		}
+		return !found
+	})
+
+	return found
+}
+
+// terminates 는 block 이 return, break, continue, panic 으로 끝나는지 본다
+func terminates(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+
+	switch last := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := last.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		id, ok := call.Fun.(*ast.Ident)
+		return ok && id.Name == "panic"
+	}
+
+	return false
+}
+
+// following 은 stmt 뒤에 오는 같은 block 의 문장들이다
+func following(stack []ast.Node, stmt ast.Stmt) *ast.BlockStmt {
+	if len(stack) < 2 {
+		return nil
+	}
+
+	var list []ast.Stmt
+	switch b := stack[len(stack)-2].(type) {
+	case *ast.BlockStmt:
+		list = b.List
+	case *ast.CaseClause:
+		list = b.Body
+	case *ast.CommClause:
+		list = b.Body
+	}
+
+	for i, st := range list {
+		if st == stmt {
+			return &ast.BlockStmt{List: list[i+1:]}
+		}
+	}
+
+	return nil
+}
+
+func isNil(pass *analysis.Pass, e ast.Expr) bool {
+	return pass.TypesInfo.Types[e].IsNil()
+}
+
+/*
+jsonEncoded 는 결과가 JSON 으로 직렬화되는 함수를 모은다
+
+	json.Marshal(GetUsers())                 // 직접
+	users := GetUsers(); json.Marshal(users) // 같은 함수 안의 변수를 거쳐서
+	enc.Encode(Response{Users: GetUsers()})  // composite literal 안
+*/
+func jsonEncoded(pass *analysis.Pass, insp *inspector.Inspector) (map[*types.Func]bool, map[*types.Var]bool) {
+	encoded := map[*types.Func]bool{}
+	encodedVars := map[*types.Var]bool{}
+
+	insp.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		call := n.(*ast.CallExpr)
+		fn, _ := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if fn == nil || !jsonEncoders[fn.FullName()] || len(call.Args) == 0 {
+			return true
+		}
+
+		vars := map[*types.Var]bool{}
+		ast.Inspect(call.Args[0], func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				if callee, ok := typeutil.Callee(pass.TypesInfo, n).(*types.Func); ok {
+					encoded[callee] = true
+				}
+			case *ast.Ident:
+				if v, ok := pass.TypesInfo.Uses[n].(*types.Var); ok {
+					vars[v] = true
+				}
+			}
+			return true
+		})
+
+		if len(vars) == 0 {
+			return true
+		}
+		for v := range vars {
+			encodedVars[v] = true
+		}
+
+		for i := len(stack) - 1; i >= 0; i-- {
+			body := funcBody(stack[i])
+			if body == nil {
+				continue
+			}
+
+			markAssigned(pass, body, vars, encoded)
+			break
+		}
+		return true
+	})
+
+	return encoded, encodedVars
+}
+
+/*
+jsonSlice 는 nil 비교하는 slice 가 JSON 으로 나가는 값인지 본다 (-exempt-json).
+JSON 에서는 nil 이 null, 빈 slice 가 [] 이므로 둘을 구분하는 것이 맞다.
+
+	data, _ := json.Marshal(users); if users == nil { ... } // 직렬화하는 변수
+	if resp.Users == nil { ... }                            // json 태그가 있는 필드
+*/
+func jsonSlice(pass *analysis.Pass, be *ast.BinaryExpr, encodedVars map[*types.Var]bool) bool {
+	for _, e := range []ast.Expr{be.X, be.Y} {
+		switch e := ast.Unparen(e).(type) {
+		case *ast.Ident:
+			if v, ok := pass.TypesInfo.Uses[e].(*types.Var); ok && encodedVars[v] {
+				return true
+			}
+		case *ast.SelectorExpr:
+			sel, ok := pass.TypesInfo.Selections[e]
+			if !ok || sel.Kind() != types.FieldVal {
+				continue
+			}
+			if jsonTagged(sel) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// jsonTagged 는 선택한 필드에 json 태그가 있는지 본다
+func jsonTagged(sel *types.Selection) bool {
+	st, ok := derefStruct(sel.Recv())
+	if !ok {
+		return false
+	}
+
+	field := sel.Obj()
+	for i := range st.NumFields() {
+		if st.Field(i) == field {
+			_, ok := reflect.StructTag(st.Tag(i)).Lookup("json")
+			return ok
+		}
+	}
+
+	return false
+}
+
+func derefStruct(t types.Type) (*types.Struct, bool) {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	st, ok := t.Underlying().(*types.Struct)
+	return st, ok
+}
+
+func funcBody(n ast.Node) *ast.BlockStmt {
+	switch f := n.(type) {
+	case *ast.FuncDecl:
+		return f.Body
+	case *ast.FuncLit:
+		return f.Body
+	}
+
+	return nil
+}
+
+// markAssigned 는 body 에서 vars 에 함수 호출 결과를 넣는 곳을 찾아 그 함수를 표시한다
+func markAssigned(pass *analysis.Pass, body *ast.BlockStmt, vars map[*types.Var]bool, encoded map[*types.Func]bool) {
+	mark := func(lhs []ast.Expr, rhs []ast.Expr) {
+		for _, l := range lhs {
+			id, ok := l.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			v, _ := pass.TypesInfo.ObjectOf(id).(*types.Var)
+			if !vars[v] {
+				continue
+			}
+
+			for _, r := range rhs {
+				if call, ok := ast.Unparen(r).(*ast.CallExpr); ok {
+					if callee, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func); ok {
+						encoded[callee] = true
+					}
+				}
+			}
+		}
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			mark(n.Lhs, n.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, 0, len(n.Names))
+			for _, name := range n.Names {
+				lhs = append(lhs, name)
+			}
+			mark(lhs, n.Values)
+		}
+		return true
+	})
+}
@@ -0,0 +1,22 @@
+package nilslice_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/zkfmapf123/100/analyzers/nilslice"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), nilslice.Analyzer, "a")
+}
+
+func TestExemptJSON(t *testing.T) {
+	if err := nilslice.Analyzer.Flags.Set("exempt-json", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer nilslice.Analyzer.Flags.Set("exempt-json", "false")
+
+	analysistest.Run(t, analysistest.TestData(), nilslice.Analyzer, "b")
+}
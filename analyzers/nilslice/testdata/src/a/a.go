@@ -0,0 +1,129 @@
+package a
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+// ✅ 16.go 의 GetUserNilSlice. 원소에 접근하지 않으면 nil 과 빈 slice 를 구분하는 것일 수 있다
+func GetUserNilSlice() []string {
+	var s []string
+
+	if s != nil {
+		return nil
+	}
+
+	return s
+}
+
+// ❌ 16.go 의 GetUserEmptySlice
+func GetUserEmptySlice() []string {
+	return []string{} // want `\[\]string\{\} allocates an empty slice; return nil instead`
+}
+
+func generateId(id string) string {
+	return fmt.Sprintf("%s-%s", id, id)
+}
+
+// ❌ 17.go 의 handleOperations
+func handleOperations(id string) []any {
+	op := generateId(id)
+
+	if len(op) == 0 {
+		return nil
+	}
+
+	return []any{} // want `\[\]any\{\} allocates an empty slice; return nil instead`
+}
+
+func withMake(n int) ([]int, error) {
+	if n == 0 {
+		return make([]int, 0), nil // want `make\(\[\]int, 0\) allocates an empty slice; return nil instead`
+	}
+
+	return make([]int, 0, n), nil
+}
+
+// ✅ 결과가 any 이면 nil 로 바꿀 수 없다
+func asAny() any {
+	return []int{}
+}
+
+// ✅ 원소가 있다
+func one() []int {
+	return []int{1}
+}
+
+func isEmpty(s []int) bool {
+	return s == nil // want `s == nil does not treat an empty non-nil slice as empty; use len\(s\) == 0`
+}
+
+// ❌ 빈 slice 면 s[0] 에서 panic
+func first(s []string) string {
+	if s != nil && s[0] != "" { // want `s != nil does not treat an empty non-nil slice as empty; use len\(s\) != 0`
+		return s[0]
+	}
+
+	return ""
+}
+
+func head(s []int) int {
+	if s == nil { // want `s == nil does not treat an empty non-nil slice as empty; use len\(s\) == 0`
+		return 0
+	}
+
+	return s[0]
+}
+
+func tail(s []int) []int {
+	if s == nil { // want `s == nil does not treat an empty non-nil slice as empty; use len\(s\) == 0`
+		return nil
+	} else {
+		return s[1:]
+	}
+}
+
+type clause struct {
+	List []string
+}
+
+// ✅ nil 이 뜻을 가진다 (ast.CaseClause.List == nil 은 default)
+func caseName(c clause) string {
+	if c.List == nil {
+		return "default"
+	}
+
+	return "case " + fmt.Sprint(c.List)
+}
+
+// ✅ 다른 패키지 타입의 필드는 nil 이 뜻을 가질 수 있다
+func label(c *ast.CaseClause) string {
+	if c.List == nil {
+		return "default"
+	}
+
+	return fmt.Sprint(c.List[0])
+}
+
+// ✅ nil 과 빈 slice 를 일부러 구분한다
+func emptyNonNil(got []int) bool {
+	return got == nil || len(got) != 0
+}
+
+// ✅ 비어있는지가 아니라 nil 인지를 확인한다
+func check(items []int) {
+	if items == nil {
+		fmt.Println("want empty non-nil")
+	}
+}
+
+// ✅ map, 포인터의 nil 비교
+func other(m map[string]int, p *int) bool {
+	return m == nil || p == nil
+}
+
+func closure() func() []byte {
+	return func() []byte {
+		return []byte{} // want `\[\]byte\{\} allocates an empty slice; return nil instead`
+	}
+}
@@ -0,0 +1,63 @@
+package b
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type Response struct {
+	Users []string `json:"users"`
+}
+
+// -exempt-json: 결과가 JSON 으로 나가므로 [] 와 null 이 다르다
+func Users() []string {
+	return []string{}
+}
+
+func Groups() []string {
+	return []string{}
+}
+
+func Roles() []string {
+	return []string{}
+}
+
+// ❌ JSON 으로 나가지 않는다
+func Internal() []string {
+	return []string{} // want `\[\]string\{\} allocates an empty slice; return nil instead`
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(Response{Users: Users()})
+
+	groups := Groups()
+	data, _ := json.Marshal(groups)
+	w.Write(data)
+
+	var roles []string
+	roles = Roles()
+	data, _ = json.MarshalIndent(roles, "", "  ")
+	w.Write(data)
+
+	_ = Internal()
+}
+
+// -exempt-json: JSON 으로 나가는 slice 는 nil (null) 과 [] 가 다르다
+func first(w http.ResponseWriter, resp Response, names []string) string {
+	data, _ := json.Marshal(names)
+	w.Write(data)
+
+	if names != nil {
+		return names[0]
+	}
+	if resp.Users != nil {
+		return resp.Users[0]
+	}
+
+	internal := Internal()
+	if internal != nil { // want `internal != nil does not treat an empty non-nil slice as empty; use len\(internal\) != 0`
+		return internal[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,12 @@
+// prealloc 는 prealloc analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/prealloc"
+)
+
+func main() {
+	singlechecker.Main(prealloc.Analyzer)
+}
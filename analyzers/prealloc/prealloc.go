@@ -0,0 +1,536 @@
+package prealloc
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+/*
+15.go 의 convert1 처럼 용량 없이 만든 slice 에 range 안에서 append 하는 코드를 찾는다.
+
+	bars := make([]Foo, 0)          // ❌ convert1
+	for _, foo := range foos {
+		bars = append(bars, foo)
+	}
+
+	bars := make([]Foo, 0, len(foos)) // ✅ convert2 로 바꾸는 수정안
+
+- range 대상이 slice, array, map, string, 정수이면 반복 횟수를 안다 (string 은 len 이 rune 수의 상한이다)
+- make(map[K]V) 에 m[k] = v 로 채우는 경우는 make(map[K]V, len(xs)) 를 제안한다
+- if 안에서만 append 하거나 continue 가 있으면 len 은 상한일 뿐이므로 -conditional 을 켰을 때만 보고한다
+- 안쪽 loop 안에서 append 하면 횟수를 모르므로 보고하지 않는다
+*/
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	c := &checker{}
+
+	a := &analysis.Analyzer{
+		Name:     "prealloc",
+		Doc:      "reports slices and maps filled in a range loop of known length without preallocated capacity",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      c.run,
+	}
+
+	a.Flags.BoolVar(&c.conditional, "conditional", false, "also report conditional appends, using the loop length as an upper bound")
+
+	return a
+}
+
+type checker struct {
+	conditional bool
+}
+
+// fill 은 range 안에서 dest 를 채우는 문장이다 (s = append(s, v) 또는 m[k] = v)
+type fill struct {
+	stmt        *ast.AssignStmt
+	conditional bool
+	nested      bool // 안쪽 loop 안이면 반복마다 몇번 채우는지 모른다
+}
+
+func (c *checker) run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.WithStack([]ast.Node{(*ast.RangeStmt)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		rng := n.(*ast.RangeStmt)
+		block, ok := stack[len(stack)-2].(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+
+		length, upper := loopLength(pass, rng.X)
+		if length == "" {
+			return true
+		}
+
+		fills, order := collectFills(pass, rng.Body)
+		for _, v := range order {
+			c.check(pass, block, rng, v, fills[v], length, upper)
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+/*
+loopLength 는 반복 횟수를 나타내는 식을 돌려준다
+
+	range xs  ->  len(xs)
+	range n   ->  max(n, 0), n 이 unsigned 거나 0 이상의 상수면 n (n < 0 이면 loop 는 돌지 않지만 make 는 panic 한다)
+	range s   ->  len(s), string 이면 upper = true (byte 수 >= rune 수)
+*/
+func loopLength(pass *analysis.Pass, x ast.Expr) (length string, upper bool) {
+	if !pure(x) {
+		return "", false
+	}
+
+	t := pass.TypesInfo.TypeOf(x)
+	if t == nil {
+		return "", false
+	}
+
+	src := types.ExprString(x)
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+		return "len(" + src + ")", false
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return "len(" + src + ")", true
+		case u.Info()&types.IsInteger != 0:
+			tv := pass.TypesInfo.Types[x]
+			switch {
+			case tv.Value != nil && constant.Sign(tv.Value) < 0:
+				return "", false
+			case tv.Value != nil || u.Info()&types.IsUnsigned != 0:
+				return src, false
+			}
+			return "max(" + src + ", 0)", false
+		}
+	}
+
+	return "", false
+}
+
+// pure 는 식을 선언 위치로 옮겨도 같은 값인지 본다 (변수, 필드, 상수만 허용)
+func pure(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.SelectorExpr:
+		return pure(x.X)
+	case *ast.ParenExpr:
+		return pure(x.X)
+	case *ast.StarExpr:
+		return pure(x.X)
+	}
+
+	return false
+}
+
+// collectFills 는 loop 본문에서 변수별로 채우는 문장을 모은다 (중첩 함수는 제외).
+// 본문 바로 아래가 아닌 곳 (if, switch 안) 에서 채우면 conditional 이다.
+// 안쪽 loop 안에서 채우면 len(a)*len(row) 처럼 len 은 상한도 아니므로 nested 로 표시한다.
+func collectFills(pass *analysis.Pass, body *ast.BlockStmt) (map[*types.Var][]fill, []*types.Var) {
+	fills := map[*types.Var][]fill{}
+	var order []*types.Var
+
+	escapes := hasEscape(body)
+	add := func(a *ast.AssignStmt, conditional, nested bool) {
+		v := filledVar(pass, a)
+		if v == nil {
+			return
+		}
+
+		if _, ok := fills[v]; !ok {
+			order = append(order, v)
+		}
+		fills[v] = append(fills[v], fill{stmt: a, conditional: conditional, nested: nested})
+	}
+
+	var walk func(n ast.Node, nested bool)
+	walk = func(n ast.Node, nested bool) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ForStmt:
+				walk(n.Body, true)
+				return false
+			case *ast.RangeStmt:
+				walk(n.Body, true)
+				return false
+			case *ast.AssignStmt:
+				add(n, true, nested)
+			}
+			return true
+		})
+	}
+
+	for _, stmt := range body.List {
+		if a, ok := stmt.(*ast.AssignStmt); ok {
+			add(a, escapes, false)
+			continue
+		}
+
+		walk(stmt, false)
+	}
+
+	return fills, order
+}
+
+// filledVar 는 s = append(s, v) 의 s 또는 m[k] = v 의 m 을 돌려준다
+func filledVar(pass *analysis.Pass, a *ast.AssignStmt) *types.Var {
+	if len(a.Lhs) != 1 || len(a.Rhs) != 1 || a.Tok != token.ASSIGN {
+		return nil
+	}
+
+	if idx, ok := a.Lhs[0].(*ast.IndexExpr); ok {
+		m, ok := idx.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+
+		v, _ := pass.TypesInfo.Uses[m].(*types.Var)
+		if v == nil {
+			return nil
+		}
+		if _, ok := v.Type().Underlying().(*types.Map); !ok {
+			return nil
+		}
+		return v
+	}
+
+	lhs, ok := a.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	call, ok := a.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || call.Ellipsis.IsValid() {
+		return nil
+	}
+
+	if b, ok := pass.TypesInfo.Uses[ident(call.Fun)].(*types.Builtin); !ok || b.Name() != "append" {
+		return nil
+	}
+
+	dst, ok := call.Args[0].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	v, _ := pass.TypesInfo.Uses[lhs].(*types.Var)
+	if v == nil || pass.TypesInfo.Uses[dst] != v {
+		return nil
+	}
+
+	return v
+}
+
+func ident(e ast.Expr) *ast.Ident {
+	id, _ := ast.Unparen(e).(*ast.Ident)
+	return id
+}
+
+// hasEscape 는 본문에 이번 반복을 건너뛰거나 loop 를 빠져나가는 문장이 있는지 본다
+func hasEscape(body *ast.BlockStmt) bool {
+	found := false
+
+	var walk func(n ast.Node, inLoop, inSwitch bool)
+	walk = func(n ast.Node, inLoop, inSwitch bool) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			if found {
+				return false
+			}
+
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ForStmt:
+				walk(n.Body, true, inSwitch)
+				return false
+			case *ast.RangeStmt:
+				walk(n.Body, true, inSwitch)
+				return false
+			case *ast.SwitchStmt:
+				walk(n.Body, inLoop, true)
+				return false
+			case *ast.TypeSwitchStmt:
+				walk(n.Body, inLoop, true)
+				return false
+			case *ast.SelectStmt:
+				walk(n.Body, inLoop, true)
+				return false
+			case *ast.ReturnStmt:
+				found = true
+			case *ast.BranchStmt:
+				switch {
+				case n.Label != nil, n.Tok == token.GOTO:
+					found = true
+				case n.Tok == token.CONTINUE && !inLoop:
+					found = true
+				case n.Tok == token.BREAK && !inLoop && !inSwitch:
+					found = true
+				}
+			}
+			return true
+		})
+	}
+	walk(body, false, false)
+
+	return found
+}
+
+func (c *checker) check(pass *analysis.Pass, block *ast.BlockStmt, rng *ast.RangeStmt, v *types.Var, fills []fill, length string, upper bool) {
+	// 한 반복에 두번 채우면 용량은 len 의 배수가 되므로 판단하지 않는다
+	if len(fills) != 1 {
+		return
+	}
+
+	if fills[0].nested {
+		return
+	}
+
+	conditional := fills[0].conditional
+	if conditional && !c.conditional {
+		return
+	}
+
+	decl, init := findDecl(pass, block, rng, v)
+	if decl == nil || !onlyFilledHere(pass, block, v, fills[0].stmt) {
+		return
+	}
+
+	typ, hinted := initType(pass, init)
+	if typ == nil || hinted {
+		return
+	}
+
+	_, isMap := v.Type().Underlying().(*types.Map)
+
+	var want string
+	if isMap {
+		want = fmt.Sprintf("make(%s, %s)", render(pass, typ), length)
+	} else {
+		want = fmt.Sprintf("make(%s, 0, %s)", render(pass, typ), length)
+	}
+
+	msg := fmt.Sprintf("%s is appended to in range over %s without preallocated capacity; use %s", v.Name(), types.ExprString(rng.X), want)
+	if isMap {
+		msg = fmt.Sprintf("%s is filled in range over %s without a size hint; use %s", v.Name(), types.ExprString(rng.X), want)
+	}
+
+	switch {
+	case conditional:
+		msg += fmt.Sprintf(" (conditional, %s is an upper bound)", length)
+	case upper:
+		msg += fmt.Sprintf(" (%s counts bytes, an upper bound for runes)", length)
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     decl.Pos(),
+		End:     decl.End(),
+		Message: msg,
+	}
+
+	if declaredBefore(pass, rng.X, decl) {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "preallocate " + v.Name(),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     decl.Pos(),
+				End:     decl.End(),
+				NewText: []byte(v.Name() + " := " + want),
+			}},
+		}}
+	}
+
+	pass.Report(diag)
+}
+
+/*
+findDecl 은 range 와 같은 블록에서 v 를 선언한 문장과 초기값을 찾는다
+
+	var s []T               (init == nil)
+	s := []T{}  /  s := make([]T, 0)
+	var s = []T{}
+
+선언과 range 사이에서 v 를 쓰면 (다른 곳에서 채울 수 있으므로) 찾지 않는다.
+*/
+func findDecl(pass *analysis.Pass, block *ast.BlockStmt, rng *ast.RangeStmt, v *types.Var) (ast.Stmt, ast.Expr) {
+	var (
+		decl ast.Stmt
+		init ast.Expr
+		typ  ast.Expr
+	)
+
+	for _, stmt := range block.List {
+		if stmt == rng {
+			break
+		}
+
+		if decl != nil {
+			if uses(pass, stmt, v) {
+				return nil, nil
+			}
+			continue
+		}
+
+		switch s := stmt.(type) {
+		case *ast.AssignStmt:
+			if s.Tok == token.DEFINE && len(s.Lhs) == 1 && len(s.Rhs) == 1 && pass.TypesInfo.Defs[ident(s.Lhs[0])] == v {
+				decl, init = s, s.Rhs[0]
+			}
+		case *ast.DeclStmt:
+			gen, ok := s.Decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR || len(gen.Specs) != 1 {
+				continue
+			}
+
+			spec := gen.Specs[0].(*ast.ValueSpec)
+			if len(spec.Names) != 1 || pass.TypesInfo.Defs[spec.Names[0]] != v {
+				continue
+			}
+
+			switch len(spec.Values) {
+			case 0:
+				decl, typ = s, spec.Type
+			case 1:
+				if spec.Type == nil {
+					decl, init = s, spec.Values[0]
+				}
+			}
+		}
+	}
+
+	if decl == nil {
+		return nil, nil
+	}
+
+	if init == nil {
+		// var s []T 는 make 로 바꿀 수 있는 slice 만 (nil map 에는 쓸 수 없다)
+		if _, ok := v.Type().Underlying().(*types.Slice); !ok {
+			return nil, nil
+		}
+		return decl, typ
+	}
+
+	return decl, init
+}
+
+func uses(pass *analysis.Pass, n ast.Node, v *types.Var) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[id] == v {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
+
+// onlyFilledHere 는 블록 안에서 v 를 append 하거나 m[k] = 로 채우는 곳이 stmt 하나뿐인지 본다
+func onlyFilledHere(pass *analysis.Pass, block *ast.BlockStmt, v *types.Var, stmt *ast.AssignStmt) bool {
+	ok := true
+	ast.Inspect(block, func(n ast.Node) bool {
+		if a, isAssign := n.(*ast.AssignStmt); isAssign && a != stmt && filledVar(pass, a) == v {
+			ok = false
+		}
+		return ok
+	})
+
+	return ok
+}
+
+/*
+initType 은 초기값에서 make 에 넘길 타입 표현식을 꺼낸다. 이미 용량이나 크기를 지정했으면 hinted 다.
+
+	[]T{}            ->  []T
+	make([]T, 0)     ->  []T
+	make([]T, 0, n)  ->  hinted
+	map[K]V{}        ->  map[K]V
+*/
+func initType(pass *analysis.Pass, init ast.Expr) (typ ast.Expr, hinted bool) {
+	switch e := init.(type) {
+	case *ast.ArrayType:
+		// var s []T 에서 넘어온 타입
+		return e, false
+	case *ast.Ident, *ast.SelectorExpr:
+		// var s Foos 처럼 이름 있는 타입
+		if tv, ok := pass.TypesInfo.Types[e]; ok && tv.IsType() {
+			return e, false
+		}
+	case *ast.CompositeLit:
+		if len(e.Elts) == 0 && e.Type != nil {
+			return e.Type, false
+		}
+	case *ast.CallExpr:
+		if b, ok := pass.TypesInfo.Uses[ident(e.Fun)].(*types.Builtin); !ok || b.Name() != "make" {
+			return nil, false
+		}
+
+		switch pass.TypesInfo.TypeOf(e.Args[0]).Underlying().(type) {
+		case *types.Slice:
+			if len(e.Args) == 2 && isZero(pass, e.Args[1]) {
+				return e.Args[0], false
+			}
+			return nil, true
+		case *types.Map:
+			return e.Args[0], len(e.Args) > 1
+		}
+	}
+
+	return nil, false
+}
+
+func isZero(pass *analysis.Pass, e ast.Expr) bool {
+	tv := pass.TypesInfo.Types[e]
+	return tv.Value != nil && tv.Value.String() == "0"
+}
+
+// declaredBefore 는 range 대상의 변수가 모두 decl 보다 먼저 선언되었는지 본다 (수정안에서 decl 위치로 옮긴다)
+func declaredBefore(pass *analysis.Pass, x ast.Expr, decl ast.Stmt) bool {
+	ok := true
+	ast.Inspect(x, func(n ast.Node) bool {
+		id, isIdent := n.(*ast.Ident)
+		if !isIdent {
+			return true
+		}
+
+		obj := pass.TypesInfo.Uses[id]
+		if obj != nil && obj.Parent() != pass.Pkg.Scope() && obj.Pos().IsValid() && obj.Pos() > decl.Pos() {
+			ok = false
+		}
+		return ok
+	})
+
+	return ok
+}
+
+func render(pass *analysis.Pass, e ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, pass.Fset, e); err != nil {
+		return types.ExprString(e)
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,22 @@
+package prealloc_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/zkfmapf123/100/analyzers/prealloc"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), prealloc.Analyzer, "a")
+}
+
+func TestConditional(t *testing.T) {
+	if err := prealloc.Analyzer.Flags.Set("conditional", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer prealloc.Analyzer.Flags.Set("conditional", "false")
+
+	analysistest.Run(t, analysistest.TestData(), prealloc.Analyzer, "b")
+}
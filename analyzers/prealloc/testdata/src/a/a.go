@@ -0,0 +1,161 @@
+package a
+
+type Foo struct{ ID string }
+
+// ❌ 15.go 의 convert1
+func convert1(foos []Foo) []Foo {
+	bars := make([]Foo, 0) // want `bars is appended to in range over foos without preallocated capacity; use make\(\[\]Foo, 0, len\(foos\)\)`
+
+	for _, foo := range foos {
+		bars = append(bars, foo)
+	}
+
+	return bars
+}
+
+// ✅ 15.go 의 convert2
+func convert2(foos []Foo) []Foo {
+	l := len(foos)
+	bars := make([]Foo, 0, l)
+
+	for _, foo := range foos {
+		bars = append(bars, foo)
+	}
+
+	return bars
+}
+
+func fromVar(foos [4]Foo) []string {
+	var ids []string // want `ids is appended to in range over foos without preallocated capacity; use make\(\[\]string, 0, len\(foos\)\)`
+	for _, foo := range foos {
+		ids = append(ids, foo.ID)
+	}
+
+	return ids
+}
+
+func fromLiteral(m map[string]Foo) []Foo {
+	out := []Foo{} // want `out is appended to in range over m without preallocated capacity; use make\(\[\]Foo, 0, len\(m\)\)`
+	for _, v := range m {
+		out = append(out, v)
+	}
+
+	return out
+}
+
+func runes(s string) []rune {
+	var rs []rune // want `rs is appended to in range over s without preallocated capacity; use make\(\[\]rune, 0, len\(s\)\) \(len\(s\) counts bytes, an upper bound for runes\)`
+	for _, r := range s {
+		rs = append(rs, r)
+	}
+
+	return rs
+}
+
+func count(n int) []int {
+	var out []int // want `out is appended to in range over n without preallocated capacity; use make\(\[\]int, 0, max\(n, 0\)\)`
+	for i := range n {
+		out = append(out, i*i)
+	}
+
+	return out
+}
+
+// n 이 음수면 loop 는 돌지 않으므로 make 도 panic 하면 안된다
+func countNegative() []int {
+	return count(-1)
+}
+
+func countUint(n uint) []uint {
+	var out []uint // want `out is appended to in range over n without preallocated capacity; use make\(\[\]uint, 0, n\)`
+	for i := range n {
+		out = append(out, i)
+	}
+
+	return out
+}
+
+func squares(n int) map[int]int {
+	m := make(map[int]int) // want `m is filled in range over n without a size hint; use make\(map\[int\]int, max\(n, 0\)\)`
+	for i := range n {
+		m[i] = i * i
+	}
+
+	return m
+}
+
+const none = -1
+
+// 음수 상수만큼은 돌지 않는다
+func never() []int {
+	var out []int
+	for i := range none {
+		out = append(out, i)
+	}
+
+	return out
+}
+
+func index(foos []Foo) map[string]Foo {
+	byID := make(map[string]Foo) // want `byID is filled in range over foos without a size hint; use make\(map\[string\]Foo, len\(foos\)\)`
+	for _, foo := range foos {
+		byID[foo.ID] = foo
+	}
+
+	return byID
+}
+
+// ✅ 조건부 append 는 기본으로 보고하지 않는다
+func filter(foos []Foo) []Foo {
+	var out []Foo
+	for _, foo := range foos {
+		if foo.ID != "" {
+			out = append(out, foo)
+		}
+	}
+
+	return out
+}
+
+// ✅ 한 반복에 두번 append
+func twice(foos []Foo) []string {
+	var out []string
+	for _, foo := range foos {
+		out = append(out, foo.ID)
+		out = append(out, foo.ID)
+	}
+
+	return out
+}
+
+// ✅ range 전에 다른 곳에서 채운다
+func prefilled(foos []Foo, extra Foo) []Foo {
+	var out []Foo
+	out = append(out, extra)
+	for _, foo := range foos {
+		out = append(out, foo)
+	}
+
+	return out
+}
+
+// ✅ 반복 횟수를 모른다
+func fromChan(ch chan Foo) []Foo {
+	var out []Foo
+	for foo := range ch {
+		out = append(out, foo)
+	}
+
+	return out
+}
+
+// range 대상이 선언보다 뒤에 있으면 보고만 하고 수정안은 없다
+func late(get func() []Foo) []Foo {
+	var out []Foo // want `out is appended to in range over foos without preallocated capacity; use make\(\[\]Foo, 0, len\(foos\)\)`
+	foos := get()
+	for _, foo := range foos {
+		out = append(out, foo)
+	}
+
+	return out
+}
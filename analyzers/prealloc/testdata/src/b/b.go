@@ -0,0 +1,52 @@
+package b
+
+type Foo struct{ ID string }
+
+// -conditional 을 켜면 상한으로 보고한다
+func filter(foos []Foo) []Foo {
+	var out []Foo // want `out is appended to in range over foos without preallocated capacity; use make\(\[\]Foo, 0, len\(foos\)\) \(conditional, len\(foos\) is an upper bound\)`
+	for _, foo := range foos {
+		if foo.ID != "" {
+			out = append(out, foo)
+		}
+	}
+
+	return out
+}
+
+func skip(foos []Foo) []string {
+	ids := make([]string, 0) // want `ids is appended to in range over foos without preallocated capacity; use make\(\[\]string, 0, len\(foos\)\) \(conditional, len\(foos\) is an upper bound\)`
+	for _, foo := range foos {
+		if foo.ID == "" {
+			continue
+		}
+		ids = append(ids, foo.ID)
+	}
+
+	return ids
+}
+
+// ✅ 안쪽 loop 의 continue 는 바깥 반복을 건너뛰지 않는다
+func inner(foos []Foo) []Foo {
+	out := make([]Foo, 0) // want `out is appended to in range over foos without preallocated capacity; use make\(\[\]Foo, 0, len\(foos\)\)$`
+	for _, foo := range foos {
+		for range 3 {
+			continue
+		}
+		out = append(out, foo)
+	}
+
+	return out
+}
+
+// ✅ 안쪽 range 의 append 는 len(rows)*len(row) 번이므로 len(rows) 는 상한이 아니다
+func flatten(rows [][]Foo) []Foo {
+	var out []Foo
+	for _, row := range rows {
+		for _, foo := range row {
+			out = append(out, foo)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,12 @@
+// rangecopy 는 rangecopy analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/rangecopy"
+)
+
+func main() {
+	singlechecker.Main(rangecopy.Analyzer)
+}
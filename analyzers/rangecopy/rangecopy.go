@@ -0,0 +1,659 @@
+package rangecopy
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+/*
+21.go 의 range 값 복사 실수를 찾는다.
+
+	for _, v := range a {
+		v.Balance += 1         // ❌ balanceA: 복사본만 바뀐다 -> a[i].Balance += 1 (balanceB)
+	}
+
+	var big [1024]int
+	for i, v := range big { // ❌ 배열 전체를 복사한다 -> range &big (arrayBetterCode)
+	}
+
+struct 의 slice, array 를 range 로 돌면서 값 변수의 필드를 바꾸고, 그 뒤에 값 변수를 읽지 않으면 보고한다.
+값 변수의 주소를 가져가거나 (&v, pointer receiver 메서드), 함수 리터럴에서 쓰면 보고하지 않는다.
+-array-bytes 이상인 배열을 값 변수와 함께 range 로 돌면 보고한다.
+본문에서 배열을 바꾸면 &a 로 바꿨을 때 v 가 달라지므로 (range_ex1) 수정안은 내지 않는다.
+*/
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	c := &checker{}
+
+	a := &analysis.Analyzer{
+		Name:     "rangecopy",
+		Doc:      "reports writes to range value copies that are lost and range over large arrays by value",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      c.run,
+	}
+
+	a.Flags.Int64Var(&c.arrayBytes, "array-bytes", 1024, "report range by value over arrays of at least this many bytes")
+
+	return a
+}
+
+type checker struct {
+	arrayBytes int64
+}
+
+func (c *checker) run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.Preorder([]ast.Node{(*ast.RangeStmt)(nil)}, func(n ast.Node) {
+		rng := n.(*ast.RangeStmt)
+		checkValueWrites(pass, rng)
+		c.checkArray(pass, rng)
+	})
+
+	return nil, nil
+}
+
+// write 는 값 변수 (또는 그 필드, 배열 원소) 에 쓰는 문장이다
+type write struct {
+	stmt ast.Stmt
+	root *ast.Ident
+	// loop 는 write 를 감싸는 본문 안의 가장 바깥 loop 다 (없으면 nil)
+	loop ast.Node
+}
+
+func checkValueWrites(pass *analysis.Pass, rng *ast.RangeStmt) {
+	if rng.Tok != token.DEFINE || rng.Value == nil {
+		return
+	}
+
+	vid := ident(rng.Value)
+	if vid == nil || vid.Name == "_" {
+		return
+	}
+
+	v, ok := pass.TypesInfo.Defs[vid].(*types.Var)
+	if !ok {
+		return
+	}
+	if _, ok := v.Type().Underlying().(*types.Struct); !ok {
+		return
+	}
+	if !indexable(pass, rng.X) {
+		return
+	}
+
+	writes, reads, ok := collectUses(pass, rng.Body, v)
+	if !ok {
+		return
+	}
+
+	var dead []write
+	for _, w := range writes {
+		if !readAfter(w, reads) {
+			dead = append(dead, w)
+		}
+	}
+	if len(dead) == 0 {
+		return
+	}
+
+	first := dead[0].stmt
+	msg := fmt.Sprintf("%s only changes %s, a copy of the element of %s; the change is lost after the iteration", render(pass, first), v.Name(), render(pass, rng.X))
+	if len(dead) > 1 {
+		msg += fmt.Sprintf(" (and %d more writes)", len(dead)-1)
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     first.Pos(),
+		End:     first.End(),
+		Message: msg,
+	}
+
+	if edits, rewritten := indexFix(pass, rng, dead, len(dead) == len(writes) && len(reads) == 0); edits != nil {
+		diag.Message += "; write through the index: " + rewritten
+		diag.SuggestedFixes = []analysis.SuggestedFix{{Message: "write through the index", TextEdits: edits}}
+	}
+
+	pass.Report(diag)
+}
+
+// indexable 은 range 대상이 index 로 원소를 바꿀 수 있는 slice, array, *array 인지 본다
+func indexable(pass *analysis.Pass, x ast.Expr) bool {
+	t := pass.TypesInfo.TypeOf(x)
+	if t == nil {
+		return false
+	}
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	switch t.Underlying().(type) {
+	case *types.Slice, *types.Array:
+		return true
+	}
+
+	return false
+}
+
+/*
+collectUses 는 본문에서 v 에 쓰는 문장과 v 를 읽는 위치를 모은다.
+v 가 빠져나갈 수 있으면 (주소, 함수 리터럴, goto) ok 가 false 다.
+*/
+func collectUses(pass *analysis.Pass, body *ast.BlockStmt, v *types.Var) (writes []write, reads []token.Pos, ok bool) {
+	roots := map[*ast.Ident]bool{}
+	ok = true
+
+	var walk func(n ast.Node, loop ast.Node)
+	walk = func(n ast.Node, loop ast.Node) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			if !ok {
+				return false
+			}
+
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				if uses(pass, n, v) {
+					ok = false
+				}
+				return false
+			case *ast.LabeledStmt:
+				ok = false
+			case *ast.BranchStmt:
+				if n.Tok == token.GOTO {
+					ok = false
+				}
+			case *ast.UnaryExpr:
+				if n.Op == token.AND && rootVar(pass, n.X) == v {
+					ok = false
+				}
+			case *ast.SelectorExpr:
+				if addressMethod(pass, n) && rootVar(pass, n.X) == v {
+					ok = false
+				}
+			case *ast.ForStmt, *ast.RangeStmt:
+				if loop == nil {
+					inner := n
+					for _, c := range children(n) {
+						walk(c, inner)
+					}
+					return false
+				}
+			case *ast.AssignStmt:
+				if n.Tok == token.DEFINE {
+					return true
+				}
+				for _, lhs := range n.Lhs {
+					if id := writeRoot(pass, lhs); id != nil && pass.TypesInfo.Uses[id] == v {
+						roots[id] = true
+						writes = append(writes, write{stmt: n, root: id, loop: loop})
+					}
+				}
+			case *ast.IncDecStmt:
+				if id := writeRoot(pass, n.X); id != nil && pass.TypesInfo.Uses[id] == v {
+					roots[id] = true
+					writes = append(writes, write{stmt: n, root: id, loop: loop})
+				}
+			}
+			return true
+		})
+	}
+	walk(body, nil)
+
+	if !ok {
+		return nil, nil, false
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if id, isIdent := n.(*ast.Ident); isIdent && pass.TypesInfo.Uses[id] == v && !roots[id] {
+			reads = append(reads, id.Pos())
+		}
+		return true
+	})
+
+	return writes, reads, true
+}
+
+// children 은 loop 의 자식이다 (없는 자리는 nil)
+func children(n ast.Node) []ast.Node {
+	var out []ast.Node
+	add := func(c ast.Node) {
+		if c != nil && !reflect.ValueOf(c).IsNil() {
+			out = append(out, c)
+		}
+	}
+
+	switch n := n.(type) {
+	case *ast.ForStmt:
+		add(n.Init)
+		add(n.Cond)
+		add(n.Post)
+		add(n.Body)
+	case *ast.RangeStmt:
+		add(n.Key)
+		add(n.Value)
+		add(n.X)
+		add(n.Body)
+	}
+
+	return out
+}
+
+// readAfter 는 같은 반복 안에서 w 뒤에 v 를 읽는지 본다. 안쪽 loop 에서 썼다면 그 loop 의 다음 반복에서 읽을 수 있다.
+func readAfter(w write, reads []token.Pos) bool {
+	for _, r := range reads {
+		if r > w.stmt.End() {
+			return true
+		}
+		if w.loop != nil && w.loop.Pos() <= r && r < w.loop.End() {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+writeRoot 는 값으로만 이어진 쓰기 대상의 변수를 돌려준다
+
+	v = x, v.f = x, v.arr[0] = x, v.inner.f++  -> v
+	v.ptr.f = x, v.slice[0] = x, v.m[k] = x  -> nil (복사본이 아닌 곳을 바꾼다)
+*/
+func writeRoot(pass *analysis.Pass, e ast.Expr) *ast.Ident {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		sel, ok := pass.TypesInfo.Selections[e]
+		if !ok || sel.Kind() != types.FieldVal || sel.Indirect() {
+			return nil
+		}
+		return writeRoot(pass, e.X)
+	case *ast.IndexExpr:
+		if _, ok := pass.TypesInfo.TypeOf(e.X).Underlying().(*types.Array); !ok {
+			return nil
+		}
+		return writeRoot(pass, e.X)
+	}
+
+	return nil
+}
+
+// rootVar 는 v.f.g, v[0] 같은 식의 맨 앞 변수다
+func rootVar(pass *analysis.Pass, e ast.Expr) *types.Var {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		v, _ := pass.TypesInfo.Uses[e].(*types.Var)
+		return v
+	case *ast.SelectorExpr:
+		return rootVar(pass, e.X)
+	case *ast.IndexExpr:
+		return rootVar(pass, e.X)
+	}
+
+	return nil
+}
+
+// addressMethod 는 값에 pointer receiver 메서드를 불러서 주소를 가져가는지 본다
+func addressMethod(pass *analysis.Pass, sel *ast.SelectorExpr) bool {
+	s, ok := pass.TypesInfo.Selections[sel]
+	if !ok || s.Kind() != types.MethodVal {
+		return false
+	}
+
+	sig := s.Obj().Type().(*types.Signature)
+	if sig.Recv() == nil {
+		return false
+	}
+	_, ptrRecv := sig.Recv().Type().(*types.Pointer)
+	_, ptrX := pass.TypesInfo.TypeOf(sel.X).Underlying().(*types.Pointer)
+
+	return ptrRecv && !ptrX
+}
+
+/*
+indexFix 는 balanceB 처럼 v 대신 a[i] 에 쓰도록 바꾼다
+
+	for _, v := range a { v.Balance += 1 }  ->  for i := range a { a[i].Balance += 1 }
+
+v 를 더 쓰지 않으면 (unused) 값 변수를 지우고, 아니면 _ 만 i 로 바꾼다.
+range 대상이 변수나 필드가 아니면 (함수 호출 등) 두번 계산할 수 없으므로 수정안을 내지 않는다.
+rewritten 은 첫번째 쓰기를 바꾼 문장이다.
+*/
+func indexFix(pass *analysis.Pass, rng *ast.RangeStmt, dead []write, unused bool) (edits []analysis.TextEdit, rewritten string) {
+	if !pure(rng.X) {
+		return nil, ""
+	}
+
+	name, rename := indexName(pass, rng, dead)
+	if name == "" {
+		return nil, ""
+	}
+
+	// range 대상의 변수가 쓰는 곳에서 다른 변수로 가려지면 안 된다
+	if root := ident(baseExpr(rng.X)); root != nil {
+		obj := pass.TypesInfo.Uses[root]
+		for _, w := range dead {
+			if lookup(pass, w.stmt.Pos(), root.Name) != obj {
+				return nil, ""
+			}
+		}
+	}
+
+	src, ok := source(pass, dead[0].stmt)
+	if !ok {
+		return nil, ""
+	}
+
+	x := render(pass, rng.X)
+	if _, star := ast.Unparen(rng.X).(*ast.StarExpr); star {
+		x = "(" + x + ")"
+	}
+	elem := x + "[" + name + "]"
+
+	switch {
+	case unused:
+		// for _, v := range a  ->  for i := range a
+		edits = append(edits, analysis.TextEdit{Pos: rng.Key.Pos(), End: rng.Value.End(), NewText: []byte(name)})
+	case rename:
+		edits = append(edits, analysis.TextEdit{Pos: rng.Key.Pos(), End: rng.Key.End(), NewText: []byte(name)})
+	}
+
+	for _, w := range dead {
+		edits = append(edits, analysis.TextEdit{Pos: w.root.Pos(), End: w.root.End(), NewText: []byte(elem)})
+	}
+
+	root := dead[0].root
+	offset := int(root.Pos() - dead[0].stmt.Pos())
+	rewritten = src[:offset] + elem + src[offset+len(root.Name):]
+
+	return edits, rewritten
+}
+
+// source 는 n 의 원래 코드다
+func source(pass *analysis.Pass, n ast.Node) (string, bool) {
+	tf := pass.Fset.File(n.Pos())
+	if tf == nil {
+		return "", false
+	}
+
+	content, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return "", false
+	}
+
+	start, end := tf.Offset(n.Pos()), tf.Offset(n.End())
+	if end > len(content) {
+		return "", false
+	}
+
+	return string(content[start:end]), true
+}
+
+// indexName 은 index 변수 이름이다. 이미 있으면 그대로 쓰고, _ 이면 쓰는 곳에서 겹치지 않는 이름을 고른다.
+func indexName(pass *analysis.Pass, rng *ast.RangeStmt, dead []write) (name string, rename bool) {
+	if key := ident(rng.Key); key != nil && key.Name != "_" {
+		obj := pass.TypesInfo.Defs[key]
+		for _, w := range dead {
+			if lookup(pass, w.stmt.Pos(), key.Name) != obj {
+				return "", false
+			}
+		}
+		return key.Name, false
+	}
+
+	if rng.Key != nil && ident(rng.Key) == nil {
+		return "", false
+	}
+
+	declared := map[string]bool{}
+	ast.Inspect(rng, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			declared[id.Name] = true
+		}
+		return true
+	})
+
+	for _, candidate := range []string{"i", "idx", "i2", "i3"} {
+		if declared[candidate] {
+			continue
+		}
+
+		free := true
+		for _, w := range dead {
+			if lookup(pass, w.stmt.Pos(), candidate) != nil {
+				free = false
+				break
+			}
+		}
+		if free {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+func lookup(pass *analysis.Pass, pos token.Pos, name string) types.Object {
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return nil
+	}
+
+	_, obj := scope.LookupParent(name, pos)
+	return obj
+}
+
+/*
+checkArray 는 큰 배열을 값으로 range 하는 것을 찾는다 (arrayBetterCode)
+
+	for i, v := range big   ->  for i, v := range &big
+
+값 변수가 없으면 배열을 복사하지 않는다 (len 만 쓴다).
+*/
+func (c *checker) checkArray(pass *analysis.Pass, rng *ast.RangeStmt) {
+	if rng.Value == nil {
+		return
+	}
+	if id := ident(rng.Value); id != nil && id.Name == "_" {
+		return
+	}
+
+	t := pass.TypesInfo.TypeOf(rng.X)
+	if t == nil {
+		return
+	}
+
+	arr, ok := t.Underlying().(*types.Array)
+	if !ok {
+		return
+	}
+
+	size := pass.TypesSizes.Sizeof(arr)
+	if size < c.arrayBytes {
+		return
+	}
+
+	x := render(pass, rng.X)
+	diag := analysis.Diagnostic{
+		Pos:     rng.X.Pos(),
+		End:     rng.X.End(),
+		Message: fmt.Sprintf("range over %s copies the whole array (%d bytes) before the loop; range over &%s instead", x, size, x),
+	}
+
+	root := rootVar(pass, rng.X)
+	if addressable(pass, rng.X) && (root == nil || !modifies(pass, rng.Body, root)) {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "range over &" + x,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     rng.X.Pos(),
+				End:     rng.X.Pos(),
+				NewText: []byte("&"),
+			}},
+		}}
+	} else {
+		diag.Message += " (the loop would then see its own writes to " + x + ")"
+	}
+
+	pass.Report(diag)
+}
+
+// addressable 은 & 를 붙일 수 있는 식인지 본다 (변수, 그 필드와 배열 원소, slice 원소, *p)
+func addressable(pass *analysis.Pass, e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		_, ok := pass.TypesInfo.Uses[e].(*types.Var)
+		return ok
+	case *ast.SelectorExpr:
+		sel, ok := pass.TypesInfo.Selections[e]
+		if !ok || sel.Kind() != types.FieldVal {
+			return false
+		}
+		return sel.Indirect() || addressable(pass, e.X)
+	case *ast.IndexExpr:
+		switch pass.TypesInfo.TypeOf(e.X).Underlying().(type) {
+		case *types.Slice, *types.Pointer:
+			return true
+		case *types.Array:
+			return addressable(pass, e.X)
+		}
+	case *ast.StarExpr:
+		return true
+	}
+
+	return false
+}
+
+/*
+modifies 는 본문에서 v 에 쓰거나 주소를 가져가는지 본다 (&v 로 range 하면 결과가 달라진다)
+
+	v.arr[0] = 1, &v.arr, v.arr[:]   // 직접 쓰거나 주소를 가져간다
+	v.bump()                         // 메서드는 (pointer 인 v 를 통해서든, 주소를 가져가서든) 바꿀 수 있다
+	mutate(v)                        // v 가 pointer, slice, map 이면 호출된 함수가 바꿀 수 있다
+*/
+func modifies(pass *analysis.Pass, body *ast.BlockStmt, v *types.Var) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if rootVar(pass, lhs) == v {
+					found = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if rootVar(pass, n.X) == v {
+				found = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && rootVar(pass, n.X) == v {
+				found = true
+			}
+		case *ast.SliceExpr:
+			if _, ok := pass.TypesInfo.TypeOf(n.X).Underlying().(*types.Array); ok && rootVar(pass, n.X) == v {
+				found = true
+			}
+		case *ast.SelectorExpr:
+			if sel, ok := pass.TypesInfo.Selections[n]; ok && sel.Kind() == types.MethodVal && rootVar(pass, n.X) == v {
+				found = true
+			}
+		case *ast.CallExpr:
+			if b, ok := typeutil.Callee(pass.TypesInfo, n).(*types.Builtin); ok && b.Name() != "copy" && b.Name() != "clear" {
+				break
+			}
+			for _, arg := range n.Args {
+				if rootVar(pass, arg) == v && reference(pass.TypesInfo.TypeOf(arg)) {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+
+	return found
+}
+
+// reference 는 함수에 넘기면 받은 쪽이 원래 값을 바꿀 수 있는 타입이다
+func reference(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map:
+		return true
+	}
+
+	return false
+}
+
+func uses(pass *analysis.Pass, n ast.Node, v *types.Var) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[id] == v {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
+
+// pure 는 식을 다시 계산해도 같은 값인지 본다 (변수, 필드만 허용)
+func pure(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return pure(x.X)
+	case *ast.ParenExpr:
+		return pure(x.X)
+	case *ast.StarExpr:
+		return pure(x.X)
+	}
+
+	return false
+}
+
+// baseExpr 는 a.b.c, *p 의 맨 앞 식이다
+func baseExpr(x ast.Expr) ast.Expr {
+	switch e := x.(type) {
+	case *ast.SelectorExpr:
+		return baseExpr(e.X)
+	case *ast.ParenExpr:
+		return baseExpr(e.X)
+	case *ast.StarExpr:
+		return baseExpr(e.X)
+	}
+
+	return x
+}
+
+func ident(e ast.Expr) *ast.Ident {
+	if e == nil {
+		return nil
+	}
+
+	id, _ := ast.Unparen(e).(*ast.Ident)
+	return id
+}
+
+func render(pass *analysis.Pass, n ast.Node) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, pass.Fset, n); err != nil {
+		return strconv.Quote(fmt.Sprint(n))
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,22 @@
+package rangecopy_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/zkfmapf123/100/analyzers/rangecopy"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), rangecopy.Analyzer, "a")
+}
+
+func TestArrayBytes(t *testing.T) {
+	if err := rangecopy.Analyzer.Flags.Set("array-bytes", "16"); err != nil {
+		t.Fatal(err)
+	}
+	defer rangecopy.Analyzer.Flags.Set("array-bytes", "1024")
+
+	analysistest.Run(t, analysistest.TestData(), rangecopy.Analyzer, "b")
+}
@@ -0,0 +1,215 @@
+package a
+
+import "fmt"
+
+type Account struct {
+	Balance int
+	History [4]int
+	Owner   *Owner
+	Tags    []string
+}
+
+type Owner struct {
+	Name string
+}
+
+func (a *Account) Deposit(n int) { a.Balance += n }
+
+func (a Account) Total() int { return a.Balance }
+
+var accounts = []Account{{Balance: 1000}, {Balance: 2000}}
+
+// 21.go 의 balanceA
+func balanceA() {
+	for _, v := range accounts {
+		v.Balance += 1 // want `v.Balance \+= 1 only changes v, a copy of the element of accounts; the change is lost after the iteration; write through the index: accounts\[i\].Balance \+= 1`
+	}
+}
+
+// balanceB 는 그대로 둔다
+func balanceB() {
+	for i := range accounts {
+		accounts[i].Balance += 1
+	}
+}
+
+func withIndex(list []Account) {
+	for j, v := range list {
+		fmt.Println(j)
+		v.Balance = 0 // want `v.Balance = 0 only changes v, a copy of the element of list; .* \(and 2 more writes\); write through the index: list\[j\].Balance = 0`
+		v.History[1]++
+		v = Account{}
+	}
+}
+
+// 읽은 뒤에 쓰면 i 로 바꾸고 v 는 남긴다
+func readBefore(list []Account) {
+	for _, v := range list {
+		fmt.Println(v.Total())
+		v.Balance *= 2 // want `v.Balance \*= 2 only changes v.*write through the index: list\[i\].Balance \*= 2`
+	}
+}
+
+// i 가 이미 있으면 다른 이름을 쓴다
+func nameTaken(list []Account, i int) {
+	for _, v := range list {
+		v.Balance = i // want `v.Balance = i only changes v.*write through the index: list\[idx\].Balance = i`
+	}
+}
+
+type bank struct {
+	accounts [3]Account
+}
+
+func (b *bank) reset() {
+	for _, acc := range b.accounts {
+		acc.Balance = 0 // want `acc.Balance = 0 only changes acc, a copy of the element of b.accounts.*b.accounts\[i\].Balance = 0`
+	}
+}
+
+func pointerToArray(p *[3]Account) {
+	for _, v := range *p {
+		v.Balance = 1 // want `write through the index: \(\*p\)\[i\].Balance = 1`
+	}
+}
+
+func load() []Account { return accounts }
+
+// range 대상을 다시 계산할 수 없으면 수정안 없이 보고한다
+func fromCall() {
+	for _, v := range load() {
+		v.Balance = 1 // want `v.Balance = 1 only changes v, a copy of the element of load\(\); the change is lost after the iteration$`
+	}
+}
+
+// 쓴 뒤에 읽으면 의미가 있다
+func readAfter(list []Account) {
+	for _, v := range list {
+		v.Balance += 10
+		fmt.Println(v.Balance)
+	}
+}
+
+// 안쪽 loop 의 다음 반복에서 읽는다
+func readInInnerLoop(list []Account) {
+	for _, v := range list {
+		for range 3 {
+			fmt.Println(v.Balance)
+			v.Balance++
+		}
+	}
+}
+
+// 주소를 가져가면 빠져나갈 수 있다
+func addressTaken(list []Account) {
+	var keep []*Account
+	for _, v := range list {
+		v.Balance = 1
+		keep = append(keep, &v)
+	}
+	fmt.Println(keep)
+}
+
+func pointerMethod(list []Account) {
+	for _, v := range list {
+		v.Balance = 1
+		v.Deposit(1)
+	}
+}
+
+func closure(list []Account) {
+	for _, v := range list {
+		v.Balance = 1
+		defer func() { fmt.Println(v) }()
+	}
+}
+
+// pointer, slice 를 거친 쓰기는 원본을 바꾼다
+func throughPointer(list []Account) {
+	for _, v := range list {
+		v.Owner.Name = "kim"
+		v.Tags[0] = "vip"
+	}
+}
+
+func pointers(list []*Account) {
+	for _, v := range list {
+		v.Balance = 1
+	}
+}
+
+func ints(list []int) {
+	for _, v := range list {
+		v++
+	}
+}
+
+var big [256]int
+
+func bigArray() {
+	for i, v := range big { // want `range over big copies the whole array \(2048 bytes\) before the loop; range over &big instead`
+		fmt.Println(i, v)
+	}
+
+	// 값 변수가 없으면 복사하지 않는다
+	for i := range big {
+		fmt.Println(i)
+	}
+	for i, _ := range big {
+		fmt.Println(i)
+	}
+
+	// 이미 pointer 다
+	for i, v := range &big {
+		fmt.Println(i, v)
+	}
+
+	small := [3]int{1, 2, 3}
+	for i, v := range small {
+		fmt.Println(i, v)
+	}
+}
+
+// range_ex1 처럼 본문에서 배열을 바꾸면 &big 은 v 를 바꾼다
+func bigArrayModified() {
+	for i, v := range big { // want `range over big copies the whole array \(2048 bytes\) before the loop; range over &big instead \(the loop would then see its own writes to big\)$`
+		big[255] = 10
+		fmt.Println(i, v)
+	}
+}
+
+type holder struct{ arr [256]int }
+
+func (h *holder) bump() { h.arr[0]++ }
+
+func mutate(h *holder) { h.arr[1]++ }
+
+// h.bump(), mutate(h) 는 h.arr 를 바꿀 수 있으므로 &h.arr 로 바꾸지 않는다
+func bigArrayThroughPointer(h *holder) {
+	for _, v := range h.arr { // want `range over h.arr copies the whole array \(2048 bytes\) before the loop; range over &h.arr instead \(the loop would then see its own writes to h.arr\)$`
+		h.bump()
+		fmt.Println(v)
+	}
+
+	for _, v := range h.arr { // want `range over h.arr copies the whole array \(2048 bytes\) before the loop; range over &h.arr instead \(the loop would then see its own writes to h.arr\)$`
+		mutate(h)
+		fmt.Println(v)
+	}
+
+	for _, v := range big { // want `range over big copies the whole array \(2048 bytes\) before the loop; range over &big instead \(the loop would then see its own writes to big\)$`
+		clear(big[:])
+		fmt.Println(v)
+	}
+
+	for _, v := range h.arr { // want `range over h.arr copies the whole array \(2048 bytes\) before the loop; range over &h.arr instead$`
+		fmt.Println(len(h.arr), v)
+	}
+}
+
+func newBig() [256]int { return big }
+
+func bigArrayCall() {
+	for _, v := range newBig() { // want `range over newBig\(\) copies the whole array`
+		fmt.Println(v)
+	}
+}
@@ -0,0 +1,26 @@
+package b
+
+import "fmt"
+
+// -array-bytes=16 이면 21.go 의 range_ex1 도 보고한다
+func rangeEx1() {
+	arrayA := [3]int{1, 2, 3}
+	for i, v := range arrayA { // want `range over arrayA copies the whole array \(24 bytes\) before the loop; range over &arrayA instead \(the loop would then see its own writes to arrayA\)`
+		arrayA[2] = 10
+
+		if i == 2 {
+			fmt.Println(v)
+		}
+	}
+}
+
+func arrayBetterCode() {
+	a := [3]int{1, 2, 3}
+	for i, v := range &a {
+		a[2] = 10
+
+		if i == 2 {
+			fmt.Println(v)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// shadow 는 shadow analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/shadow"
+)
+
+func main() {
+	singlechecker.Main(shadow.Analyzer)
+}
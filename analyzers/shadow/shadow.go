@@ -0,0 +1,257 @@
+package shadow
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+/*
+1.go 의 HttpTracing 처럼 중첩 블록 안에서 := 로 바깥 변수를 가리는 경우를 찾는다.
+
+	var client *http.Client
+	if tracing {
+		client, _ := createClient() // ❌ 바깥 client 는 여전히 nil
+	}
+	return client
+
+바깥 변수가 zero value 로 선언되었고, 가림 이후에 한번도 대입되지 않은 채로 읽힐 때만 보고한다.
+가능하면 GoodHttpTracingPattern 처럼 = 대입으로 바꾸는 수정안을 함께 제공한다.
+*/
+var Analyzer = &analysis.Analyzer{
+	Name:     "shadow",
+	Doc:      "reports := declarations in nested blocks that shadow an outer zero-valued variable which is read later",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		var (
+			typ  *ast.FuncType
+			body *ast.BlockStmt
+		)
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			typ, body = fn.Type, fn.Body
+		case *ast.FuncLit:
+			typ, body = fn.Type, fn.Body
+		}
+
+		if body == nil {
+			return
+		}
+
+		checkFunc(pass, pass.TypesInfo.Scopes[typ], body)
+	})
+
+	return nil, nil
+}
+
+// varUsage 는 함수 안에서 하나의 바깥 변수가 어디서 쓰이고 읽히는지 기록한다
+type varUsage struct {
+	zeroDecl bool
+	writes   []token.Pos
+	reads    []token.Pos
+}
+
+func checkFunc(pass *analysis.Pass, funcScope *types.Scope, body *ast.BlockStmt) {
+	if funcScope == nil {
+		return
+	}
+
+	usages := collectUsages(pass.TypesInfo, body)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		// 중첩 함수는 별도로 검사한다
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE {
+			return true
+		}
+
+		checkAssign(pass, funcScope, body, usages, assign)
+		return true
+	})
+}
+
+func checkAssign(pass *analysis.Pass, funcScope *types.Scope, body *ast.BlockStmt, usages map[*types.Var]*varUsage, assign *ast.AssignStmt) {
+	var (
+		reported []*ast.Ident
+		fixable  = true
+	)
+
+	for _, lhs := range assign.Lhs {
+		id, ok := lhs.(*ast.Ident)
+		if !ok || id.Name == "_" {
+			continue
+		}
+
+		inner, ok := pass.TypesInfo.Defs[id].(*types.Var)
+		if !ok {
+			// 이미 선언된 변수의 재대입이므로 = 로 바꿔도 문제 없다
+			continue
+		}
+
+		outer := shadowedVar(inner, funcScope, body)
+		if outer == nil || !isReadWhileZero(usages[outer], assign.End()) {
+			fixable = false
+			continue
+		}
+
+		if !types.AssignableTo(inner.Type(), outer.Type()) {
+			fixable = false
+		}
+
+		reported = append(reported, id)
+	}
+
+	for _, id := range reported {
+		outer := shadowedVar(pass.TypesInfo.Defs[id].(*types.Var), funcScope, body)
+		line := pass.Fset.Position(outer.Pos()).Line
+
+		diag := analysis.Diagnostic{
+			Pos:     id.Pos(),
+			End:     id.End(),
+			Message: fmt.Sprintf("declaration of %q shadows variable declared at line %d, which is read later while still zero", id.Name, line),
+		}
+
+		if fixable {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Assign to the outer variable with = instead of declaring a new one",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     assign.TokPos,
+					End:     assign.TokPos + token.Pos(len(token.DEFINE.String())),
+					NewText: []byte(token.ASSIGN.String()),
+				}},
+			}}
+		}
+
+		pass.Report(diag)
+	}
+}
+
+// shadowedVar 는 inner 가 가리고 있는 같은 함수 안의 바깥 변수를 찾는다
+func shadowedVar(inner *types.Var, funcScope *types.Scope, body *ast.BlockStmt) *types.Var {
+	scope := inner.Parent()
+	if scope == nil || scope == funcScope {
+		return nil
+	}
+
+	_, obj := scope.Parent().LookupParent(inner.Name(), inner.Pos())
+	outer, ok := obj.(*types.Var)
+	if !ok || outer.Pos() < body.Pos() || outer.Pos() > body.End() {
+		return nil
+	}
+
+	return outer
+}
+
+// isReadWhileZero 는 after 이후에 대입 없이 읽히는 경우가 있는지 확인한다
+func isReadWhileZero(u *varUsage, after token.Pos) bool {
+	if u == nil || !u.zeroDecl {
+		return false
+	}
+
+	for _, read := range u.reads {
+		if read < after {
+			continue
+		}
+
+		written := false
+		for _, w := range u.writes {
+			if w < read {
+				written = true
+				break
+			}
+		}
+
+		if !written {
+			return true
+		}
+	}
+
+	return false
+}
+
+func collectUsages(info *types.Info, body *ast.BlockStmt) map[*types.Var]*varUsage {
+	usages := map[*types.Var]*varUsage{}
+	usage := func(v *types.Var) *varUsage {
+		u, ok := usages[v]
+		if !ok {
+			u = &varUsage{}
+			usages[v] = u
+		}
+		return u
+	}
+
+	writeIdents := map[*ast.Ident]bool{}
+	markWrite := func(e ast.Expr) {
+		if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+			writeIdents[id] = true
+		}
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			if len(n.Values) != 0 {
+				break
+			}
+			for _, id := range n.Names {
+				if v, ok := info.Defs[id].(*types.Var); ok {
+					usage(v).zeroDecl = true
+				}
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				markWrite(lhs)
+			}
+		case *ast.IncDecStmt:
+			markWrite(n.X)
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN {
+				markWrite(n.Key)
+				markWrite(n.Value)
+			}
+		case *ast.UnaryExpr:
+			// 포인터가 새어나가면 어디서든 바뀔 수 있으므로 쓰기로 본다
+			if n.Op == token.AND {
+				markWrite(n.X)
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		v, ok := info.Uses[id].(*types.Var)
+		if !ok {
+			return true
+		}
+
+		if writeIdents[id] {
+			usage(v).writes = append(usage(v).writes, id.Pos())
+		} else {
+			usage(v).reads = append(usage(v).reads, id.Pos())
+		}
+		return true
+	})
+
+	return usages
+}
@@ -0,0 +1,97 @@
+package a
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func createClient() (*http.Client, error) {
+	return &http.Client{}, nil
+}
+
+// 1.go 의 HttpTracing 을 client 를 돌려주도록 바꾼 형태
+func HttpTracing(tracing bool) *http.Client {
+	var client *http.Client
+
+	if tracing {
+		client, _ := createClient() // want `declaration of "client" shadows variable declared at line 14, which is read later while still zero`
+		fmt.Println("tracing ... ", client)
+	} else {
+		client, _ := createClient() // want `declaration of "client" shadows variable declared at line 14, which is read later while still zero`
+		fmt.Println("None Tracing ... ", client)
+	}
+
+	return client
+}
+
+// 새로 선언되는 err 때문에 = 로 바꿀 수 없으므로 수정안은 없다
+func HttpTracingWithErr(tracing bool) *http.Client {
+	var client *http.Client
+
+	if tracing {
+		client, err := createClient() // want `declaration of "client" shadows`
+		fmt.Println(client, err)
+	}
+
+	return client
+}
+
+func GoodHttpTracingPattern(t bool) *http.Client {
+	var client *http.Client
+
+	if t {
+		c, _ := createClient()
+		client = c
+	} else {
+		c, _ := createClient()
+		client = c
+	}
+
+	return client
+}
+
+func BetterHttpTracingPattern(t bool) *http.Client {
+	if t {
+		client, _ := createClient()
+		return client
+	}
+
+	client, _ := createClient()
+	return client
+}
+
+// 바깥 변수가 가림 이전에 대입되었다면 zero value 가 아니다
+func AssignedBefore(t bool) *http.Client {
+	var client *http.Client
+	client = &http.Client{}
+
+	if t {
+		client, _ := createClient()
+		fmt.Println(client)
+	}
+
+	return client
+}
+
+// 바깥 변수를 다시 읽지 않으면 보고하지 않는다
+func NeverRead(t bool) {
+	var client *http.Client
+	_ = client
+
+	if t {
+		client, _ := createClient()
+		fmt.Println(client)
+	}
+}
+
+// 초기값이 있는 바깥 변수는 대상이 아니다
+func Initialized(t bool) *http.Client {
+	client := &http.Client{}
+
+	if t {
+		client, _ := createClient()
+		fmt.Println(client)
+	}
+
+	return client
+}
@@ -0,0 +1,12 @@
+// subslice 는 subslice analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/subslice"
+)
+
+func main() {
+	singlechecker.Main(subslice.Analyzer)
+}
@@ -0,0 +1,541 @@
+package subslice
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/zkfmapf123/100/analyzers/internal/passutil"
+)
+
+/*
+19.go 처럼 큰 buffer 의 일부 (sub-slice) 를 오래 사는 곳에 저장해서 backing array 전체를 붙잡는 코드를 찾는다.
+
+	func getMessageType(msg []byte) []byte {
+		return msg[:5]                        // 파라미터의 sub-slice 를 돌려준다
+	}
+
+	msg := receiveMessage()
+	storageMessage(getMessageType(msg))      // ❌ 5 byte 를 위해 msg 전체가 남는다
+	storageMessage(slices.Clone(getMessageType(msg))) // ✅ consumeMessageGood 처럼 복사한다
+
+저장하는 곳: struct 필드, map, channel, 전역 변수, 그리고 인자를 그런 곳에 저장하는 함수.
+"파라미터의 sub-slice 를 돌려준다", "파라미터를 저장한다" 는 함수 fact 로 남겨서 다른 패키지에서도 쓴다.
+s.queue = s.queue[1:] 처럼 자기 자신을 자르는 경우는 보고하지 않는다.
+*/
+var Analyzer = &analysis.Analyzer{
+	Name:      "subslice",
+	Doc:       "reports sub-slices of buffers that escape into struct fields, maps, channels or globals and pin the whole backing array",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(returnsSubslice), new(storesParam)},
+}
+
+// returnsSubslice 는 함수가 Params 번째 파라미터의 sub-slice 를 돌려줄 수 있다는 fact 다
+type returnsSubslice struct{ Params []int }
+
+func (*returnsSubslice) AFact() {}
+
+func (f *returnsSubslice) String() string { return fmt.Sprintf("returnsSubslice%v", f.Params) }
+
+// storesParam 은 함수가 Params 번째 파라미터를 오래 사는 곳에 저장한다는 fact 다
+type storesParam struct{ Params []int }
+
+func (*storesParam) AFact() {}
+
+func (f *storesParam) String() string { return fmt.Sprintf("storesParam%v", f.Params) }
+
+type summary struct {
+	returns map[int]bool
+	stores  map[int]bool
+}
+
+func (s *summary) size() int { return len(s.returns) + len(s.stores) }
+
+type checker struct {
+	pass      *analysis.Pass
+	summaries map[*types.Func]*summary
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	var decls []*ast.FuncDecl
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		if decl := n.(*ast.FuncDecl); decl.Body != nil {
+			decls = append(decls, decl)
+		}
+	})
+
+	c := &checker{pass: pass, summaries: map[*types.Func]*summary{}}
+
+	// 패키지 안에서 서로 부르는 함수가 있으므로 요약이 바뀌지 않을 때까지 반복한다
+	for changed := true; changed; {
+		changed = false
+		for _, decl := range decls {
+			fn, _ := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if fn == nil {
+				continue
+			}
+
+			sum := &summary{returns: map[int]bool{}, stores: map[int]bool{}}
+			c.walk(decl, sum, false)
+
+			if old := c.summaries[fn]; old == nil || old.size() != sum.size() {
+				c.summaries[fn] = sum
+				changed = true
+			}
+		}
+	}
+
+	for fn, sum := range c.summaries {
+		if len(sum.returns) > 0 {
+			pass.ExportObjectFact(fn, &returnsSubslice{Params: sortedKeys(sum.returns)})
+		}
+		if len(sum.stores) > 0 {
+			pass.ExportObjectFact(fn, &storesParam{Params: sortedKeys(sum.stores)})
+		}
+	}
+
+	for _, decl := range decls {
+		c.walk(decl, &summary{returns: map[int]bool{}, stores: map[int]bool{}}, true)
+	}
+
+	return nil, nil
+}
+
+func sortedKeys(m map[int]bool) []int {
+	out := make([]int, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	slices.Sort(out)
+
+	return out
+}
+
+func (c *checker) returns(fn *types.Func, i int) bool {
+	if sum, ok := c.summaries[fn]; ok {
+		return sum.returns[i]
+	}
+
+	var fact returnsSubslice
+	return c.pass.ImportObjectFact(fn, &fact) && slices.Contains(fact.Params, i)
+}
+
+func (c *checker) stores(fn *types.Func, i int) bool {
+	if sum, ok := c.summaries[fn]; ok {
+		return sum.stores[i]
+	}
+
+	var fact storesParam
+	return c.pass.ImportObjectFact(fn, &fact) && slices.Contains(fact.Params, i)
+}
+
+// origin 은 sub-slice 가 가리키는 buffer 다 (param 은 파라미터 순서, 파라미터가 아니면 -1)
+type origin struct {
+	buf   string
+	param int
+}
+
+type walker struct {
+	*checker
+	sum    *summary
+	report bool
+
+	// params 는 slice 파라미터의 순서, aliases 는 b := msg 같은 별칭이다
+	params  map[*types.Var]int
+	aliases map[*types.Var]int
+	// subs 는 sub-slice 를 담은 지역 변수다
+	subs map[*types.Var]origin
+}
+
+func (c *checker) walk(decl *ast.FuncDecl, sum *summary, report bool) {
+	w := &walker{
+		checker: c,
+		sum:     sum,
+		report:  report,
+		params:  map[*types.Var]int{},
+		aliases: map[*types.Var]int{},
+		subs:    map[*types.Var]origin{},
+	}
+
+	i := 0
+	for _, field := range decl.Type.Params.List {
+		if len(field.Names) == 0 {
+			i++
+			continue
+		}
+		for _, name := range field.Names {
+			if v, ok := c.pass.TypesInfo.Defs[name].(*types.Var); ok {
+				w.params[v] = i
+			}
+			i++
+		}
+	}
+
+	w.stmts(decl.Body, false)
+}
+
+func (w *walker) stmts(body ast.Node, inLit bool) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			w.stmts(n.Body, true)
+			return false
+		case *ast.AssignStmt:
+			w.assign(n.Lhs, n.Rhs, n.Tok)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, 0, len(n.Names))
+			for _, name := range n.Names {
+				lhs = append(lhs, name)
+			}
+			w.assign(lhs, n.Values, token.DEFINE)
+		case *ast.SendStmt:
+			w.escape(n.Chan, n.Value, "is sent on channel "+types.ExprString(n.Chan))
+		case *ast.CallExpr:
+			w.call(n)
+		case *ast.ReturnStmt:
+			if inLit {
+				return true
+			}
+			for _, res := range n.Results {
+				if o, ok := w.subOf(res); ok && o.param >= 0 {
+					w.sum.returns[o.param] = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+func (w *walker) assign(lhs, rhs []ast.Expr, tok token.Token) {
+	if len(lhs) != len(rhs) {
+		for _, l := range lhs {
+			w.forget(l)
+		}
+		return
+	}
+
+	for i, l := range lhs {
+		if desc, ok := w.sink(l); ok {
+			w.escape(l, rhs[i], desc)
+			continue
+		}
+
+		if tok != token.DEFINE && tok != token.ASSIGN {
+			continue
+		}
+
+		v := w.localVar(l)
+		if v == nil {
+			continue
+		}
+
+		w.forget(l)
+		if o, ok := w.subOf(rhs[i]); ok {
+			w.subs[v] = o
+		} else if p, ok := w.param(rhs[i]); ok {
+			w.aliases[v] = p
+		}
+	}
+}
+
+func (w *walker) forget(e ast.Expr) {
+	if v := w.localVar(e); v != nil {
+		delete(w.subs, v)
+		delete(w.aliases, v)
+	}
+}
+
+// call 은 인자를 저장하는 함수에 sub-slice 나 파라미터를 넘기는지 본다
+func (w *walker) call(call *ast.CallExpr) {
+	fn, _ := typeutil.Callee(w.pass.TypesInfo, call).(*types.Func)
+	if fn == nil {
+		return
+	}
+
+	for i, arg := range call.Args {
+		if call.Ellipsis.IsValid() && i == len(call.Args)-1 {
+			continue
+		}
+		if w.stores(fn, i) {
+			w.escape(nil, arg, fmt.Sprintf("is passed to %s, which stores it", fn.Name()))
+		}
+	}
+}
+
+/*
+escape 는 value 가 오래 사는 곳 (desc) 으로 나갈 때 호출된다
+
+  - value 가 sub-slice 이면 보고한다 (report 일 때)
+  - value 가 파라미터 자체이면 함수 요약에 storesParam 을 남긴다
+
+composite literal 의 원소, &T{...}, append 의 원소도 함께 나간다.
+*/
+func (w *walker) escape(dst, value ast.Expr, desc string) {
+	for _, e := range carried(w.pass, value) {
+		if p, ok := w.param(e); ok {
+			w.sum.stores[p] = true
+			continue
+		}
+
+		o, ok := w.subOf(e)
+		if !ok || !w.report {
+			continue
+		}
+
+		// s.queue = s.queue[1:] 처럼 자기 자신을 다시 자르는 경우
+		if dst != nil && o.buf == types.ExprString(dst) {
+			continue
+		}
+
+		w.pass.Report(analysis.Diagnostic{
+			Pos:            e.Pos(),
+			End:            e.End(),
+			Message:        fmt.Sprintf("%s is a sub-slice of %s and %s; it keeps the whole backing array alive, store a copy", types.ExprString(e), o.buf, desc),
+			SuggestedFixes: w.cloneFix(e),
+		})
+	}
+}
+
+func carried(pass *analysis.Pass, e ast.Expr) []ast.Expr {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.CompositeLit:
+		var out []ast.Expr
+		for _, elt := range e.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			out = append(out, carried(pass, elt)...)
+		}
+		return out
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return carried(pass, e.X)
+		}
+	case *ast.CallExpr:
+		if passutil.IsBuiltin(pass, e.Fun, "append") {
+			var out []ast.Expr
+			for i, arg := range e.Args {
+				// append(dst, sub...) 는 원소를 복사한다
+				if e.Ellipsis.IsValid() && i == len(e.Args)-1 {
+					continue
+				}
+				out = append(out, carried(pass, arg)...)
+			}
+			return out
+		}
+	}
+
+	return []ast.Expr{e}
+}
+
+/*
+sink 는 대입의 왼쪽이 오래 사는 곳인지 본다
+
+	g = v          // 전역 변수
+	s.field = v    // struct 필드
+	m[k] = v       // map
+	s.items[i] = v // 필드나 전역 변수의 원소
+*/
+func (w *walker) sink(e ast.Expr) (string, bool) {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		v, ok := w.pass.TypesInfo.ObjectOf(e).(*types.Var)
+		if ok && v.Parent() == w.pass.Pkg.Scope() {
+			return "is stored in global " + e.Name, true
+		}
+	case *ast.SelectorExpr:
+		if sel, ok := w.pass.TypesInfo.Selections[e]; ok && sel.Kind() == types.FieldVal {
+			return "is stored in field " + types.ExprString(e), true
+		}
+		if v, ok := w.pass.TypesInfo.Uses[e.Sel].(*types.Var); ok && !v.IsField() {
+			return "is stored in global " + types.ExprString(e), true
+		}
+	case *ast.IndexExpr:
+		if _, ok := w.pass.TypesInfo.TypeOf(e.X).Underlying().(*types.Map); ok {
+			return "is stored in map " + types.ExprString(e.X), true
+		}
+		return w.sink(e.X)
+	}
+
+	return "", false
+}
+
+/*
+subOf 는 e 가 어떤 buffer 의 sub-slice 인지 본다
+
+	msg[:5], msg[i:j]       // 경계를 준 slice 식 (msg[:], msg[:0], msg[:cap(msg)] 는 아니다)
+	t                       // t := msg[:5]
+	getMessageType(msg)     // returnsSubslice fact 가 있는 함수
+*/
+func (w *walker) subOf(e ast.Expr) (origin, bool) {
+	e = ast.Unparen(e)
+	info := w.pass.TypesInfo
+
+	switch e := e.(type) {
+	case *ast.Ident:
+		if v, ok := info.Uses[e].(*types.Var); ok {
+			o, ok := w.subs[v]
+			return o, ok
+		}
+	case *ast.SliceExpr:
+		if w.whole(e) {
+			return origin{}, false
+		}
+		if _, ok := info.TypeOf(e.X).Underlying().(*types.Slice); !ok {
+			return origin{}, false
+		}
+		return w.buffer(e.X)
+	case *ast.CallExpr:
+		fn, _ := typeutil.Callee(info, e).(*types.Func)
+		if fn == nil {
+			return origin{}, false
+		}
+		for i, arg := range e.Args {
+			if w.returns(fn, i) {
+				if o, ok := w.buffer(arg); ok {
+					return o, true
+				}
+			}
+		}
+	}
+
+	return origin{}, false
+}
+
+// whole 은 backing array 전체를 그대로 넘기는 slice 식이다.
+// pool 에 돌려주는 b[:0] 나 b[:cap(b)] 는 일부만 남기는 게 아니라 buffer 를 다시 쓰려는 것이다.
+func (w *walker) whole(e *ast.SliceExpr) bool {
+	if e.Low != nil && !w.zero(e.Low) {
+		return false
+	}
+
+	if e.High == nil || w.zero(e.High) {
+		return true
+	}
+
+	// b[:len(b)], b[:cap(b)], b[0:len(b):cap(b)]
+	call, ok := ast.Unparen(e.High).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+	builtin, ok := typeutil.Callee(w.pass.TypesInfo, call).(*types.Builtin)
+	if !ok || (builtin.Name() != "len" && builtin.Name() != "cap") {
+		return false
+	}
+
+	return types.ExprString(call.Args[0]) == types.ExprString(e.X)
+}
+
+func (w *walker) zero(e ast.Expr) bool {
+	tv, ok := w.pass.TypesInfo.Types[e]
+	return ok && tv.Value != nil && tv.Value.String() == "0"
+}
+
+// buffer 는 잘리는 쪽의 buffer 다.
+// composite literal 은 작은 값이고, os.Args 같은 전역 변수는 이미 오래 살기 때문에 제외한다.
+func (w *walker) buffer(e ast.Expr) (origin, bool) {
+	e = ast.Unparen(e)
+	if _, ok := e.(*ast.CompositeLit); ok {
+		return origin{}, false
+	}
+	if w.global(e) {
+		return origin{}, false
+	}
+
+	if o, ok := w.subOf(e); ok {
+		return o, true
+	}
+	if p, ok := w.param(e); ok {
+		return origin{buf: types.ExprString(e), param: p}, true
+	}
+
+	return origin{buf: types.ExprString(e), param: -1}, true
+}
+
+func (w *walker) global(e ast.Expr) bool {
+	var id *ast.Ident
+	switch e := e.(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return false
+	}
+
+	v, ok := w.pass.TypesInfo.Uses[id].(*types.Var)
+	return ok && !v.IsField() && v.Parent() == v.Pkg().Scope()
+}
+
+func (w *walker) param(e ast.Expr) (int, bool) {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return 0, false
+	}
+
+	v, ok := w.pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok {
+		return 0, false
+	}
+	if _, ok := v.Type().Underlying().(*types.Slice); !ok {
+		return 0, false
+	}
+
+	if p, ok := w.params[v]; ok {
+		return p, true
+	}
+	p, ok := w.aliases[v]
+	return p, ok
+}
+
+func (w *walker) localVar(e ast.Expr) *types.Var {
+	id := ident(e)
+	if id == nil {
+		return nil
+	}
+
+	v, ok := w.pass.TypesInfo.ObjectOf(id).(*types.Var)
+	if !ok || v.Parent() == w.pass.Pkg.Scope() {
+		return nil
+	}
+
+	return v
+}
+
+// cloneFix 는 e 를 slices.Clone(e) 로 감싼다
+func (w *walker) cloneFix(e ast.Expr) []analysis.SuggestedFix {
+	file := passutil.File(w.pass, e)
+	if file == nil {
+		return nil
+	}
+
+	name, importEdit, ok := passutil.ImportSlices(w.pass, file, e.Pos())
+	if !ok {
+		return nil
+	}
+
+	edits := []analysis.TextEdit{
+		{Pos: e.Pos(), End: e.Pos(), NewText: []byte(name + ".Clone(")},
+		{Pos: e.End(), End: e.End(), NewText: []byte(")")},
+	}
+	if importEdit != nil {
+		edits = append(edits, *importEdit)
+	}
+
+	return []analysis.SuggestedFix{{Message: "store a copy with slices.Clone", TextEdits: edits}}
+}
+
+func ident(e ast.Expr) *ast.Ident {
+	id, _ := ast.Unparen(e).(*ast.Ident)
+	return id
+}
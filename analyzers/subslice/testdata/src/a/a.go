@@ -0,0 +1,120 @@
+package a
+
+import "bytes"
+
+func receiveMessage() []byte {
+	return make([]byte, 1<<20)
+}
+
+// getMessageType 는 파라미터의 sub-slice 를 돌려준다
+func getMessageType(msg []byte) []byte { // want getMessageType:`returnsSubslice\[0\]`
+	return msg[:5]
+}
+
+var storage [][]byte
+
+// StorageMessage 는 인자를 전역 변수에 저장한다
+func StorageMessage(b []byte) { // want StorageMessage:`storesParam\[0\]`
+	storage = append(storage, b)
+}
+
+// keep 은 StorageMessage 를 거쳐서 저장한다
+func keep(b []byte) { // want keep:`storesParam\[0\]`
+	alias := b
+	StorageMessage(alias)
+}
+
+// ❌ 19.go 의 consumeMessages
+func consumeMessages() {
+	for range 10 {
+		msg := receiveMessage()
+
+		StorageMessage(getMessageType(msg)) // want `getMessageType\(msg\) is a sub-slice of msg and is passed to StorageMessage, which stores it; it keeps the whole backing array alive, store a copy`
+	}
+}
+
+type Message struct {
+	Type []byte
+	Body []byte
+}
+
+type Server struct {
+	last    []byte
+	byType  map[string][]byte
+	queue   [][]byte
+	headers [][]byte
+	out     chan []byte
+}
+
+func (s *Server) handle(buf []byte, n int) {
+	s.last = buf[:n] // want `buf\[:n\] is a sub-slice of buf and is stored in field s.last`
+
+	typ := buf[:5]
+	s.byType[string(typ)] = typ // want `typ is a sub-slice of buf and is stored in map s.byType`
+
+	s.out <- buf[5:n] // want `buf\[5:n\] is a sub-slice of buf and is sent on channel s.out`
+
+	s.headers = append(s.headers, bytes.TrimSpace(buf[:n])) // want `bytes.TrimSpace\(buf\[:n\]\) is a sub-slice of buf and is stored in field s.headers`
+
+	msgs := []*Message{}
+	msgs = append(msgs, &Message{Type: buf[:5]})
+	_ = msgs
+}
+
+var last *Message
+
+func parse(buf []byte) {
+	last = &Message{Type: buf[:5], Body: buf[5:]} // want `buf\[:5\] is a sub-slice of buf and is stored in global last` `buf\[5:\] is a sub-slice of buf and is stored in global last`
+}
+
+// ✅ 복사해서 저장한다 (19.go 의 consumeMessageGood)
+func (s *Server) handleGood(buf []byte, n int) {
+	dst := make([]byte, n)
+	copy(dst, buf)
+	s.last = dst
+
+	s.byType["x"] = bytes.Clone(buf[:5])
+	s.headers = append(s.headers, append([]byte(nil), buf[:n]...))
+}
+
+// ✅ queue 에서 꺼낸다
+func (s *Server) pop() []byte {
+	head := s.queue[0]
+	s.queue = s.queue[1:]
+	return head
+}
+
+// ✅ 지역 변수에만 둔다
+func local(buf []byte) int {
+	typ := buf[:5]
+	return len(typ)
+}
+
+// ✅ 작은 literal 은 붙잡을 것이 없다
+func literal() {
+	last = &Message{Type: []byte{1, 2, 3}[:2]}
+}
+
+var buffer = make([]byte, 1<<20)
+
+// ✅ 전역 buffer 는 이미 오래 산다
+func fromGlobal(s *Server) {
+	s.last = buffer[:5]
+}
+
+type pool struct{ free chan []byte }
+
+// ✅ backing array 전체를 pool 에 돌려준다 (consumer/pool.go 의 Put)
+func (p *pool) put(b []byte) {
+	p.free <- b[:0]
+}
+
+func (p *pool) putFull(b []byte) {
+	p.free <- b[0:len(b):cap(b)]
+	p.free <- b[:cap(b)]
+}
+
+// ❌ 다른 buffer 의 길이로 자르면 일부만 남는다
+func (p *pool) putPrefix(b, hdr []byte) {
+	p.free <- b[:len(hdr)] // want `b\[:len\(hdr\)\] is a sub-slice of b and is sent on channel p.free`
+}
@@ -0,0 +1,8 @@
+package b
+
+import "a"
+
+// 다른 패키지의 storesParam fact 를 쓴다
+func forward(buf []byte) {
+	a.StorageMessage(buf[:5]) // want `buf\[:5\] is a sub-slice of buf and is passed to StorageMessage, which stores it`
+}
@@ -0,0 +1,12 @@
+// typeswitch 는 typeswitch analyzer 를 단독으로 실행한다
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/zkfmapf123/100/analyzers/typeswitch"
+)
+
+func main() {
+	singlechecker.Main(typeswitch.Analyzer)
+}
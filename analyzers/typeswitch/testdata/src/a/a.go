@@ -0,0 +1,152 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ❌ 5.go 의 GetKeysOtherTypes
+func GetKeysOtherTypes(m any) ([]any, error) { // want `GetKeysOtherTypes type-switches m over 2 types whose cases all do the same operation; use a generic signature func GetKeysOtherTypes\[K comparable, V any\]\(m map\[K\]V\) \[\]K`
+	switch t := m.(type) {
+
+	default:
+		return nil, fmt.Errorf("unknown type : %T", t)
+
+	case map[string]int:
+		var keys []any
+		for k := range t {
+			keys = append(keys, k)
+		}
+		return keys, nil
+
+	case map[int]string: // want `case map\[int\]string falls through to return nil, nil without a result or an error; implement it or return an error`
+		// ...
+	}
+
+	return nil, nil
+}
+
+// ❌ 모든 case 가 같은 연산
+func Double(v any) any { // want `Double type-switches v over 3 types whose cases all do the same operation; use a generic signature func Double\[T ~int \| ~int64 \| ~float64\]\(v T\) T`
+	switch n := v.(type) {
+	case int:
+		return n * 2
+	case int64:
+		return n * 2
+	case float64:
+		return n * 2
+	}
+
+	return v
+}
+
+// ❌ slice 원소 타입만 다르다
+func Count(v any) (int, error) { // want `Count type-switches v over 2 types whose cases all do the same operation; use a generic signature func Count\[E any\]\(v \[\]E\) int`
+	switch s := v.(type) {
+	case []string:
+		return len(s), nil
+	case []bool:
+		return len(s), nil
+	}
+
+	return 0, errors.New("unsupported")
+}
+
+// ✅ case 마다 하는 일이 다르다
+func Describe(v any) string {
+	switch t := v.(type) {
+	case int:
+		return fmt.Sprint(t + 1)
+	case string:
+		return t + "!"
+	}
+
+	return ""
+}
+
+// ✅ 파라미터가 아닌 값을 switch 한다
+func Local() int {
+	var v any = 1
+	switch n := v.(type) {
+	case int:
+		return n
+	case int8:
+		return int(n)
+	}
+
+	return 0
+}
+
+// 제네릭을 제안하지는 않지만 nil, nil 로 빠지는 case 는 보고한다
+func Load(name string, v any) (*int, error) {
+	fmt.Println(name)
+
+	switch v.(type) {
+	case int:
+		n := 1
+		return &n, nil
+	case string: // want `case string falls through to return nil, nil without a result or an error; implement it or return an error`
+		fmt.Println("todo")
+	default:
+		panic("unreachable")
+	}
+
+	return nil, nil
+}
+
+type point struct{ X int }
+
+type pixel struct{ X int }
+
+// ✅ T any 로는 p.X 를 쓸 수 없다
+func GetX(v any) int {
+	switch p := v.(type) {
+	case *point:
+		return p.X
+	case *pixel:
+		return p.X
+	}
+
+	return 0
+}
+
+// ❌ 다른 패키지의 타입은 패키지 이름으로 쓴다
+func Write(w http.ResponseWriter, v any) error { // want `Write type-switches v over 2 types whose cases all do the same operation; use a generic signature func Write\[E any\]\(w http.ResponseWriter, v \[\]E\) error`
+	switch t := v.(type) {
+	case []string:
+		_, err := fmt.Fprint(w, len(t))
+		return err
+	case []int:
+		_, err := fmt.Fprint(w, len(t))
+		return err
+	}
+
+	return nil
+}
+
+// ❌ map[string]string 에서 string 은 K 이기도 V 이기도 하지만 map[int]string 에서 key 는 K 뿐이다
+func Keys(m any) []any { // want `Keys type-switches m over 3 types whose cases all do the same operation; use a generic signature func Keys\[K comparable, V any\]\(m map\[K\]V\) \[\]K`
+	switch t := m.(type) {
+	case map[string]string:
+		var keys []any
+		for k := range t {
+			keys = append(keys, k)
+		}
+		return keys
+	case map[int]int:
+		var keys []any
+		for k := range t {
+			keys = append(keys, k)
+		}
+		return keys
+	case map[int]string:
+		var keys []any
+		for k := range t {
+			keys = append(keys, k)
+		}
+		return keys
+	}
+
+	return nil
+}
@@ -0,0 +1,687 @@
+package typeswitch
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+/*
+5.go 에 있던 GetKeysOtherTypes 처럼 any 를 type switch 해서 타입마다 같은 일을 하는 함수를 찾는다.
+
+	func GetKeysOtherTypes(m any) ([]any, error) {
+		switch t := m.(type) {
+		case map[string]int:
+			var keys []any
+			for k := range t { keys = append(keys, k) }
+			return keys, nil
+		case map[int]string:
+			// ...                  ❌ 아무것도 하지 않고 아래의 return nil, nil 로 빠진다
+		}
+		return nil, nil
+	}
+
+- 함수 본문이 파라미터에 대한 type switch (와 마지막 return) 뿐이고
+- 구현된 case 들이 case 타입만 빼면 구조가 같으면
+
+제네릭 시그니처를 제안한다: func GetKeysOtherTypes[K comparable, V any](m map[K]V) []K
+비어있는 case 는 아직 구현하지 않은 같은 연산으로 본다.
+
+switch 뒤의 return nil, nil 로 조용히 빠지는 case 는 따로 보고한다.
+*/
+var Analyzer = &analysis.Analyzer{
+	Name:     "typeswitch",
+	Doc:      "reports type-switch helpers that do the same operation for every case and cases that silently fall through to return nil, nil",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		fn := n.(*ast.FuncDecl)
+		if fn.Body == nil {
+			return
+		}
+
+		for i, stmt := range fn.Body.List {
+			sw, ok := stmt.(*ast.TypeSwitchStmt)
+			if !ok {
+				continue
+			}
+
+			var next ast.Stmt
+			if i+1 < len(fn.Body.List) {
+				next = fn.Body.List[i+1]
+			}
+			checkFallThrough(pass, sw, next)
+		}
+
+		checkGeneric(pass, fn)
+	})
+
+	return nil, nil
+}
+
+// checkFallThrough 는 switch 바로 뒤가 return nil, nil 일 때 그곳으로 빠지는 case 를 보고한다
+func checkFallThrough(pass *analysis.Pass, sw *ast.TypeSwitchStmt, next ast.Stmt) {
+	ret, ok := next.(*ast.ReturnStmt)
+	if !ok || len(ret.Results) < 2 || !allNil(pass, ret.Results) {
+		return
+	}
+
+	for _, c := range sw.Body.List {
+		clause := c.(*ast.CaseClause)
+		if terminates(clause.Body) {
+			continue
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     clause.Case,
+			End:     clause.Colon,
+			Message: fmt.Sprintf("%s falls through to return %s without a result or an error; implement it or return an error", caseName(clause), joinExprs(ret.Results)),
+		})
+	}
+}
+
+func allNil(pass *analysis.Pass, exprs []ast.Expr) bool {
+	for _, e := range exprs {
+		if !pass.TypesInfo.Types[e].IsNil() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// terminates 는 case 본문이 return, panic 같은 종료문으로 끝나는지 본다 (단순화된 spec 의 terminating statement)
+func terminates(body []ast.Stmt) bool {
+	if len(body) == 0 {
+		return false
+	}
+
+	switch s := body[len(body)-1].(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return s.Tok == token.GOTO
+	case *ast.ExprStmt:
+		call, ok := s.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		id, ok := call.Fun.(*ast.Ident)
+		return ok && id.Name == "panic"
+	case *ast.BlockStmt:
+		return terminates(s.List)
+	case *ast.IfStmt:
+		if s.Else == nil || !terminates(s.Body.List) {
+			return false
+		}
+		return terminates([]ast.Stmt{s.Else})
+	case *ast.ForStmt:
+		return s.Cond == nil && !hasBreak(s.Body)
+	}
+
+	return false
+}
+
+func hasBreak(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			return false
+		case *ast.BranchStmt:
+			if n.Tok == token.BREAK && n.Label == nil {
+				found = true
+			}
+		}
+		return !found
+	})
+
+	return found
+}
+
+func caseName(clause *ast.CaseClause) string {
+	if clause.List == nil {
+		return "default"
+	}
+
+	return "case " + joinExprs(clause.List)
+}
+
+func joinExprs(exprs []ast.Expr) string {
+	parts := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		parts = append(parts, types.ExprString(e))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// typeCase 는 타입 하나만 가진 case 다
+type typeCase struct {
+	clause *ast.CaseClause
+	typ    types.Type
+}
+
+// checkGeneric 은 함수 본문이 파라미터에 대한 type switch 하나이고 case 들이 같은 연산이면 제네릭 시그니처를 제안한다
+func checkGeneric(pass *analysis.Pass, fn *ast.FuncDecl) {
+	body := fn.Body.List
+	if len(body) == 2 {
+		if _, ok := body[1].(*ast.ReturnStmt); !ok {
+			return
+		}
+	} else if len(body) != 1 {
+		return
+	}
+
+	sw, ok := body[0].(*ast.TypeSwitchStmt)
+	if !ok || sw.Init != nil {
+		return
+	}
+
+	param, ok := switchedParam(pass, fn, sw)
+	if !ok {
+		return
+	}
+
+	var cases []typeCase
+	for _, c := range sw.Body.List {
+		clause := c.(*ast.CaseClause)
+		if clause.List == nil {
+			continue // default
+		}
+
+		// case A, B: 에서는 t 가 interface 로 남으므로 같은 연산인지 판단하지 않는다
+		if len(clause.List) != 1 {
+			return
+		}
+
+		tv := pass.TypesInfo.Types[clause.List[0]]
+		if tv.IsNil() {
+			continue
+		}
+		cases = append(cases, typeCase{clause: clause, typ: tv.Type})
+	}
+
+	if len(cases) < 2 {
+		return
+	}
+
+	var shape string
+	implemented := 0
+	for _, c := range cases {
+		if len(c.clause.Body) == 0 {
+			continue
+		}
+
+		s := normalize(pass, c.clause.Body, c.typ)
+		if implemented > 0 && s != shape {
+			return
+		}
+		shape = s
+		implemented++
+	}
+
+	if implemented == 0 {
+		return
+	}
+
+	caseTypes := make([]types.Type, 0, len(cases))
+	for _, c := range cases {
+		caseTypes = append(caseTypes, c.typ)
+	}
+
+	u := &unifier{qf: qualifier(pass.Pkg), names: map[string]int{}, bindings: make([]map[string][]string, len(cases))}
+	for i := range u.bindings {
+		u.bindings[i] = map[string][]string{}
+	}
+	paramType := u.unify(caseTypes, "T", false)
+
+	// T any 로는 필드나 메서드를 쓸 수 없다
+	for i, c := range cases {
+		if selectsParam(pass, u, i, c.clause.Body) {
+			return
+		}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos: fn.Name.Pos(),
+		End: fn.Name.End(),
+		Message: fmt.Sprintf("%s type-switches %s over %d types whose cases all do the same operation; use a generic signature %s",
+			fn.Name.Name, param.Name(), len(cases), signature(pass, fn, param, paramType, u, cases)),
+	})
+}
+
+// qualifier 는 다른 패키지의 타입을 go/ast.BlockStmt 대신 ast.BlockStmt 처럼 패키지 이름으로 쓴다
+func qualifier(pkg *types.Package) types.Qualifier {
+	return func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		return p.Name()
+	}
+}
+
+// selectsParam 은 i 번째 case 본문이 타입 파라미터가 될 타입의 값에서 필드나 메서드를 고르는지 본다
+//
+//	case *ast.FuncDecl: return n.Body   // n 은 *T 가 되고 T any 에는 Body 가 없다
+func selectsParam(pass *analysis.Pass, u *unifier, i int, body []ast.Stmt) bool {
+	found := false
+	for _, stmt := range body {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok || found {
+				return !found
+			}
+
+			if _, ok := pass.TypesInfo.Selections[sel]; !ok {
+				return true
+			}
+
+			t := pass.TypesInfo.TypeOf(sel.X)
+			if p, ok := t.(*types.Pointer); ok {
+				t = p.Elem()
+			}
+			if _, ok := u.bindings[i][types.TypeString(t, u.qf)]; ok {
+				found = true
+			}
+			return !found
+		})
+	}
+
+	return found
+}
+
+// switchedParam 은 switch t := p.(type) 의 p 가 interface 타입 파라미터인지 본다
+func switchedParam(pass *analysis.Pass, fn *ast.FuncDecl, sw *ast.TypeSwitchStmt) (*types.Var, bool) {
+	var x ast.Expr
+	switch s := sw.Assign.(type) {
+	case *ast.AssignStmt:
+		x = s.Rhs[0].(*ast.TypeAssertExpr).X
+	case *ast.ExprStmt:
+		x = s.X.(*ast.TypeAssertExpr).X
+	}
+
+	id, ok := ast.Unparen(x).(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok || !types.IsInterface(v.Type()) {
+		return nil, false
+	}
+
+	sig := pass.TypesInfo.Defs[fn.Name].(*types.Func).Type().(*types.Signature)
+	for i := 0; i < sig.Params().Len(); i++ {
+		if sig.Params().At(i) == v {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+/*
+normalize 는 case 본문을 비교용 문자열로 바꾼다. case 타입 자체를 가리키는 타입 표현식은 T 로 바꾼다.
+
+	case map[string]int: keys := make([]map[string]int, 0)   ->  keys := make([]T, 0)
+*/
+func normalize(pass *analysis.Pass, body []ast.Stmt, caseType types.Type) string {
+	var b strings.Builder
+	for _, stmt := range body {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if n == nil {
+				return false
+			}
+
+			if e, ok := n.(ast.Expr); ok {
+				if tv, ok := pass.TypesInfo.Types[e]; ok && tv.IsType() && types.Identical(tv.Type, caseType) {
+					b.WriteString("T;")
+					return false
+				}
+			}
+
+			fmt.Fprintf(&b, "%T", n)
+			switch n := n.(type) {
+			case *ast.Ident:
+				b.WriteString(":" + n.Name)
+			case *ast.BasicLit:
+				b.WriteString(":" + n.Value)
+			case *ast.BinaryExpr:
+				b.WriteString(":" + n.Op.String())
+			case *ast.UnaryExpr:
+				b.WriteString(":" + n.Op.String())
+			case *ast.AssignStmt:
+				b.WriteString(":" + n.Tok.String())
+			case *ast.IncDecStmt:
+				b.WriteString(":" + n.Tok.String())
+			case *ast.BranchStmt:
+				b.WriteString(":" + n.Tok.String())
+			case *ast.RangeStmt:
+				b.WriteString(":" + n.Tok.String())
+			}
+			b.WriteString(";")
+			return true
+		})
+	}
+
+	return b.String()
+}
+
+/*
+unifier 는 case 타입들을 하나의 제네릭 타입으로 합친다
+
+	map[string]int, map[int]string  ->  map[K]V   (K comparable, V any)
+	int, string                      ->  T         (T ~int | ~string)
+
+bindings[i] 는 i 번째 case 에서 각 타입 파라미터가 어떤 타입이었는지 기록한다 (결과 타입을 추론할 때 쓴다).
+map[string]string 처럼 K 와 V 가 같은 타입일 수 있으므로 구체 타입 하나에 타입 파라미터가 여럿일 수 있다.
+*/
+type unifier struct {
+	qf       types.Qualifier
+	names    map[string]int
+	tparams  []string
+	bindings []map[string][]string // case 별: 구체 타입 -> 타입 파라미터들
+}
+
+func (u *unifier) unify(ts []types.Type, hint string, key bool) string {
+	first := ts[0]
+	same := true
+	for _, t := range ts[1:] {
+		if !types.Identical(first, t) {
+			same = false
+			break
+		}
+	}
+	if same {
+		return types.TypeString(first, u.qf)
+	}
+
+	switch first.Underlying().(type) {
+	case *types.Map:
+		if keys, elems, ok := mapParts(ts); ok && allUnnamed(ts) {
+			return "map[" + u.unify(keys, "K", true) + "]" + u.unify(elems, "V", false)
+		}
+	case *types.Slice:
+		if elems, ok := sliceElems(ts); ok && allUnnamed(ts) {
+			return "[]" + u.unify(elems, "E", false)
+		}
+	case *types.Pointer:
+		if elems, ok := pointerElems(ts); ok {
+			return "*" + u.unify(elems, hint, key)
+		}
+	}
+
+	name := u.newParam(hint)
+	u.tparams = append(u.tparams, name+" "+u.constraint(ts, key, hint == "T"))
+	for i, t := range ts {
+		k := types.TypeString(t, u.qf)
+		u.bindings[i][k] = append(u.bindings[i][k], name)
+	}
+
+	return name
+}
+
+func (u *unifier) newParam(hint string) string {
+	u.names[hint]++
+	if n := u.names[hint]; n > 1 {
+		return fmt.Sprintf("%s%d", hint, n)
+	}
+
+	return hint
+}
+
+// constraint 는 map key 면 comparable, 원소 타입이면 any 를 쓴다.
+// switch 한 값 자체가 기본 타입이면 case 의 연산(n * 2 등)이 필요하므로 ~int | ~string 같은 union 을 쓴다.
+func (u *unifier) constraint(ts []types.Type, key, top bool) string {
+	if key {
+		return "comparable"
+	}
+
+	if !top {
+		return "any"
+	}
+
+	terms := make([]string, 0, len(ts))
+	seen := map[string]bool{}
+	for _, t := range ts {
+		b, ok := t.Underlying().(*types.Basic)
+		if !ok {
+			return "any"
+		}
+
+		if term := "~" + b.Name(); !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	return strings.Join(terms, " | ")
+}
+
+func allUnnamed(ts []types.Type) bool {
+	for _, t := range ts {
+		if _, ok := types.Unalias(t).(*types.Named); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mapParts(ts []types.Type) (keys, elems []types.Type, ok bool) {
+	for _, t := range ts {
+		m, ok := t.Underlying().(*types.Map)
+		if !ok {
+			return nil, nil, false
+		}
+		keys = append(keys, m.Key())
+		elems = append(elems, m.Elem())
+	}
+
+	return keys, elems, true
+}
+
+func sliceElems(ts []types.Type) ([]types.Type, bool) {
+	var elems []types.Type
+	for _, t := range ts {
+		s, ok := t.Underlying().(*types.Slice)
+		if !ok {
+			return nil, false
+		}
+		elems = append(elems, s.Elem())
+	}
+
+	return elems, true
+}
+
+func pointerElems(ts []types.Type) ([]types.Type, bool) {
+	var elems []types.Type
+	for _, t := range ts {
+		p, ok := t.(*types.Pointer)
+		if !ok {
+			return nil, false
+		}
+		elems = append(elems, p.Elem())
+	}
+
+	return elems, true
+}
+
+/*
+signature 는 제안할 시그니처를 만든다
+
+  - switch 한 파라미터는 합친 타입으로 바꾼다
+  - any, []any 결과는 구현된 case 에서 넣는 값의 타입이 같은 타입 파라미터로 모이면 그것으로 바꾼다
+  - 마지막 error 결과가 구현된 case 에서 항상 nil 이면 뺀다 (지원하지 않는 타입은 컴파일 에러가 된다)
+*/
+func signature(pass *analysis.Pass, fn *ast.FuncDecl, param *types.Var, paramType string, u *unifier, cases []typeCase) string {
+	sig := pass.TypesInfo.Defs[fn.Name].(*types.Func).Type().(*types.Signature)
+
+	params := make([]string, 0, sig.Params().Len())
+	for i := 0; i < sig.Params().Len(); i++ {
+		p := sig.Params().At(i)
+		t := types.TypeString(p.Type(), u.qf)
+		if p == param {
+			t = paramType
+		}
+		params = append(params, strings.TrimSpace(p.Name()+" "+t))
+	}
+
+	var results []string
+	for i := 0; i < sig.Results().Len(); i++ {
+		t := sig.Results().At(i).Type()
+
+		if i == sig.Results().Len()-1 && isError(t) && alwaysNil(pass, cases, i) {
+			continue
+		}
+
+		s := types.TypeString(t, u.qf)
+		switch {
+		case isAny(t):
+			if p := resultParam(pass, u, cases, i, false); p != "" {
+				s = p
+			}
+		case isAnySlice(t):
+			if p := resultParam(pass, u, cases, i, true); p != "" {
+				s = "[]" + p
+			}
+		}
+		results = append(results, s)
+	}
+
+	out := fmt.Sprintf("func %s[%s](%s)", fn.Name.Name, strings.Join(u.tparams, ", "), strings.Join(params, ", "))
+	switch len(results) {
+	case 0:
+	case 1:
+		out += " " + results[0]
+	default:
+		out += " (" + strings.Join(results, ", ") + ")"
+	}
+
+	return out
+}
+
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+func isAny(t types.Type) bool {
+	iface, ok := types.Unalias(t).(*types.Interface)
+	return ok && iface.Empty()
+}
+
+func isAnySlice(t types.Type) bool {
+	s, ok := types.Unalias(t).(*types.Slice)
+	return ok && isAny(s.Elem())
+}
+
+func returns(body []ast.Stmt, f func(*ast.ReturnStmt)) {
+	for _, stmt := range body {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				f(n)
+			}
+			return true
+		})
+	}
+}
+
+func alwaysNil(pass *analysis.Pass, cases []typeCase, index int) bool {
+	ok := true
+	for _, c := range cases {
+		returns(c.clause.Body, func(ret *ast.ReturnStmt) {
+			if index >= len(ret.Results) || !pass.TypesInfo.Types[ret.Results[index]].IsNil() {
+				ok = false
+			}
+		})
+	}
+
+	return ok
+}
+
+/*
+resultParam 은 any (또는 []any) 결과에 들어가는 값의 타입을 case 별 bindings 로 타입 파라미터로 바꾼다
+
+	case map[string]int: keys = append(keys, k)   // k 는 string, 이 case 에서 string 은 K
+*/
+func resultParam(pass *analysis.Pass, u *unifier, cases []typeCase, index int, slice bool) string {
+	// 넣는 값마다 가능한 타입 파라미터들의 교집합 (K 와 V 가 같은 타입인 case 가 있어도 다른 case 가 가려준다)
+	var found map[string]bool
+	unknown := false
+
+	add := func(i int, e ast.Expr) {
+		t := pass.TypesInfo.TypeOf(e)
+		if t == nil || types.IsInterface(t) {
+			unknown = true
+			return
+		}
+
+		params, ok := u.bindings[i][types.TypeString(t, u.qf)]
+		if !ok {
+			unknown = true
+			return
+		}
+
+		next := map[string]bool{}
+		for _, p := range params {
+			if found == nil || found[p] {
+				next[p] = true
+			}
+		}
+		found = next
+	}
+
+	for i, c := range cases {
+		if slice {
+			for _, stmt := range c.clause.Body {
+				ast.Inspect(stmt, func(n ast.Node) bool {
+					call, ok := n.(*ast.CallExpr)
+					if !ok || len(call.Args) < 2 || call.Ellipsis.IsValid() {
+						return true
+					}
+
+					if id, ok := call.Fun.(*ast.Ident); !ok || id.Name != "append" || !isAnySlice(pass.TypesInfo.TypeOf(call.Args[0])) {
+						return true
+					}
+
+					for _, arg := range call.Args[1:] {
+						add(i, arg)
+					}
+					return true
+				})
+			}
+			continue
+		}
+
+		returns(c.clause.Body, func(ret *ast.ReturnStmt) {
+			if index < len(ret.Results) && !pass.TypesInfo.Types[ret.Results[index]].IsNil() {
+				add(i, ret.Results[index])
+			}
+		})
+	}
+
+	if unknown || len(found) != 1 {
+		return ""
+	}
+
+	for p := range found {
+		return p
+	}
+
+	return ""
+}
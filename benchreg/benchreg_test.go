@@ -0,0 +1,198 @@
+package benchreg
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const output = `goos: linux
+goarch: amd64
+pkg: github.com/zkfmapf123/100
+BenchmarkConvert1-8   	     100	  12000000 ns/op	 8003584 B/op	      38 allocs/op
+BenchmarkConvert1-8   	     100	  12100000 ns/op	 8003584 B/op	      38 allocs/op
+BenchmarkConvert2-8   	     200	   6000000 ns/op	       0 B/op	       0 allocs/op
+BenchmarkConvert3
+BenchmarkConvert3-8   	     300	   4000000.5 ns/op
+PASS
+ok  	github.com/zkfmapf123/100	3.210s
+`
+
+func TestParse(t *testing.T) {
+	got, err := Parse(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got["Convert1"]) != 2 || len(got["Convert2"]) != 1 || len(got["Convert3"]) != 1 {
+		t.Fatalf("Parse = %v", got)
+	}
+
+	if s := got["Convert1"][1]; s.NsPerOp != 12100000 || s.BytesPerOp != 8003584 || s.AllocsPerOp != 38 {
+		t.Errorf("Convert1[1] = %+v", s)
+	}
+
+	if s := got["Convert3"][0]; s.NsPerOp != 4000000.5 || s.BytesPerOp != 0 {
+		t.Errorf("Convert3[0] = %+v", s)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench", "history.json")
+
+	h, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Runs) != 0 || h.Version != SchemaVersion {
+		t.Fatalf("Load(missing) = %+v", h)
+	}
+
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	h.Add(Run{Label: "v1", Time: now, Benchmarks: map[string][]Sample{"Convert1": {{NsPerOp: 1}}}})
+	h.Add(Run{Label: "v2", Time: now, Benchmarks: map[string][]Sample{"Convert1": {{NsPerOp: 2}}}})
+	h.Add(Run{Label: "v1", Time: now, Benchmarks: map[string][]Sample{"Convert1": {{NsPerOp: 3}}}})
+
+	if err := h.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r, ok := h.Find("v1"); !ok || r.Benchmarks["Convert1"][0].NsPerOp != 3 {
+		t.Errorf("Find(v1) = %+v, %v; want the latest v1", r, ok)
+	}
+
+	if r, ok := h.Last(1); !ok || r.Label != "v2" {
+		t.Errorf("Last(1) = %+v, %v", r, ok)
+	}
+
+	if _, ok := h.Last(3); ok {
+		t.Error("Last(3) found a run in a history of 3")
+	}
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "runs": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Load = %v, want %v", err, ErrUnsupportedVersion)
+	}
+}
+
+func TestStudentQuantile(t *testing.T) {
+	// t 분포표
+	tests := []struct {
+		p, df, want float64
+	}{
+		{0.975, 1, 12.706},
+		{0.975, 10, 2.228},
+		{0.995, 5, 4.032},
+		{0.95, 30, 1.697},
+		{0.975, 1e6, 1.960},
+	}
+
+	for _, tt := range tests {
+		if got := studentQuantile(tt.p, tt.df); math.Abs(got-tt.want) > 1e-3 {
+			t.Errorf("studentQuantile(%g, %g) = %.4f, want %.3f", tt.p, tt.df, got, tt.want)
+		}
+	}
+}
+
+func samples(ns ...float64) []Sample {
+	out := make([]Sample, 0, len(ns))
+	for _, v := range ns {
+		out = append(out, Sample{NsPerOp: v, BytesPerOp: 800, AllocsPerOp: 1})
+	}
+
+	return out
+}
+
+func TestCompare(t *testing.T) {
+	base := Run{Label: "base", Benchmarks: map[string][]Sample{
+		"Convert1": samples(100, 101, 99, 100, 100),
+		"Convert2": samples(50, 52, 48, 50, 50),
+		"Removed":  samples(1, 1),
+	}}
+	head := Run{Label: "head", Benchmarks: map[string][]Sample{
+		"Convert1": samples(120, 121, 119, 120, 120), // 20% 느려짐
+		"Convert2": samples(49, 53, 47, 51, 50),      // 노이즈
+		"Added":    samples(1, 1),
+	}}
+	head.Benchmarks["Convert2"][0].AllocsPerOp = 2
+
+	deltas := Compare(base, head, 0.95)
+	if len(deltas) != 6 {
+		t.Fatalf("len(deltas) = %d, want 6 (2 common benchmarks x 3 metrics)", len(deltas))
+	}
+
+	byKey := make(map[string]Delta, len(deltas))
+	for _, d := range deltas {
+		byKey[d.Name+" "+d.Metric.String()] = d
+	}
+
+	if d := byKey["Convert1 ns/op"]; !d.Regression(5) || math.Abs(d.Pct-20) > 1e-9 || d.Lo > 20 || d.Hi < 20 {
+		t.Errorf("Convert1 ns/op = %+v, want a regression around +20%%", d)
+	}
+
+	if d := byKey["Convert1 ns/op"]; d.Regression(25) {
+		t.Errorf("Convert1 ns/op is a regression beyond 25%%: %+v", d)
+	}
+
+	if d := byKey["Convert2 ns/op"]; d.Significant || d.Regression(5) {
+		t.Errorf("Convert2 ns/op = %+v, want noise", d)
+	}
+
+	// 분산이 0 인 지표는 구간이 한 점이다
+	if d := byKey["Convert1 B/op"]; !d.HasCI || d.Significant || d.Pct != 0 {
+		t.Errorf("Convert1 B/op = %+v", d)
+	}
+
+	// 한번만 튄 값은 평균이 20% 올라도 유의하지 않다
+	if d := byKey["Convert2 allocs/op"]; !d.HasCI || d.Significant || d.Lo >= 0 || d.Hi <= 0 {
+		t.Errorf("Convert2 allocs/op = %+v, want an interval around 0", d)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	base := Run{Label: "base", Benchmarks: map[string][]Sample{"Convert1": samples(1000, 1010, 990)}}
+	head := Run{Label: "head", Benchmarks: map[string][]Sample{"Convert1": samples(2000, 2010, 1990)}}
+
+	var buf bytes.Buffer
+	if err := Markdown(&buf, Compare(base, head, 0.95), 0.95, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Markdown has %d lines, want header, separator and 3 metrics:\n%s", len(lines), buf.String())
+	}
+
+	if want := "| Benchmark | Metric | Base | Head | Delta | 95% CI |  |"; lines[0] != want {
+		t.Errorf("header = %q, want %q", lines[0], want)
+	}
+
+	if !strings.HasPrefix(lines[2], "| Convert1 | ns/op | 1k ± 1% | 2k ± 0% | +100.00% | [+") || !strings.HasSuffix(lines[2], "| ❌ |") {
+		t.Errorf("ns/op row = %q", lines[2])
+	}
+
+	buf.Reset()
+	if err := Table(&buf, head); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "| Convert1 | 2k ± 0% | 800 | 1 | 3 |") {
+		t.Errorf("Table =\n%s", buf.String())
+	}
+}
@@ -0,0 +1,220 @@
+/*
+benchreg 는 벤치마크를 여러번 실행해 history 에 쌓고 이전 실행과 비교한다
+
+	# 15_test.go 의 convert 벤치마크를 10번 실행해서 기록
+	go run ./benchreg/cmd/benchreg run -bench 'Convert' -count 10 -label $(git rev-parse --short HEAD)
+
+	# 바로 이전 실행(또는 -base 라벨)과 비교, 5% 넘게 느려지면 exit 1
+	go run ./benchreg/cmd/benchreg compare -threshold 5 -md delta.md
+
+	# 마지막 실행 결과 표
+	go run ./benchreg/cmd/benchreg table
+
+exit code: 0 정상, 1 회귀 발견, 2 사용법이나 실행 오류
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+
+	"github.com/zkfmapf123/100/benchreg"
+)
+
+const defaultHistory = "benchreg/history.json"
+
+var errRegression = errors.New("benchmark regression")
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCmd(ctx, os.Args[2:])
+	case "compare":
+		err = compareCmd(os.Args[2:])
+	case "table":
+		err = tableCmd(os.Args[2:])
+	default:
+		usage()
+	}
+
+	switch {
+	case errors.Is(err, errRegression):
+		fmt.Fprintln(os.Stderr, "benchreg:", err)
+		os.Exit(1)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "benchreg:", err)
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: benchreg run|compare|table [flags]")
+	os.Exit(2)
+}
+
+func runCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	history := fs.String("history", defaultHistory, "history JSON file")
+	pkg := fs.String("pkg", ".", "package to benchmark")
+	dir := fs.String("dir", ".", "directory to run go test in")
+	bench := fs.String("bench", ".", "benchmark regexp")
+	count := fs.Int("count", 10, "number of runs per benchmark")
+	label := fs.String("label", "", "label of this run (commit or version)")
+	fs.Parse(args)
+
+	if *label == "" {
+		return errors.New("run: -label is required")
+	}
+	if *count < 2 {
+		return errors.New("run: -count must be at least 2 to compute confidence intervals")
+	}
+
+	h, err := benchreg.Load(*history)
+	if err != nil {
+		return err
+	}
+
+	samples, err := benchreg.RunBenchmarks(ctx, *dir, *pkg, *bench, *count, io.Discard)
+	if err != nil {
+		return err
+	}
+
+	h.Add(benchreg.Run{
+		Label:      *label,
+		Time:       time.Now().UTC(),
+		GoVersion:  runtime.Version(),
+		Package:    *pkg,
+		Benchmarks: samples,
+	})
+
+	if err := h.Save(*history); err != nil {
+		return err
+	}
+
+	fmt.Printf("benchreg: recorded %d benchmarks as %q in %s\n", len(samples), *label, *history)
+	return nil
+}
+
+func compareCmd(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	history := fs.String("history", defaultHistory, "history JSON file")
+	baseLabel := fs.String("base", "", "baseline label (default: the run before head)")
+	headLabel := fs.String("head", "", "head label (default: the latest run)")
+	threshold := fs.Float64("threshold", 5, "fail when a metric is worse by more than this percent")
+	confidence := fs.Float64("confidence", 0.95, "confidence level of the intervals")
+	md := fs.String("md", "", "also write the Markdown table to this file")
+	fs.Parse(args)
+
+	if *confidence <= 0 || *confidence >= 1 {
+		return fmt.Errorf("compare: -confidence %g must be between 0 and 1", *confidence)
+	}
+
+	h, err := benchreg.Load(*history)
+	if err != nil {
+		return err
+	}
+
+	head, ok := pick(h, *headLabel, 0)
+	if !ok {
+		return fmt.Errorf("compare: head run %q not found", *headLabel)
+	}
+
+	base, ok := h.Find(*baseLabel)
+	if *baseLabel == "" {
+		base, ok = previous(h, head.Label)
+	}
+	if !ok {
+		return fmt.Errorf("compare: base run %q not found", *baseLabel)
+	}
+
+	deltas := benchreg.Compare(base, head, *confidence)
+	if len(deltas) == 0 {
+		return fmt.Errorf("compare: %s and %s have no benchmarks in common", base.Label, head.Label)
+	}
+
+	fmt.Printf("%s -> %s\n\n", base.Label, head.Label)
+	if err := benchreg.Markdown(os.Stdout, deltas, *confidence, *threshold); err != nil {
+		return err
+	}
+
+	if *md != "" {
+		f, err := os.Create(*md)
+		if err != nil {
+			return err
+		}
+		if err := benchreg.Markdown(f, deltas, *confidence, *threshold); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	var regressions int
+	for _, d := range deltas {
+		if d.Regression(*threshold) {
+			regressions++
+		}
+	}
+
+	if regressions > 0 {
+		return fmt.Errorf("%w: %d metrics worse than %g%%", errRegression, regressions, *threshold)
+	}
+
+	return nil
+}
+
+func tableCmd(args []string) error {
+	fs := flag.NewFlagSet("table", flag.ExitOnError)
+	history := fs.String("history", defaultHistory, "history JSON file")
+	label := fs.String("label", "", "label of the run (default: the latest run)")
+	fs.Parse(args)
+
+	h, err := benchreg.Load(*history)
+	if err != nil {
+		return err
+	}
+
+	r, ok := pick(h, *label, 0)
+	if !ok {
+		return fmt.Errorf("table: run %q not found", *label)
+	}
+
+	return benchreg.Table(os.Stdout, r)
+}
+
+// pick 은 label 이 있으면 그 실행을, 없으면 뒤에서 n 번째 실행을 고른다
+func pick(h *benchreg.History, label string, n int) (benchreg.Run, bool) {
+	if label != "" {
+		return h.Find(label)
+	}
+
+	return h.Last(n)
+}
+
+// previous 는 label 의 가장 최근 실행 바로 앞의 실행이다
+func previous(h *benchreg.History, label string) (benchreg.Run, bool) {
+	for i := len(h.Runs) - 1; i > 0; i-- {
+		if h.Runs[i].Label == label {
+			return h.Runs[i-1], true
+		}
+	}
+
+	return benchreg.Run{}, false
+}
@@ -0,0 +1,219 @@
+package benchreg
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+type Metric int
+
+const (
+	NsPerOp Metric = iota
+	BytesPerOp
+	AllocsPerOp
+)
+
+var metrics = []Metric{NsPerOp, BytesPerOp, AllocsPerOp}
+
+func (m Metric) String() string {
+	switch m {
+	case NsPerOp:
+		return "ns/op"
+	case BytesPerOp:
+		return "B/op"
+	case AllocsPerOp:
+		return "allocs/op"
+	}
+
+	return fmt.Sprintf("Metric(%d)", int(m))
+}
+
+func (m Metric) value(s Sample) float64 {
+	switch m {
+	case BytesPerOp:
+		return s.BytesPerOp
+	case AllocsPerOp:
+		return s.AllocsPerOp
+	}
+
+	return s.NsPerOp
+}
+
+/*
+Delta 는 벤치마크 하나의 지표 하나에 대한 base -> head 변화다.
+
+Pct, Lo, Hi 는 base 평균 대비 백분율이다 (+ 가 느려짐/늘어남).
+Significant 는 신뢰구간이 0 을 포함하지 않는다는 뜻이다.
+*/
+type Delta struct {
+	Name        string
+	Metric      Metric
+	Base, Head  Summary
+	Pct         float64
+	Lo, Hi      float64
+	HasCI       bool
+	Significant bool
+}
+
+// Regression 은 신뢰구간의 하한까지 threshold(%) 보다 나빠졌는지 본다.
+// 평균만 보면 노이즈로 실패하므로 구간 전체가 threshold 를 넘을 때만 회귀로 본다.
+func (d Delta) Regression(threshold float64) bool {
+	return d.Significant && d.Lo > threshold
+}
+
+// Compare 는 두 실행에 모두 있는 벤치마크를 이름순으로 비교한다
+func Compare(base, head Run, confidence float64) []Delta {
+	names := make([]string, 0, len(head.Benchmarks))
+	for name := range head.Benchmarks {
+		if _, ok := base.Benchmarks[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	deltas := make([]Delta, 0, len(names)*len(metrics))
+	for _, name := range names {
+		for _, m := range metrics {
+			deltas = append(deltas, compare(name, m, base.Benchmarks[name], head.Benchmarks[name], confidence))
+		}
+	}
+
+	return deltas
+}
+
+func compare(name string, m Metric, base, head []Sample, confidence float64) Delta {
+	d := Delta{
+		Name:   name,
+		Metric: m,
+		Base:   summarize(values(m, base)),
+		Head:   summarize(values(m, head)),
+	}
+
+	lo, hi, ok := welch(d.Base, d.Head, confidence)
+	d.HasCI = ok
+	d.Significant = ok && (lo > 0 || hi < 0)
+
+	diff := d.Head.Mean - d.Base.Mean
+	d.Pct, d.Lo, d.Hi = percent(diff, d.Base.Mean), percent(lo, d.Base.Mean), percent(hi, d.Base.Mean)
+
+	return d
+}
+
+// percent 는 base 가 0 일 때 (0 allocs -> 1 allocs) 변화가 있으면 ±Inf 다
+func percent(diff, base float64) float64 {
+	if base == 0 {
+		switch {
+		case diff > 0:
+			return math.Inf(1)
+		case diff < 0:
+			return math.Inf(-1)
+		}
+		return 0
+	}
+
+	return diff / base * 100
+}
+
+func values(m Metric, samples []Sample) []float64 {
+	out := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		out = append(out, m.value(s))
+	}
+
+	return out
+}
+
+/*
+Markdown 은 README 에 붙여넣을 수 있는 비교 표를 쓴다
+
+	| Benchmark | Metric | Base | Head | Delta | 95% CI |  |
+	|---|---|---:|---:|---:|---|---|
+	| Convert1 | ns/op | 1.234m ± 2% | 1.301m ± 1% | +5.43% | [+3.10%, +7.76%] | ❌ |
+
+❌ 는 threshold 를 넘은 회귀, ✅ 는 유의한 개선, ~ 는 유의하지 않은 변화다.
+*/
+func Markdown(w io.Writer, deltas []Delta, confidence, threshold float64) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "| Benchmark | Metric | Base | Head | Delta | %g%% CI |  |\n", confidence*100)
+	b.WriteString("|---|---|---:|---:|---:|---|---|\n")
+
+	for _, d := range deltas {
+		ci := "n/a"
+		if d.HasCI {
+			ci = fmt.Sprintf("[%s, %s]", signed(d.Lo), signed(d.Hi))
+		}
+
+		mark := "~"
+		switch {
+		case d.Regression(threshold):
+			mark = "❌"
+		case d.Significant && d.Hi < 0:
+			mark = "✅"
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s |\n", d.Name, d.Metric, human(d.Base), human(d.Head), signed(d.Pct), ci, mark)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Table 은 실행 하나의 결과 표를 쓴다 (README 의 convert 결과 옆에 두는 용도)
+func Table(w io.Writer, r Run) error {
+	names := make([]string, 0, len(r.Benchmarks))
+	for name := range r.Benchmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "| Benchmark (%s) | ns/op | B/op | allocs/op | n |\n", r.Label)
+	b.WriteString("|---|---:|---:|---:|---:|\n")
+
+	for _, name := range names {
+		samples := r.Benchmarks[name]
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %d |\n", name,
+			human(summarize(values(NsPerOp, samples))),
+			human(summarize(values(BytesPerOp, samples))),
+			human(summarize(values(AllocsPerOp, samples))),
+			len(samples))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func signed(pct float64) string {
+	if math.IsInf(pct, 0) {
+		if pct > 0 {
+			return "+inf"
+		}
+		return "-inf"
+	}
+
+	return fmt.Sprintf("%+.2f%%", pct)
+}
+
+// human 은 평균 ± 변동계수 를 k, M, G 단위로 줄여 쓴다
+func human(s Summary) string {
+	v := s.Mean
+	unit := ""
+	for _, u := range []string{"k", "M", "G"} {
+		if math.Abs(v) < 1000 {
+			break
+		}
+		v /= 1000
+		unit = u
+	}
+
+	out := fmt.Sprintf("%.4g%s", v, unit)
+	if s.N > 1 && s.Mean != 0 && s.StdDev != 0 {
+		out += fmt.Sprintf(" ± %.0f%%", s.StdDev/s.Mean*100)
+	}
+
+	return out
+}
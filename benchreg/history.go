@@ -0,0 +1,128 @@
+package benchreg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+/*
+15_test.go 의 BenchmarkConvert1..3 결과를 눈으로 비교하지 않도록 기록하고 비교한다.
+
+	history.json
+	{
+	  "version": 1,
+	  "runs": [
+	    {"label": "a1b2c3d", "time": "...", "benchmarks": {"Convert1": [{"ns_per_op": ..., "bytes_per_op": ..., "allocs_per_op": ...}, ...]}}
+	  ]
+	}
+
+version 은 파일 형식의 버전이다. 형식이 바뀌면 SchemaVersion 을 올리고 Load 에서 이전 버전을 변환한다.
+*/
+
+// SchemaVersion 은 이 패키지가 읽고 쓰는 history 파일 형식의 버전이다
+const SchemaVersion = 1
+
+var ErrUnsupportedVersion = errors.New("unsupported history version")
+
+// Sample 은 벤치마크 한번 실행 결과다 (-count 10 이면 10개)
+type Sample struct {
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+}
+
+// Run 은 한번의 go test -bench 실행이다. Label 은 보통 커밋 해시나 버전이다.
+type Run struct {
+	Label      string              `json:"label"`
+	Time       time.Time           `json:"time"`
+	GoVersion  string              `json:"go_version,omitempty"`
+	Package    string              `json:"package,omitempty"`
+	Benchmarks map[string][]Sample `json:"benchmarks"`
+}
+
+type History struct {
+	Version int   `json:"version"`
+	Runs    []Run `json:"runs"`
+}
+
+// Load 는 history 파일을 읽는다. 파일이 없으면 빈 history 를 돌려준다.
+func Load(path string) (*History, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &History{Version: SchemaVersion}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var h History
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if h.Version < 1 || h.Version > SchemaVersion {
+		return nil, fmt.Errorf("%s: %w %d (want 1..%d)", path, ErrUnsupportedVersion, h.Version, SchemaVersion)
+	}
+	h.Version = SchemaVersion
+
+	return &h, nil
+}
+
+// Save 는 임시 파일에 쓴 뒤 rename 하므로 중간에 실패해도 기존 history 가 깨지지 않는다
+func (h *History) Save(path string) error {
+	h.Version = SchemaVersion
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (h *History) Add(r Run) {
+	h.Runs = append(h.Runs, r)
+}
+
+// Find 는 label 이 같은 가장 최근 실행을 돌려준다
+func (h *History) Find(label string) (Run, bool) {
+	for i := len(h.Runs) - 1; i >= 0; i-- {
+		if h.Runs[i].Label == label {
+			return h.Runs[i], true
+		}
+	}
+
+	return Run{}, false
+}
+
+// Last 는 뒤에서 n 번째 실행을 돌려준다 (Last(0) 이 가장 최근)
+func (h *History) Last(n int) (Run, bool) {
+	if n < 0 || n >= len(h.Runs) {
+		return Run{}, false
+	}
+
+	return h.Runs[len(h.Runs)-1-n], true
+}
@@ -0,0 +1,91 @@
+package benchreg
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// procSuffix 는 BenchmarkConvert1-8 의 -8 (GOMAXPROCS) 이다
+var procSuffix = regexp.MustCompile(`-\d+$`)
+
+/*
+Parse 는 go test -bench -benchmem 출력에서 결과 줄을 읽는다
+
+	BenchmarkConvert1-8   	     100	  12345678 ns/op	 8003584 B/op	      38 allocs/op
+
+이름은 Benchmark 접두사와 -8 을 뺀 Convert1 로 저장한다. 같은 이름이 여러번 나오면 (-count) Sample 이 쌓인다.
+*/
+func Parse(r io.Reader) (map[string][]Sample, error) {
+	out := map[string][]Sample{}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue // 벤치마크 이름만 출력된 줄
+		}
+
+		name := procSuffix.ReplaceAllString(strings.TrimPrefix(fields[0], "Benchmark"), "")
+
+		var s Sample
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse %q: %w", sc.Text(), err)
+			}
+
+			switch fields[i+1] {
+			case "ns/op":
+				s.NsPerOp = v
+			case "B/op":
+				s.BytesPerOp = v
+			case "allocs/op":
+				s.AllocsPerOp = v
+			}
+		}
+
+		out[name] = append(out[name], s)
+	}
+
+	return out, sc.Err()
+}
+
+/*
+RunBenchmarks 는 pkg 의 pattern 벤치마크를 count 번 실행한다. go test 출력은 w 로도 흘려보낸다.
+
+	go test -run ^$ -bench pattern -benchmem -count count pkg
+*/
+func RunBenchmarks(ctx context.Context, dir, pkg, pattern string, count int, w io.Writer) (map[string][]Sample, error) {
+	cmd := exec.CommandContext(ctx, "go", "test", "-run", "^$", "-bench", pattern, "-benchmem", "-count", strconv.Itoa(count), pkg)
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+
+	var buf strings.Builder
+	cmd.Stdout = io.MultiWriter(&buf, w)
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go test -bench %s %s: %w", pattern, pkg, err)
+	}
+
+	samples, err := Parse(strings.NewReader(buf.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no benchmarks matched %q in %s", pattern, pkg)
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,148 @@
+package benchreg
+
+import "math"
+
+// Summary 는 표본 평균과 표본 표준편차다
+type Summary struct {
+	N      int
+	Mean   float64
+	StdDev float64
+}
+
+func summarize(xs []float64) Summary {
+	s := Summary{N: len(xs)}
+	if s.N == 0 {
+		return s
+	}
+
+	for _, x := range xs {
+		s.Mean += x
+	}
+	s.Mean /= float64(s.N)
+
+	if s.N > 1 {
+		var ss float64
+		for _, x := range xs {
+			ss += (x - s.Mean) * (x - s.Mean)
+		}
+		s.StdDev = math.Sqrt(ss / float64(s.N-1))
+	}
+
+	return s
+}
+
+/*
+welch 는 두 평균의 차이 (head - base) 에 대한 Welch t 신뢰구간이다.
+두 표본 모두 분산이 0 이면 (allocs/op 처럼 항상 같은 값) 구간은 차이 한 점이다.
+표본이 2개보다 적으면 분산을 알 수 없으므로 ok == false 다.
+*/
+func welch(base, head Summary, confidence float64) (lo, hi float64, ok bool) {
+	if base.N < 2 || head.N < 2 {
+		return 0, 0, false
+	}
+
+	diff := head.Mean - base.Mean
+
+	vb := base.StdDev * base.StdDev / float64(base.N)
+	vh := head.StdDev * head.StdDev / float64(head.N)
+	if vb == 0 && vh == 0 {
+		return diff, diff, true
+	}
+
+	se := math.Sqrt(vb + vh)
+	df := (vb + vh) * (vb + vh) / (vb*vb/float64(base.N-1) + vh*vh/float64(head.N-1))
+	t := studentQuantile(1-(1-confidence)/2, df)
+
+	return diff - t*se, diff + t*se, true
+}
+
+// studentQuantile 은 자유도 df 인 t 분포의 p 분위수다 (p > 0.5). CDF 를 이분 탐색한다.
+func studentQuantile(p, df float64) float64 {
+	lo, hi := 0.0, 1e3
+	for range 200 {
+		mid := (lo + hi) / 2
+		if studentCDF(mid, df) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2
+}
+
+// studentCDF 는 t >= 0 에서의 t 분포 누적분포함수다
+func studentCDF(t, df float64) float64 {
+	x := df / (df + t*t)
+	return 1 - 0.5*incompleteBeta(df/2, 0.5, x)
+}
+
+// incompleteBeta 는 정규화된 불완전 베타 함수 I_x(a, b) 다 (Numerical Recipes 의 연분수 전개)
+func incompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaCF(a, b, x) / a
+	}
+
+	return 1 - front*betaCF(b, a, 1-x)/b
+}
+
+func betaCF(a, b, x float64) float64 {
+	const (
+		eps  = 1e-14
+		tiny = 1e-300
+	)
+
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1.0; m <= 300; m++ {
+		m2 := 2 * m
+
+		aa := m * (b - m) * x / ((a + m2 - 1) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + m) * (a + b + m) * x / ((a + m2) * (a + m2 + 1))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+
+	return h
+}
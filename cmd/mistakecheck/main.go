@@ -0,0 +1,37 @@
+// mistakecheck 는 이 저장소의 실수 패턴 analyzer 를 한번에 실행한다
+//
+//	go run ./cmd/mistakecheck ./...
+//	go vet -vettool=$(which mistakecheck) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/zkfmapf123/100/analyzers/anyapi"
+	"github.com/zkfmapf123/100/analyzers/copylen"
+	"github.com/zkfmapf123/100/analyzers/initcheck"
+	"github.com/zkfmapf123/100/analyzers/loopptr"
+	"github.com/zkfmapf123/100/analyzers/nestedif"
+	"github.com/zkfmapf123/100/analyzers/nilslice"
+	"github.com/zkfmapf123/100/analyzers/prealloc"
+	"github.com/zkfmapf123/100/analyzers/rangecopy"
+	"github.com/zkfmapf123/100/analyzers/shadow"
+	"github.com/zkfmapf123/100/analyzers/subslice"
+	"github.com/zkfmapf123/100/analyzers/typeswitch"
+)
+
+func main() {
+	multichecker.Main(
+		shadow.Analyzer,
+		nestedif.Analyzer,
+		initcheck.Analyzer,
+		anyapi.Analyzer,
+		typeswitch.Analyzer,
+		prealloc.Analyzer,
+		nilslice.Analyzer,
+		copylen.Analyzer,
+		subslice.Analyzer,
+		rangecopy.Analyzer,
+		loopptr.Analyzer,
+	)
+}
@@ -0,0 +1,128 @@
+package collections
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+/*
+직접 작성한 버전(용량을 잡지 않음, 5.go 의 GetKeys 와 같은 방식)과 비교한다
+
+	go test ./collections -bench . -benchmem
+*/
+
+const benchSize = 100000
+
+func benchMap() map[string]int {
+	m := make(map[string]int, benchSize)
+	for i := range benchSize {
+		m[strconv.Itoa(i)] = i
+	}
+
+	return m
+}
+
+func benchSlice() []int {
+	s := make([]int, benchSize)
+	for i := range s {
+		s[i] = i % 1000
+	}
+
+	return s
+}
+
+func handKeys(m map[string]int) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+func handFilter(s []int, keep func(int) bool) []int {
+	var out []int
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+func handMap(s []int, f func(int) string) []string {
+	var out []string
+	for _, v := range s {
+		out = append(out, f(v))
+	}
+
+	return out
+}
+
+func handUniq(s []int) []int {
+	seen := map[int]bool{}
+	var out []int
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+func handPartition(s []int, pred func(int) bool) (yes, no []int) {
+	for _, v := range s {
+		if pred(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+
+	return yes, no
+}
+
+func bench(b *testing.B, f func()) {
+	// GC 통계 초기화
+	runtime.GC()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for b.Loop() {
+		f()
+	}
+}
+
+func BenchmarkKeys(b *testing.B) {
+	m := benchMap()
+	b.Run("hand", func(b *testing.B) { bench(b, func() { handKeys(m) }) })
+	b.Run("collections", func(b *testing.B) { bench(b, func() { Keys(m) }) })
+}
+
+func BenchmarkFilter(b *testing.B) {
+	s := benchSlice()
+	b.Run("hand", func(b *testing.B) { bench(b, func() { handFilter(s, isEven) }) })
+	b.Run("collections", func(b *testing.B) { bench(b, func() { Filter(s, isEven) }) })
+}
+
+func BenchmarkMap(b *testing.B) {
+	s := benchSlice()
+	b.Run("hand", func(b *testing.B) { bench(b, func() { handMap(s, strconv.Itoa) }) })
+	b.Run("collections", func(b *testing.B) { bench(b, func() { Map(s, strconv.Itoa) }) })
+}
+
+func BenchmarkUniq(b *testing.B) {
+	s := benchSlice()
+	b.Run("hand", func(b *testing.B) { bench(b, func() { handUniq(s) }) })
+	b.Run("collections", func(b *testing.B) { bench(b, func() { Uniq(s) }) })
+}
+
+func BenchmarkPartition(b *testing.B) {
+	s := benchSlice()
+	b.Run("hand", func(b *testing.B) { bench(b, func() { handPartition(s, isEven) }) })
+	b.Run("collections", func(b *testing.B) { bench(b, func() { Partition(s, isEven) }) })
+}
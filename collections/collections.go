@@ -0,0 +1,142 @@
+package collections
+
+import (
+	"cmp"
+	"slices"
+)
+
+/*
+5.go 의 GetKeysUseGeneric 에서 출발한 제네릭 map/slice 유틸리티
+
+- GetKeysUseGeneric 은 ~int | ~string 만 받았지만 여기서는 comparable 이면 모두 받는다
+- 결과 길이를 알 수 있으면 항상 용량을 미리 잡는다 (15.go 의 convert2)
+- 순서가 필요하면 SortedKeys 를 쓴다 (map 순회 순서는 매번 다르다)
+*/
+
+// Keys 는 m 의 key 를 돌려준다. 순서는 정해져 있지 않다.
+func Keys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// Values 는 m 의 value 를 돌려준다. 순서는 정해져 있지 않다.
+func Values[M ~map[K]V, K comparable, V any](m M) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+// SortedKeys 는 m 의 key 를 오름차순으로 돌려준다
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+
+	return keys
+}
+
+// Filter 는 keep 이 true 인 원소만 순서대로 돌려준다.
+// 결과 길이를 미리 알 수 없으므로 최악의 경우인 len(s) 만큼 용량을 잡는다.
+func Filter[S ~[]E, E any](s S, keep func(E) bool) S {
+	out := make(S, 0, len(s))
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// Map 은 s 의 각 원소에 f 를 적용한 결과를 돌려준다
+func Map[S ~[]E, E, R any](s S, f func(E) R) []R {
+	out := make([]R, 0, len(s))
+	for _, v := range s {
+		out = append(out, f(v))
+	}
+
+	return out
+}
+
+// Reduce 는 init 에서 시작해 s 의 원소를 차례로 f 로 누적한다
+func Reduce[S ~[]E, E, A any](s S, init A, f func(A, E) A) A {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+
+	return acc
+}
+
+// GroupBy 는 key 가 같은 원소끼리 묶는다. 그룹 안의 순서는 s 의 순서를 따른다.
+func GroupBy[S ~[]E, E any, K comparable](s S, key func(E) K) map[K]S {
+	groups := make(map[K]S)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+
+	return groups
+}
+
+// Partition 은 pred 가 true 인 원소와 false 인 원소를 나눈다. 두 결과 모두 s 의 순서를 따른다.
+// 두 결과는 len(s) 크기의 배열 하나를 나눠 쓰므로 할당은 한번이다.
+func Partition[S ~[]E, E any](s S, pred func(E) bool) (yes, no S) {
+	buf := make(S, len(s))
+
+	i, j := 0, len(s)
+	for _, v := range s {
+		if pred(v) {
+			buf[i] = v
+			i++
+		} else {
+			j--
+			buf[j] = v
+		}
+	}
+
+	// no 는 뒤에서부터 채웠으므로 뒤집는다
+	slices.Reverse(buf[i:])
+
+	// 용량을 잘라 yes 에 append 해도 no 를 덮어쓰지 않게 한다
+	return buf[:i:i], buf[i:]
+}
+
+// Chunk 는 s 를 size 개씩 나눈다. 마지막 조각은 더 짧을 수 있다.
+// 각 조각은 s 를 공유하지만 용량을 잘라 두었으므로 append 해도 다음 조각을 덮어쓰지 않는다.
+func Chunk[S ~[]E, E any](s S, size int) []S {
+	if size < 1 {
+		panic("collections: Chunk size must be positive")
+	}
+
+	chunks := make([]S, 0, (len(s)+size-1)/size)
+	for i := 0; i < len(s); i += size {
+		end := min(i+size, len(s))
+		chunks = append(chunks, s[i:end:end])
+	}
+
+	return chunks
+}
+
+// Uniq 는 처음 나온 순서대로 중복을 뺀 원소를 돌려준다.
+// seen map 은 크기를 미리 잡지 않는다. 중복이 많으면 len(s) 크기의 map 이 결과보다 훨씬 비싸다.
+func Uniq[S ~[]E, E comparable](s S) S {
+	seen := make(map[E]struct{})
+	out := make(S, 0, len(s))
+	for _, v := range s {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+
+	return out
+}
@@ -0,0 +1,184 @@
+package collections
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func isEven(n int) bool { return n%2 == 0 }
+
+func TestKeysValues(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+
+	keys := Keys(m)
+	slices.Sort(keys)
+	if want := []string{"a", "b", "c"}; !slices.Equal(keys, want) {
+		t.Errorf("Keys = %v, want %v", keys, want)
+	}
+
+	values := Values(m)
+	slices.Sort(values)
+	if want := []int{1, 2, 3}; !slices.Equal(values, want) {
+		t.Errorf("Values = %v, want %v", values, want)
+	}
+
+	if got := SortedKeys(m); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("SortedKeys = %v", got)
+	}
+
+	// map[float64]string 같이 GetKeysUseGeneric 이 받지 못하던 타입도 된다
+	if got := SortedKeys(map[float64]string{2.5: "", -1: ""}); !slices.Equal(got, []float64{-1, 2.5}) {
+		t.Errorf("SortedKeys(float64) = %v", got)
+	}
+
+	if got := Keys(map[int]int(nil)); got == nil || len(got) != 0 {
+		t.Errorf("Keys(nil) = %#v, want empty non-nil", got)
+	}
+}
+
+func TestSliceFuncs(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6, 7}
+
+	if got := Filter(s, isEven); !slices.Equal(got, []int{2, 4, 6}) {
+		t.Errorf("Filter = %v", got)
+	}
+
+	if got := Map(s[:3], strconv.Itoa); !slices.Equal(got, []string{"1", "2", "3"}) {
+		t.Errorf("Map = %v", got)
+	}
+
+	if got := Reduce(s, 0, func(a, v int) int { return a + v }); got != 28 {
+		t.Errorf("Reduce = %d, want 28", got)
+	}
+
+	groups := GroupBy(s, func(v int) int { return v % 3 })
+	if want := map[int][]int{0: {3, 6}, 1: {1, 4, 7}, 2: {2, 5}}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("GroupBy = %v, want %v", groups, want)
+	}
+
+	if got := Uniq([]string{"a", "b", "a", "c", "b"}); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Uniq = %v", got)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	yes, no := Partition([]int{1, 2, 3, 4, 5, 6, 7}, isEven)
+	if !slices.Equal(yes, []int{2, 4, 6}) || !slices.Equal(no, []int{1, 3, 5, 7}) {
+		t.Fatalf("Partition = %v, %v", yes, no)
+	}
+
+	// yes 에 append 해도 no 가 바뀌면 안 된다
+	_ = append(yes, 100)
+	if !slices.Equal(no, []int{1, 3, 5, 7}) {
+		t.Errorf("append to yes overwrote no: %v", no)
+	}
+
+	yes, no = Partition([]int(nil), isEven)
+	if len(yes) != 0 || len(no) != 0 {
+		t.Errorf("Partition(nil) = %v, %v", yes, no)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		size int
+		want [][]int
+	}{
+		{1, [][]int{{1}, {2}, {3}, {4}, {5}}},
+		{2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{5, [][]int{{1, 2, 3, 4, 5}}},
+		{10, [][]int{{1, 2, 3, 4, 5}}},
+	}
+
+	for _, tt := range tests {
+		got := Chunk(s, tt.size)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Chunk(%d) = %v, want %v", tt.size, got, tt.want)
+		}
+
+		if cap(got) != len(got) {
+			t.Errorf("Chunk(%d) cap = %d, len = %d", tt.size, cap(got), len(got))
+		}
+
+		if seq := slices.Collect(ChunkSeq(s, tt.size)); !reflect.DeepEqual(seq, tt.want) {
+			t.Errorf("ChunkSeq(%d) = %v, want %v", tt.size, seq, tt.want)
+		}
+	}
+
+	chunks := Chunk(s, 2)
+	_ = append(chunks[0], 100)
+	if s[2] != 3 {
+		t.Errorf("append to chunk overwrote next chunk: %v", s)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunk(0) did not panic")
+		}
+	}()
+	Chunk(s, 0)
+}
+
+func TestSeq(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+
+	keys := slices.Sorted(KeysSeq(m))
+	if !slices.Equal(keys, []string{"a", "b", "c", "d"}) {
+		t.Errorf("KeysSeq = %v", keys)
+	}
+
+	if got := slices.Sorted(ValuesSeq(m)); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("ValuesSeq = %v", got)
+	}
+
+	var pairs []string
+	for k, v := range SortedSeq2(m) {
+		pairs = append(pairs, k+strconv.Itoa(v))
+	}
+	if !slices.Equal(pairs, []string{"a1", "b2", "c3", "d4"}) {
+		t.Errorf("SortedSeq2 = %v", pairs)
+	}
+
+	even := FilterSeq2(SortedSeq2(m), func(_ string, v int) bool { return isEven(v) })
+	if got := slices.Collect(MapSeq2(even, func(k string, _ int) string { return k })); !slices.Equal(got, []string{"b", "d"}) {
+		t.Errorf("FilterSeq2/MapSeq2 = %v", got)
+	}
+
+	s := slices.Values([]int{1, 2, 2, 3, 4, 4, 5})
+
+	if got := slices.Collect(MapSeq(FilterSeq(UniqSeq(s), isEven), strconv.Itoa)); !slices.Equal(got, []string{"2", "4"}) {
+		t.Errorf("UniqSeq/FilterSeq/MapSeq = %v", got)
+	}
+
+	if got := ReduceSeq(s, 0, func(a, v int) int { return a + v }); got != 21 {
+		t.Errorf("ReduceSeq = %d, want 21", got)
+	}
+
+	if got := GroupBySeq(s, isEven); !reflect.DeepEqual(got, map[bool][]int{true: {2, 2, 4, 4}, false: {1, 3, 5}}) {
+		t.Errorf("GroupBySeq = %v", got)
+	}
+
+	yes, no := PartitionSeq(s, isEven)
+	if !slices.Equal(yes, []int{2, 2, 4, 4}) || !slices.Equal(no, []int{1, 3, 5}) {
+		t.Errorf("PartitionSeq = %v, %v", yes, no)
+	}
+
+	// 중간에 멈추면 더 이상 yield 하지 않는다
+	var first []int
+	for v := range FilterSeq(s, isEven) {
+		first = append(first, v)
+		break
+	}
+	if !slices.Equal(first, []int{2}) {
+		t.Errorf("break after first = %v", first)
+	}
+
+	if got := slices.Collect(maps.Keys(map[int]bool{})); len(got) != 0 {
+		t.Errorf("empty map keys = %v", got)
+	}
+}
@@ -0,0 +1,153 @@
+package collections
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+/*
+iter.Seq / iter.Seq2 를 돌려주는 버전
+
+중간 slice 를 만들지 않으므로 체인으로 엮거나 중간에 멈출 때 유리하다.
+
+	for k := range collections.FilterSeq(collections.KeysSeq(m), isActive) {
+		...
+	}
+*/
+
+// KeysSeq 는 m 의 key 를 순회한다. 순서는 정해져 있지 않다.
+func KeysSeq[M ~map[K]V, K comparable, V any](m M) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq 는 m 의 value 를 순회한다. 순서는 정해져 있지 않다.
+func ValuesSeq[M ~map[K]V, K comparable, V any](m M) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SortedSeq2 는 m 의 key, value 를 key 오름차순으로 순회한다
+func SortedSeq2[M ~map[K]V, K cmp.Ordered, V any](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range SortedKeys(m) {
+			if !yield(k, m[k]) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq 는 keep 이 true 인 원소만 순회한다
+func FilterSeq[E any](seq iter.Seq[E], keep func(E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq2 는 keep 이 true 인 쌍만 순회한다
+func FilterSeq2[K, V any](seq iter.Seq2[K, V], keep func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if keep(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// MapSeq 는 각 원소에 f 를 적용한 값을 순회한다
+func MapSeq[E, R any](seq iter.Seq[E], f func(E) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// MapSeq2 는 각 쌍에 f 를 적용한 값을 순회한다
+func MapSeq2[K, V, R any](seq iter.Seq2[K, V], f func(K, V) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for k, v := range seq {
+			if !yield(f(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// ReduceSeq 는 init 에서 시작해 seq 의 원소를 차례로 f 로 누적한다
+func ReduceSeq[E, A any](seq iter.Seq[E], init A, f func(A, E) A) A {
+	acc := init
+	for v := range seq {
+		acc = f(acc, v)
+	}
+
+	return acc
+}
+
+// ChunkSeq 는 s 를 size 개씩 나눈 조각을 순회한다. 조각은 Chunk 와 같이 용량이 잘려 있다.
+func ChunkSeq[S ~[]E, E any](s S, size int) iter.Seq[S] {
+	if size < 1 {
+		panic("collections: ChunkSeq size must be positive")
+	}
+
+	return func(yield func(S) bool) {
+		for i := 0; i < len(s); i += size {
+			end := min(i+size, len(s))
+			if !yield(s[i:end:end]) {
+				return
+			}
+		}
+	}
+}
+
+// UniqSeq 는 처음 나온 순서대로 중복을 뺀 원소를 순회한다
+func UniqSeq[E comparable](seq iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		seen := make(map[E]struct{})
+		for v := range seq {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+
+			seen[v] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// GroupBySeq 는 seq 를 key 별로 묶는다. seq 를 끝까지 읽어야 하므로 map 을 돌려준다.
+func GroupBySeq[E any, K comparable](seq iter.Seq[E], key func(E) K) map[K][]E {
+	groups := make(map[K][]E)
+	for v := range seq {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+
+	return groups
+}
+
+// PartitionSeq 는 seq 를 pred 결과로 나눠 slice 로 모은다
+func PartitionSeq[E any](seq iter.Seq[E], pred func(E) bool) (yes, no []E) {
+	return Partition(slices.Collect(seq), pred)
+}
@@ -0,0 +1,483 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+3.go 의 전역 map[string]string 대신 타입이 있는 구조체로 설정을 읽는다.
+
+	type AppConfig struct {
+		Host string `config:"host" default:"localhost" required:"true"`
+		Port int    `config:"port" default:"5432" min:"1" max:"65535"`
+		DB   struct {
+			Timeout time.Duration `config:"timeout" default:"3s" min:"100ms" max:"1m"`
+		} `config:"db"`
+	}
+
+	loader := config.New[AppConfig](
+		config.WithFile("app.json"),
+		config.WithEnv("APP"),
+		config.WithFlags(flag.CommandLine, os.Args[1:]),
+	)
+	cfg, err := loader.Load()
+
+우선순위 : default < file < env < flag (뒤에 오는 값이 앞의 값을 덮어쓴다)
+
+- 키 이름은 config 태그 (없으면 필드 이름)를 소문자로 쓰고, 중첩 구조체는 "db.timeout" 처럼 점으로 잇는다
+- 환경변수는 APP_DB_TIMEOUT, 플래그는 -db.timeout 이다
+- FlagSet 에 같은 이름의 플래그가 이미 있으면 새로 정의하지 않고 그 값을 읽는다
+- 잘못된 값은 어느 소스(file:line, env 이름, flag 이름)에서 왔는지 함께 모든 에러를 돌려준다
+*/
+
+// Source 는 값을 제공한 곳의 종류다
+type Source int
+
+const (
+	SourceNone Source = iota
+	SourceDefault
+	SourceFile
+	SourceEnv
+	SourceFlag
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceFile:
+		return "file"
+	case SourceEnv:
+		return "env"
+	case SourceFlag:
+		return "flag"
+	}
+
+	return "none"
+}
+
+// value 는 하나의 키에 대해 어떤 소스가 어떤 값을 줬는지다
+type value struct {
+	raw    string
+	source Source
+	origin string // app.toml:3, APP_PORT, -port ...
+}
+
+// FieldError 는 특정 키의 값이 잘못되었을 때의 에러다
+type FieldError struct {
+	Key    string
+	Source Source
+	Origin string
+	Value  string
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	if e.Source == SourceNone {
+		return fmt.Sprintf("%s: %v", e.Key, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %v (value %q from %s %s)", e.Key, e.Err, e.Value, e.Source, e.Origin)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Errors 는 Load 중에 발견된 모든 FieldError 다
+type Errors []*FieldError
+
+func (es Errors) Error() string {
+	lines := make([]string, 0, len(es))
+	for _, e := range es {
+		lines = append(lines, e.Error())
+	}
+
+	return "config: " + strings.Join(lines, "; ")
+}
+
+func (es Errors) Unwrap() []error {
+	errs := make([]error, 0, len(es))
+	for _, e := range es {
+		errs = append(errs, e)
+	}
+
+	return errs
+}
+
+var (
+	ErrRequired   = errors.New("required value is missing")
+	ErrOutOfRange = errors.New("value out of range")
+)
+
+type Option func(o *options)
+
+type options struct {
+	file      string
+	envPrefix *string
+	flagSet   *flag.FlagSet
+	flagArgs  []string
+	interval  time.Duration
+}
+
+// WithFile 은 설정 파일을 읽는다. 확장자가 .json 이면 JSON, 그 외는 TOML 형태(key = value)로 읽는다.
+func WithFile(path string) Option {
+	return func(o *options) {
+		o.file = path
+	}
+}
+
+// WithEnv 는 PREFIX_KEY 형태의 환경변수를 읽는다
+func WithEnv(prefix string) Option {
+	return func(o *options) {
+		o.envPrefix = &prefix
+	}
+}
+
+// WithFlags 는 모든 키를 fs 에 플래그로 등록하고 args 를 파싱한다
+func WithFlags(fs *flag.FlagSet, args []string) Option {
+	return func(o *options) {
+		o.flagSet = fs
+		o.flagArgs = args
+	}
+}
+
+// WithPollInterval 은 Watch 가 파일 변경을 확인하는 주기다
+func WithPollInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.interval = d
+	}
+}
+
+type Loader[T any] struct {
+	opts   options
+	fields []field
+
+	flagOnce sync.Once
+	flagErr  error
+	flagVals map[string]value
+
+	mu          sync.Mutex
+	subscribers map[int]func(T, error)
+	nextID      int
+	loaded      *fileState // 마지막 Load 가 읽은 파일 상태
+}
+
+func New[T any](opts ...Option) *Loader[T] {
+	l := &Loader[T]{
+		opts:        options{interval: time.Second},
+		subscribers: map[int]func(T, error){},
+	}
+
+	for _, opt := range opts {
+		opt(&l.opts)
+	}
+
+	var zero T
+	l.fields = fields(reflect.TypeOf(zero), nil, "")
+
+	return l
+}
+
+// Load 는 모든 소스를 우선순위대로 합친 뒤 T 로 디코딩하고 검증한다
+func (l *Loader[T]) Load() (T, error) {
+	var cfg T
+
+	values := map[string]value{}
+	for _, f := range l.fields {
+		if d, ok := f.tag.Lookup("default"); ok {
+			values[f.key] = value{raw: d, source: SourceDefault, origin: "struct tag"}
+		}
+	}
+
+	if l.opts.file != "" {
+		state, _ := stat(l.opts.file)
+		fileVals, err := readFile(l.opts.file)
+		if err != nil {
+			return cfg, err
+		}
+		merge(values, fileVals)
+
+		l.mu.Lock()
+		l.loaded = &state
+		l.mu.Unlock()
+	}
+
+	if l.opts.envPrefix != nil {
+		merge(values, l.env(*l.opts.envPrefix))
+	}
+
+	if l.opts.flagSet != nil {
+		flagVals, err := l.flags()
+		if err != nil {
+			return cfg, err
+		}
+		merge(values, flagVals)
+	}
+
+	if err := l.decode(reflect.ValueOf(&cfg).Elem(), values); err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
+
+func merge(dst, src map[string]value) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
+
+func (l *Loader[T]) env(prefix string) map[string]value {
+	vals := map[string]value{}
+	for _, f := range l.fields {
+		name := strings.ToUpper(strings.ReplaceAll(f.key, ".", "_"))
+		if prefix != "" {
+			name = strings.ToUpper(prefix) + "_" + name
+		}
+
+		if raw, ok := os.LookupEnv(name); ok {
+			vals[f.key] = value{raw: raw, source: SourceEnv, origin: name}
+		}
+	}
+
+	return vals
+}
+
+// flags 는 플래그를 한번만 등록하고 파싱한다 (reload 시에도 같은 값을 쓴다)
+func (l *Loader[T]) flags() (map[string]value, error) {
+	l.flagOnce.Do(func() {
+		fs := l.opts.flagSet
+		raw := make(map[string]bool, len(l.fields))
+		for _, f := range l.fields {
+			usage := f.tag.Get("usage")
+			if usage == "" {
+				usage = "sets " + f.key
+			}
+			// 이미 정의된 플래그는 다시 정의하면 panic 이므로 그 값을 그대로 읽는다
+			if fs.Lookup(f.key) == nil {
+				fs.String(f.key, f.tag.Get("default"), usage)
+			}
+			raw[f.key] = true
+		}
+
+		if err := fs.Parse(l.opts.flagArgs); err != nil {
+			l.flagErr = fmt.Errorf("config: parse flags: %w", err)
+			return
+		}
+
+		l.flagVals = map[string]value{}
+		fs.Visit(func(fl *flag.Flag) {
+			if raw[fl.Name] {
+				l.flagVals[fl.Name] = value{raw: fl.Value.String(), source: SourceFlag, origin: "-" + fl.Name}
+			}
+		})
+	})
+
+	return l.flagVals, l.flagErr
+}
+
+// field 는 디코딩할 구조체의 말단 필드다
+type field struct {
+	key   string
+	index []int
+	tag   reflect.StructTag
+}
+
+func fields(t reflect.Type, index []int, prefix string) []field {
+	var out []field
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := sf.Tag.Get("config")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		name = strings.ToLower(name)
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		idx := append(append([]int(nil), index...), i)
+		if sf.Type.Kind() == reflect.Struct {
+			out = append(out, fields(sf.Type, idx, key)...)
+			continue
+		}
+
+		out = append(out, field{key: key, index: idx, tag: sf.Tag})
+	}
+
+	return out
+}
+
+func (l *Loader[T]) decode(root reflect.Value, values map[string]value) error {
+	var errs Errors
+
+	for _, f := range l.fields {
+		v, ok := values[f.key]
+		fv := root.FieldByIndex(f.index)
+
+		if !ok {
+			if f.tag.Get("required") == "true" {
+				errs = append(errs, &FieldError{Key: f.key, Err: ErrRequired})
+			}
+			continue
+		}
+
+		fail := func(err error) {
+			errs = append(errs, &FieldError{Key: f.key, Source: v.source, Origin: v.origin, Value: v.raw, Err: err})
+		}
+
+		if err := setValue(fv, v.raw); err != nil {
+			fail(err)
+			continue
+		}
+
+		if f.tag.Get("required") == "true" && fv.IsZero() {
+			fail(ErrRequired)
+			continue
+		}
+
+		if err := checkRange(fv, f.tag); err != nil {
+			fail(err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setValue(fv reflect.Value, raw string) error {
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return unwrapNumError(err)
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return unwrapNumError(err)
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, fv.Type().Bits())
+		if err != nil {
+			return unwrapNumError(err)
+		}
+		fv.SetFloat(n)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", fv.Type())
+		}
+
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		fv.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+
+	return nil
+}
+
+// unwrapNumError 는 strconv 에러 메시지에서 값이 중복으로 나오지 않게 한다
+func unwrapNumError(err error) error {
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		return ne.Err
+	}
+
+	return err
+}
+
+func checkRange(fv reflect.Value, tag reflect.StructTag) error {
+	// Duration 의 min/max 는 "1s" 처럼 적는다
+	parse := func(s string) (float64, error) { return strconv.ParseFloat(s, 64) }
+	if fv.Type() == durationType {
+		parse = func(s string) (float64, error) {
+			d, err := time.ParseDuration(s)
+			return float64(d), err
+		}
+	}
+
+	var n float64
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(fv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(fv.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = fv.Float()
+	case reflect.String, reflect.Slice:
+		n = float64(fv.Len())
+	default:
+		return nil
+	}
+
+	if s, ok := tag.Lookup("min"); ok {
+		minimum, err := parse(s)
+		if err != nil {
+			return fmt.Errorf("invalid min tag %q", s)
+		}
+		if n < minimum {
+			return fmt.Errorf("%w: must be >= %s", ErrOutOfRange, s)
+		}
+	}
+
+	if s, ok := tag.Lookup("max"); ok {
+		maximum, err := parse(s)
+		if err != nil {
+			return fmt.Errorf("invalid max tag %q", s)
+		}
+		if n > maximum {
+			return fmt.Errorf("%w: must be <= %s", ErrOutOfRange, s)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Host  string   `config:"host" default:"localhost" required:"true"`
+	Port  int      `config:"port" default:"5432" min:"1" max:"65535"`
+	Debug bool     `config:"debug"`
+	Tags  []string `config:"tags"`
+	DB    struct {
+		Timeout time.Duration `config:"timeout" default:"3s" min:"1s" max:"1m"`
+		Pool    uint          `config:"pool" default:"4" max:"64"`
+	} `config:"db"`
+	Ignored string `config:"-"`
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestDefaults(t *testing.T) {
+	cfg, err := New[testConfig]().Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Host != "localhost" || cfg.Port != 5432 || cfg.DB.Timeout != 3*time.Second || cfg.DB.Pool != 4 {
+		t.Errorf("cfg = %+v", cfg)
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	path := writeFile(t, "app.toml", `
+# 파일은 default 를 덮어쓴다
+host = "file-host"
+port = 6000
+tags = ["a", "b"]
+
+[db]
+timeout = "5s"
+pool = 8
+`)
+
+	// env 는 file 을, flag 는 env 를 덮어쓴다
+	t.Setenv("APP_PORT", "7000")
+	t.Setenv("APP_DB_POOL", "16")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l := New[testConfig](
+		WithFile(path),
+		WithEnv("app"),
+		WithFlags(fs, []string{"-db.pool", "32", "-debug", "true"}),
+	)
+
+	cfg, err := l.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Host != "file-host" {
+		t.Errorf("Host = %q, want file-host", cfg.Host)
+	}
+	if cfg.Port != 7000 {
+		t.Errorf("Port = %d, want 7000 from env", cfg.Port)
+	}
+	if cfg.DB.Pool != 32 {
+		t.Errorf("DB.Pool = %d, want 32 from flag", cfg.DB.Pool)
+	}
+	if cfg.DB.Timeout != 5*time.Second {
+		t.Errorf("DB.Timeout = %s, want 5s from file", cfg.DB.Timeout)
+	}
+	if !cfg.Debug {
+		t.Error("Debug = false, want true from flag")
+	}
+	if strings.Join(cfg.Tags, ",") != "a,b" {
+		t.Errorf("Tags = %v, want [a b]", cfg.Tags)
+	}
+
+	// 다시 읽어도 플래그는 다시 등록되지 않는다
+	if _, err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONFile(t *testing.T) {
+	path := writeFile(t, "app.json", `{"host": "json-host", "port": 8080, "db": {"timeout": "1s"}, "tags": ["x"]}`)
+
+	cfg, err := New[testConfig](WithFile(path)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Host != "json-host" || cfg.Port != 8080 || cfg.DB.Timeout != time.Second || len(cfg.Tags) != 1 {
+		t.Errorf("cfg = %+v", cfg)
+	}
+}
+
+func TestErrorsReportSource(t *testing.T) {
+	path := writeFile(t, "app.toml", "host = \"\"\nport = 0\n\n[db]\ntimeout = \"soon\"\n")
+	t.Setenv("APP_DB_POOL", "100")
+
+	_, err := New[testConfig](WithFile(path), WithEnv("APP")).Load()
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Load() = %v, want Errors", err)
+	}
+
+	// 모든 에러를 한번에 돌려준다
+	want := map[string]string{
+		"host":       `host: required value is missing (value "" from file ` + path + `:1)`,
+		"port":       `port: value out of range: must be >= 1 (value "0" from file ` + path + `:2)`,
+		"db.timeout": `db.timeout: time: invalid duration "soon" (value "soon" from file ` + path + `:5)`,
+		"db.pool":    `db.pool: value out of range: must be <= 64 (value "100" from env APP_DB_POOL)`,
+	}
+
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs), len(want), err)
+	}
+
+	for _, e := range errs {
+		if e.Error() != want[e.Key] {
+			t.Errorf("error for %s:\n got %s\nwant %s", e.Key, e.Error(), want[e.Key])
+		}
+	}
+
+	if !errors.Is(errs[1], ErrOutOfRange) {
+		t.Errorf("errors.Is(%v, ErrOutOfRange) = false", errs[1])
+	}
+}
+
+func TestFlagErrorSource(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	_, err := New[testConfig](WithFlags(fs, []string{"-port", "-1"})).Load()
+
+	if err == nil || !strings.Contains(err.Error(), `(value "-1" from flag -port)`) {
+		t.Errorf("Load() = %v, want error from flag -port", err)
+	}
+}
+
+func TestDurationRange(t *testing.T) {
+	for _, tt := range []struct {
+		timeout string
+		wantErr bool
+	}{
+		{"1s", false},
+		{"1m", false},
+		{"500ms", true},
+		{"2m", true},
+	} {
+		t.Setenv("APP_DB_TIMEOUT", tt.timeout)
+
+		_, err := New[testConfig](WithEnv("app")).Load()
+		if got := errors.Is(err, ErrOutOfRange); got != tt.wantErr {
+			t.Errorf("timeout %s: Load() = %v, want out of range %v", tt.timeout, err, tt.wantErr)
+		}
+	}
+}
+
+// 호출한 쪽이 먼저 정의한 플래그는 다시 정의하지 않고 그 값을 쓴다
+func TestFlagsAlreadyDefined(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	port := fs.Int("port", 8080, "listen port")
+
+	cfg, err := New[testConfig](WithFlags(fs, []string{"-port", "9000"})).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Port != 9000 || *port != 9000 {
+		t.Errorf("Port = %d, flag = %d, want 9000", cfg.Port, *port)
+	}
+}
+
+func TestRequiredMissing(t *testing.T) {
+	type cfg struct {
+		Token string `config:"token" required:"true"`
+	}
+
+	_, err := New[cfg]().Load()
+	if !errors.Is(err, ErrRequired) {
+		t.Errorf("Load() = %v, want ErrRequired", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	path := writeFile(t, "app.toml", "port = 6000\n")
+
+	l := New[testConfig](WithFile(path), WithPollInterval(5*time.Millisecond))
+	if _, err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(chan testConfig, 1)
+	unsubscribe := l.Subscribe(func(cfg testConfig, err error) {
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		got <- cfg
+	})
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- l.Watch(ctx)
+	}()
+
+	if err := os.WriteFile(path, []byte("port = 7000 # 변경\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case cfg := <-got:
+		if cfg.Port != 7000 {
+			t.Errorf("reloaded Port = %d, want 7000", cfg.Port)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload after file change")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Watch() = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// readFile 은 설정 파일을 "db.timeout" 같은 점으로 이은 키로 펼친다
+func readFile(path string) (map[string]value, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return parseJSON(path, b)
+	}
+
+	return parseTOML(path, b)
+}
+
+func parseJSON(path string, b []byte) (map[string]value, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+
+	vals := map[string]value{}
+	var walk func(prefix string, m map[string]any) error
+	walk = func(prefix string, m map[string]any) error {
+		for k, v := range m {
+			key := strings.ToLower(k)
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+
+			raw, nested, err := jsonScalar(v)
+			if err != nil {
+				return fmt.Errorf("config: %s: key %s: %w", path, key, err)
+			}
+
+			if nested != nil {
+				if err := walk(key, nested); err != nil {
+					return err
+				}
+				continue
+			}
+
+			vals[key] = value{raw: raw, source: SourceFile, origin: path}
+		}
+		return nil
+	}
+
+	if err := walk("", doc); err != nil {
+		return nil, err
+	}
+
+	return vals, nil
+}
+
+func jsonScalar(v any) (string, map[string]any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		return "", v, nil
+	case string:
+		return v, nil, nil
+	case json.Number:
+		return v.String(), nil, nil
+	case bool:
+		return strconv.FormatBool(v), nil, nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, nested, err := jsonScalar(item)
+			if err != nil || nested != nil {
+				return "", nil, fmt.Errorf("arrays may only contain scalars")
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil, nil
+	case nil:
+		return "", nil, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported value %T", v)
+}
+
+/*
+parseTOML 은 TOML 의 단순한 부분집합을 읽는다
+
+	# comment
+	host = "localhost"
+	port = 5432
+
+	[db]
+	timeout = "3s"
+	hosts = ["a", "b"]
+*/
+func parseTOML(path string, b []byte) (map[string]value, error) {
+	vals := map[string]value{}
+	section := ""
+
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(stripComment(sc.Text()))
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") {
+				return nil, fmt.Errorf("config: %s:%d: unterminated section %q", path, line, text)
+			}
+			section = strings.ToLower(strings.TrimSpace(text[1 : len(text)-1]))
+			continue
+		}
+
+		k, v, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("config: %s:%d: expected key = value, got %q", path, line, text)
+		}
+
+		key := strings.ToLower(strings.TrimSpace(k))
+		if section != "" {
+			key = section + "." + key
+		}
+
+		vals[key] = value{raw: tomlValue(strings.TrimSpace(v)), source: SourceFile, origin: fmt.Sprintf("%s:%d", path, line)}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	return vals, nil
+}
+
+func tomlValue(v string) string {
+	if strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]") {
+		items := strings.Split(v[1:len(v)-1], ",")
+		for i, item := range items {
+			items[i] = unquote(strings.TrimSpace(item))
+		}
+		return strings.Join(items, ",")
+	}
+
+	return unquote(v)
+}
+
+func unquote(v string) string {
+	if s, err := strconv.Unquote(v); err == nil {
+		return s
+	}
+
+	return strings.Trim(v, "'")
+}
+
+// stripComment 는 따옴표 밖의 # 부터 줄 끝까지 지운다
+func stripComment(s string) string {
+	quoted := false
+	for i, r := range s {
+		switch r {
+		case '"':
+			quoted = !quoted
+		case '#':
+			if !quoted {
+				return s[:i]
+			}
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// Subscribe 는 Watch 가 설정을 다시 읽을 때마다 fn 을 호출한다.
+// 다시 읽다가 실패하면 err 와 함께 호출되고, 이전 설정을 계속 쓸지는 호출하는 쪽이 정한다.
+func (l *Loader[T]) Subscribe(fn func(cfg T, err error)) (unsubscribe func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	id := l.nextID
+	l.nextID++
+	l.subscribers[id] = fn
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.subscribers, id)
+	}
+}
+
+/*
+Watch 는 설정 파일의 변경(수정시간, 크기)을 주기적으로 확인하고
+바뀌면 Load 를 다시 해서 구독자에게 알린다. ctx 가 취소될 때까지 돌아오지 않는다.
+변경 여부는 마지막 Load 가 읽은 파일 상태와 비교한다.
+
+	go loader.Watch(ctx)
+*/
+func (l *Loader[T]) Watch(ctx context.Context) error {
+	if l.opts.file == "" {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	loaded := l.loaded
+	l.mu.Unlock()
+
+	last, _ := stat(l.opts.file)
+	if loaded != nil {
+		last = *loaded
+	}
+
+	ticker := time.NewTicker(l.opts.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// 파일이 없어지면 zero 상태가 되므로, 없어진 순간 한번만 다시 읽는다
+			cur, _ := stat(l.opts.file)
+			if cur == last {
+				continue
+			}
+
+			last = cur
+			cfg, loadErr := l.Load()
+			l.notify(cfg, loadErr)
+		}
+	}
+}
+
+func (l *Loader[T]) notify(cfg T, err error) {
+	l.mu.Lock()
+	subs := make([]func(T, error), 0, len(l.subscribers))
+	for _, fn := range l.subscribers {
+		subs = append(subs, fn)
+	}
+	l.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(cfg, err)
+	}
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func stat(path string) (fileState, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileState{}, err
+	}
+
+	return fileState{modTime: fi.ModTime(), size: fi.Size()}, nil
+}
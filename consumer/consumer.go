@@ -0,0 +1,262 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/*
+19.go 의 consumeMessages (receiveMessage -> storageMessage 무한 loop) 를 실제로 쓸 수 있게 만든 message 소비기다.
+
+	src, _ := consumer.TailFile("app.log")
+	c := consumer.New(src, consumer.SinkFunc(func(ctx context.Context, msg []byte) error {
+		return store(ctx, msg)
+	}), consumer.WithWorkers(4))
+
+	err := c.Run(ctx) // ctx 가 취소되면 받은 message 를 모두 처리하고 돌아온다
+
+- Source 가 돌려준 payload 는 Pool 의 buffer 로 복사해서 넘긴다 (source 의 큰 buffer 를 붙잡지 않는다)
+- Pool 의 buffer 를 모두 쓰고 있으면 Source 를 더 읽지 않는다 (backpressure)
+- ctx 가 취소되면 더 읽지 않고, 이미 받은 message 는 WithDrainTimeout 동안 마저 처리한다 (graceful drain)
+- Sink 에 넘긴 msg 는 Consume 이 돌아오면 다시 쓰이므로 붙잡으려면 복사해야 한다
+*/
+
+var (
+	// ErrTooLong 은 Source 가 최대 길이를 넘는 message 를 건너뛰었을 때 돌려준다. Run 은 멈추지 않고 Dropped 로 센다.
+	ErrTooLong = errors.New("consumer: message too long")
+	// ErrTooLarge 는 payload 가 Pool 의 buffer 보다 클 때 error handler 에 넘긴다
+	ErrTooLarge = errors.New("consumer: message larger than buffer")
+	// ErrClosed 는 닫힌 Source 에 Publish 할 때 돌려준다
+	ErrClosed = errors.New("consumer: source closed")
+
+	ErrAlreadyRunning = errors.New("consumer: already running")
+)
+
+// Source 는 message 를 하나씩 읽는다
+type Source interface {
+	// Next 는 다음 message 를 돌려준다. 돌려준 slice 는 다음 Next 호출 전까지만 유효하다.
+	// 더 읽을 것이 없으면 io.EOF 를 돌려준다.
+	Next(ctx context.Context) ([]byte, error)
+	Close() error
+}
+
+// Sink 는 message 를 저장하거나 처리한다
+type Sink interface {
+	// Consume 이 돌아오면 msg 의 buffer 는 다른 message 에 다시 쓰인다
+	Consume(ctx context.Context, msg []byte) error
+}
+
+// SinkFunc 는 함수를 Sink 로 쓴다
+type SinkFunc func(ctx context.Context, msg []byte) error
+
+func (f SinkFunc) Consume(ctx context.Context, msg []byte) error {
+	return f(ctx, msg)
+}
+
+// Stats 는 Run 이 처리한 message 수다
+type Stats struct {
+	Received uint64 // Source 에서 받은 수
+	Consumed uint64 // Sink 가 성공한 수
+	Failed   uint64 // Sink 가 에러를 돌려준 수
+	Dropped  uint64 // 너무 길어서 버린 수
+}
+
+type options struct {
+	workers      int
+	buffers      int
+	bufferSize   int
+	drainTimeout time.Duration
+	onError      func(msg []byte, err error)
+}
+
+type Option func(*options)
+
+// WithWorkers 는 Sink 를 동시에 호출하는 goroutine 수다 (기본 1, 1 이면 순서를 지킨다)
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// WithBuffers 는 Pool 의 buffer 수 (동시에 처리 중인 message 의 최대 수) 와 buffer 하나의 크기다.
+// 기본은 64 개, 64 KiB 다.
+func WithBuffers(count, size int) Option {
+	return func(o *options) {
+		o.buffers, o.bufferSize = count, size
+	}
+}
+
+// WithDrainTimeout 은 ctx 가 취소된 뒤 남은 message 를 처리할 시간이다 (기본 5초).
+// 시간이 지나면 Sink 에 넘긴 ctx 를 취소한다.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.drainTimeout = d
+	}
+}
+
+// WithErrorHandler 는 Sink 가 실패하거나 message 를 버릴 때 호출된다 (버린 message 는 msg 가 nil 이다)
+func WithErrorHandler(fn func(msg []byte, err error)) Option {
+	return func(o *options) {
+		o.onError = fn
+	}
+}
+
+type Consumer struct {
+	src  Source
+	sink Sink
+	opts options
+	pool *Pool
+
+	running atomic.Bool
+
+	received atomic.Uint64
+	consumed atomic.Uint64
+	failed   atomic.Uint64
+	dropped  atomic.Uint64
+}
+
+// New 는 src 에서 읽어서 sink 로 넘기는 Consumer 를 만든다. src 는 호출하는 쪽이 닫는다.
+func New(src Source, sink Sink, opts ...Option) *Consumer {
+	o := options{
+		workers:      1,
+		buffers:      64,
+		bufferSize:   64 << 10,
+		drainTimeout: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	o.workers = max(o.workers, 1)
+	o.buffers = max(o.buffers, 1)
+
+	return &Consumer{
+		src:  src,
+		sink: sink,
+		opts: o,
+		pool: NewPool(o.buffers, o.bufferSize),
+	}
+}
+
+func (c *Consumer) Stats() Stats {
+	return Stats{
+		Received: c.received.Load(),
+		Consumed: c.consumed.Load(),
+		Failed:   c.failed.Load(),
+		Dropped:  c.dropped.Load(),
+	}
+}
+
+/*
+Run 은 Source 가 io.EOF 를 돌려주거나 ctx 가 취소될 때까지 message 를 처리한다.
+
+  - io.EOF 이면 남은 message 를 모두 처리하고 nil 을 돌려준다
+  - ctx 가 취소되면 남은 message 를 drain timeout 동안 처리하고 ctx.Err() 를 돌려준다
+  - Source 가 다른 에러를 돌려주면 남은 message 를 처리하고 그 에러를 돌려준다
+*/
+func (c *Consumer) Run(ctx context.Context) error {
+	if !c.running.CompareAndSwap(false, true) {
+		return ErrAlreadyRunning
+	}
+	defer c.running.Store(false)
+
+	// Sink 는 ctx 가 아니라 drain 이 끝날 때 취소되는 ctx 를 받는다
+	sinkCtx, cancelSink := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelSink()
+
+	queue := make(chan []byte, c.opts.buffers)
+
+	var wg sync.WaitGroup
+	for range c.opts.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range queue {
+				c.consume(sinkCtx, msg)
+				c.pool.Put(msg)
+			}
+		}()
+	}
+
+	err := c.read(ctx, queue)
+	close(queue)
+
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+
+	if ctx.Err() == nil {
+		<-drained
+		return err
+	}
+
+	timer := time.NewTimer(c.opts.drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-drained:
+	case <-timer.C:
+		cancelSink()
+		<-drained
+	}
+
+	return err
+}
+
+// read 는 Source 에서 읽은 payload 를 Pool 의 buffer 로 복사해서 queue 에 넣는다
+func (c *Consumer) read(ctx context.Context, queue chan<- []byte) error {
+	for {
+		payload, err := c.src.Next(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err == io.EOF:
+			return nil
+		case errors.Is(err, ErrTooLong):
+			c.drop(err)
+			continue
+		case err != nil:
+			return fmt.Errorf("consumer: source: %w", err)
+		}
+
+		c.received.Add(1)
+		if len(payload) > c.pool.Size() {
+			c.drop(fmt.Errorf("%w: %d > %d bytes", ErrTooLarge, len(payload), c.pool.Size()))
+			continue
+		}
+
+		buf, err := c.pool.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		// queue 의 용량이 buffer 수와 같으므로 막히지 않는다
+		queue <- append(buf, payload...)
+	}
+}
+
+func (c *Consumer) consume(ctx context.Context, msg []byte) {
+	if err := c.sink.Consume(ctx, msg); err != nil {
+		c.failed.Add(1)
+		if c.opts.onError != nil {
+			c.opts.onError(msg, err)
+		}
+		return
+	}
+
+	c.consumed.Add(1)
+}
+
+func (c *Consumer) drop(err error) {
+	c.dropped.Add(1)
+	if c.opts.onError != nil {
+		c.opts.onError(nil, err)
+	}
+}
@@ -0,0 +1,320 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// genSource 는 하나의 큰 buffer 를 다시 쓰면서 n 개의 message 를 만든다 (receiveMessage 처럼)
+type genSource struct {
+	buf   []byte
+	size  int
+	n     int
+	calls atomic.Int64
+}
+
+func newGenSource(n, size int) *genSource {
+	return &genSource{buf: make([]byte, 1<<20), size: size, n: n}
+}
+
+func (g *genSource) Next(ctx context.Context) ([]byte, error) {
+	i := g.calls.Add(1)
+	if int(i) > g.n {
+		return nil, io.EOF
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	g.buf[0] = byte(i)
+	return g.buf[:g.size], nil
+}
+
+func (g *genSource) Close() error { return nil }
+
+func heapStats() runtime.MemStats {
+	runtime.GC()
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m
+}
+
+/*
+수백만 개의 message 를 처리해도 heap 이 늘지 않는다.
+source 의 1 MiB buffer 를 붙잡지 않고, Pool 의 buffer 를 다시 쓰므로 message 당 할당도 거의 없다.
+*/
+func TestMemoryStaysFlat(t *testing.T) {
+	total := 2_000_000
+	if testing.Short() {
+		total = 200_000
+	}
+	const warmup = 10_000
+
+	var (
+		consumed atomic.Int64
+		sum      atomic.Uint64
+		before   runtime.MemStats
+		once     sync.Once
+	)
+
+	src := newGenSource(total, 512)
+	c := New(src, SinkFunc(func(ctx context.Context, msg []byte) error {
+		// buffer 를 모두 만든 뒤의 heap 을 기준으로 삼는다
+		if consumed.Add(1) == warmup {
+			once.Do(func() { before = heapStats() })
+		}
+		sum.Add(uint64(msg[0]))
+		return nil
+	}), WithWorkers(4), WithBuffers(32, 4<<10))
+
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	after := heapStats()
+
+	stats := c.Stats()
+	if stats.Consumed != uint64(total) || stats.Received != uint64(total) {
+		t.Fatalf("stats = %+v, want %d consumed", stats, total)
+	}
+
+	grown := int64(after.HeapAlloc) - int64(before.HeapAlloc)
+	mallocs := float64(after.Mallocs-before.Mallocs) / float64(total-warmup)
+	t.Logf("%d messages: heap %d KiB -> %d KiB, %.4f allocs/message", total, before.HeapAlloc>>10, after.HeapAlloc>>10, mallocs)
+
+	if grown > 1<<20 {
+		t.Errorf("heap grew by %d bytes over %d messages, want flat", grown, total)
+	}
+	if mallocs > 0.01 {
+		t.Errorf("%.4f allocs per message, want buffers to be reused", mallocs)
+	}
+}
+
+func TestOrderWithOneWorker(t *testing.T) {
+	src := NewMemorySource(8)
+	go func() {
+		defer src.Close()
+		for i := range 100 {
+			if err := src.Publish(context.Background(), fmt.Appendf(nil, "msg-%d", i)); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+
+	var got []string
+	c := New(src, SinkFunc(func(ctx context.Context, msg []byte) error {
+		got = append(got, string(msg))
+		return nil
+	}))
+
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 100 {
+		t.Fatalf("got %d messages, want 100", len(got))
+	}
+	for i, m := range got {
+		if want := fmt.Sprintf("msg-%d", i); m != want {
+			t.Fatalf("got[%d] = %q, want %q", i, m, want)
+		}
+	}
+}
+
+// Sink 가 막히면 buffer 수 + 1 (Pool 을 기다리는 것) 보다 많이 읽지 않는다
+func TestBackpressure(t *testing.T) {
+	const buffers = 4
+
+	release := make(chan struct{})
+	src := newGenSource(1000, 16)
+	c := New(src, SinkFunc(func(ctx context.Context, msg []byte) error {
+		<-release
+		return nil
+	}), WithBuffers(buffers, 16))
+
+	done := make(chan error, 1)
+	go func() { done <- c.Run(context.Background()) }()
+
+	deadline := time.Now().Add(time.Second)
+	for src.calls.Load() < buffers+1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if calls := src.calls.Load(); calls != buffers+1 {
+		t.Errorf("source read %d messages while the sink was blocked, want %d", calls, buffers+1)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Stats().Consumed; got != 1000 {
+		t.Errorf("consumed %d, want 1000", got)
+	}
+}
+
+// ctx 가 취소되면 이미 받은 message 는 마저 처리한다
+func TestGracefulDrain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	started := make(chan struct{})
+	var consumed atomic.Int64
+	src := NewMemorySource(16)
+	c := New(src, SinkFunc(func(sinkCtx context.Context, msg []byte) error {
+		if consumed.Add(1) == 1 {
+			close(started)
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+		}
+		if sinkCtx.Err() != nil {
+			return sinkCtx.Err()
+		}
+		return nil
+	}), WithBuffers(8, 16))
+
+	for i := range 8 {
+		if err := src.Publish(ctx, []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	<-started
+	// Pool 이 8 개 모두 찰 때까지 기다린다
+	for c.Stats().Received < 8 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run = %v, want context.Canceled", err)
+	}
+	if stats := c.Stats(); stats.Consumed != 8 || stats.Failed != 0 {
+		t.Errorf("stats = %+v, want all 8 drained", stats)
+	}
+}
+
+// drain timeout 이 지나면 Sink 의 ctx 를 취소한다
+func TestDrainTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	src := NewMemorySource(4)
+	src.Publish(ctx, []byte("stuck"))
+
+	started := make(chan struct{})
+	c := New(src, SinkFunc(func(sinkCtx context.Context, msg []byte) error {
+		close(started)
+		<-sinkCtx.Done()
+		return sinkCtx.Err()
+	}), WithDrainTimeout(20*time.Millisecond))
+
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Run = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the drain timeout")
+	}
+
+	if stats := c.Stats(); stats.Failed != 1 {
+		t.Errorf("stats = %+v, want the stuck message to fail", stats)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	src := NewMemorySource(4)
+	src.Publish(context.Background(), []byte("ok"))
+	src.Publish(context.Background(), make([]byte, 100))
+	src.Publish(context.Background(), []byte("fail"))
+	src.Close()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	sinkErr := errors.New("sink failed")
+	c := New(src, SinkFunc(func(ctx context.Context, msg []byte) error {
+		if string(msg) == "fail" {
+			return sinkErr
+		}
+		return nil
+	}), WithBuffers(2, 10), WithErrorHandler(func(msg []byte, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}))
+
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Stats{Received: 3, Consumed: 1, Failed: 1, Dropped: 1}
+	if got := c.Stats(); got != want {
+		t.Errorf("stats = %+v, want %+v", got, want)
+	}
+	if len(errs) != 2 || !errors.Is(errs[0], ErrTooLarge) || !errors.Is(errs[1], sinkErr) {
+		t.Errorf("errors = %v, want [ErrTooLarge, sink failed]", errs)
+	}
+
+	if err := c.Run(context.Background()); err != nil {
+		t.Errorf("second Run on a drained source = %v, want nil", err)
+	}
+}
+
+type failingSource struct{ err error }
+
+func (s failingSource) Next(context.Context) ([]byte, error) { return nil, s.err }
+func (s failingSource) Close() error                         { return nil }
+
+func TestSourceError(t *testing.T) {
+	boom := errors.New("boom")
+	c := New(failingSource{err: boom}, SinkFunc(func(context.Context, []byte) error { return nil }))
+
+	if err := c.Run(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("Run = %v, want boom", err)
+	}
+}
+
+func TestPool(t *testing.T) {
+	p := NewPool(2, 8)
+	ctx := context.Background()
+
+	a, _ := p.Get(ctx)
+	b, _ := p.Get(ctx)
+	if cap(a) != 8 || len(a) != 0 {
+		t.Fatalf("buffer len %d cap %d, want 0, 8", len(a), cap(a))
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.Get(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get on an empty pool = %v, want DeadlineExceeded", err)
+	}
+
+	a = append(a, "reused"...)
+	p.Put(a)
+	c, _ := p.Get(ctx)
+	if &c[:1][0] != &a[:1][0] {
+		t.Error("Get did not reuse the returned buffer")
+	}
+	p.Put(b)
+	p.Put(c)
+}
@@ -0,0 +1,120 @@
+package consumer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+/*
+FileSource 는 파일을 처음부터 한 줄씩 읽고, 끝에 다다르면 tail -F 처럼 새 줄이 붙기를 기다린다.
+
+	src, err := consumer.TailFile("/var/log/app.log", consumer.WithPollInterval(time.Second))
+
+- 파일이 잘리면 (크기가 읽은 위치보다 작아지면) 처음부터 다시 읽는다
+- 같은 경로에 새 파일이 생기면 (log rotate) 새 파일을 처음부터 읽는다
+- 줄바꿈 없이 끝난 마지막 줄은 줄바꿈이 붙을 때까지 기다린다
+*/
+type FileSource struct {
+	path string
+	opts lineOptions
+
+	f      *os.File
+	info   os.FileInfo
+	offset *countingReader
+	lines  *lineReader
+}
+
+func TailFile(path string, opts ...LineOption) (*FileSource, error) {
+	s := &FileSource{path: path, opts: newLineOptions(opts)}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileSource) open() error {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("consumer: tail %s: %w", s.path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("consumer: tail %s: %w", s.path, err)
+	}
+
+	if s.f != nil {
+		s.f.Close()
+	}
+
+	s.f, s.info = f, info
+	s.offset = &countingReader{r: f}
+	s.lines = newLineReader(bufio.NewReaderSize(s.offset, s.opts.maxLine+2), s.opts.maxLine)
+	return nil
+}
+
+func (s *FileSource) Next(ctx context.Context) ([]byte, error) {
+	for {
+		line, err := s.lines.next()
+		if err != io.EOF {
+			return line, err
+		}
+
+		if err := s.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wait 는 poll 간격만큼 기다린 뒤 파일이 잘리거나 바뀌었는지 확인한다
+func (s *FileSource) wait(ctx context.Context) error {
+	timer := time.NewTimer(s.opts.poll)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		// rotate 중에는 잠깐 파일이 없을 수 있다
+		return nil
+	}
+
+	switch {
+	case !os.SameFile(info, s.info):
+		return s.open()
+	case info.Size() < s.offset.n:
+		if _, err := s.f.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("consumer: tail %s: %w", s.path, err)
+		}
+		s.offset = &countingReader{r: s.f}
+		s.lines = newLineReader(bufio.NewReaderSize(s.offset, s.opts.maxLine+2), s.opts.maxLine)
+	}
+
+	return nil
+}
+
+func (s *FileSource) Close() error {
+	return s.f.Close()
+}
+
+// countingReader 는 파일에서 읽은 byte 수를 센다 (잘림을 알아채는 데 쓴다)
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,117 @@
+package consumer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"time"
+)
+
+type lineOptions struct {
+	maxLine int
+	poll    time.Duration
+}
+
+// LineOption 은 줄 단위로 읽는 Source (TailFile, ListenTCP) 의 설정이다
+type LineOption func(*lineOptions)
+
+// WithMaxLineSize 는 한 줄의 최대 길이다 (기본 64 KiB). 넘는 줄은 ErrTooLong 과 함께 건너뛴다.
+func WithMaxLineSize(n int) LineOption {
+	return func(o *lineOptions) {
+		o.maxLine = n
+	}
+}
+
+// WithPollInterval 은 TailFile 이 파일 끝에서 새 줄을 기다리며 확인하는 간격이다 (기본 100ms)
+func WithPollInterval(d time.Duration) LineOption {
+	return func(o *lineOptions) {
+		o.poll = d
+	}
+}
+
+func newLineOptions(opts []LineOption) lineOptions {
+	o := lineOptions{maxLine: 64 << 10, poll: 100 * time.Millisecond}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	o.maxLine = max(o.maxLine, 16)
+	return o
+}
+
+/*
+lineReader 는 bufio.Reader 위에서 줄을 읽는다. 끝의 "\n", "\r\n" 은 뺀다.
+
+  - 돌려준 줄은 다음 호출 전까지만 유효하다 (bufio.Reader 나 partial 을 다시 쓴다)
+  - maxLine 을 넘는 줄은 끝까지 버리고 ErrTooLong 을 돌려준다
+  - 줄 중간에 io.EOF 가 나면 읽은 부분을 partial 에 남긴다 (TailFile 이 이어서 읽는다)
+*/
+type lineReader struct {
+	r       *bufio.Reader
+	maxLine int
+
+	partial    []byte
+	discarding bool
+}
+
+func newLineReader(r *bufio.Reader, maxLine int) *lineReader {
+	return &lineReader{r: r, maxLine: maxLine}
+}
+
+func (l *lineReader) next() ([]byte, error) {
+	for {
+		chunk, err := l.r.ReadSlice('\n')
+		switch {
+		case errors.Is(err, bufio.ErrBufferFull):
+			l.keep(chunk)
+			continue
+		case err != nil:
+			// io.EOF 등: 읽은 부분은 다음 호출에서 이어붙인다
+			l.keep(chunk)
+			return nil, err
+		}
+
+		line, discarded := chunk, false
+		if len(l.partial) > 0 || l.discarding {
+			l.keep(chunk)
+			line, discarded = l.partial, l.discarding
+		}
+		l.partial, l.discarding = l.partial[:0], false
+
+		line = trimLine(line)
+		if discarded || len(line) > l.maxLine {
+			return nil, ErrTooLong
+		}
+		return line, nil
+	}
+}
+
+// keep 은 아직 끝나지 않은 줄을 모은다. maxLine 을 넘으면 줄 끝까지 버린다.
+func (l *lineReader) keep(chunk []byte) {
+	if l.discarding {
+		return
+	}
+	if len(l.partial)+len(chunk) > l.maxLine+2 {
+		l.discarding = true
+		l.partial = l.partial[:0]
+		return
+	}
+
+	l.partial = append(l.partial, chunk...)
+}
+
+// rest 는 줄바꿈 없이 끝난 마지막 줄이다 (TCP 연결이 끊긴 경우)
+func (l *lineReader) rest() []byte {
+	if l.discarding || len(l.partial) == 0 {
+		return nil
+	}
+
+	line := l.partial
+	l.partial = l.partial[:0]
+	return trimLine(line)
+}
+
+func trimLine(b []byte) []byte {
+	b = bytes.TrimSuffix(b, []byte("\n"))
+	return bytes.TrimSuffix(b, []byte("\r"))
+}
@@ -0,0 +1,75 @@
+package consumer
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+/*
+MemorySource 는 같은 프로세스 안에서 Publish 한 message 를 읽는 Source 다.
+용량만큼 쌓이면 Publish 가 기다린다.
+
+	src := consumer.NewMemorySource(128)
+	go func() {
+		defer src.Close()
+		for _, m := range msgs {
+			src.Publish(ctx, m)
+		}
+	}()
+*/
+type MemorySource struct {
+	ch chan []byte
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func NewMemorySource(capacity int) *MemorySource {
+	return &MemorySource{
+		ch:     make(chan []byte, capacity),
+		closed: make(chan struct{}),
+	}
+}
+
+// Publish 는 msg 를 넣는다. msg 는 Consumer 가 복사할 때까지 바꾸면 안된다.
+func (s *MemorySource) Publish(ctx context.Context, msg []byte) error {
+	select {
+	case <-s.closed:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case s.ch <- msg:
+		return nil
+	case <-s.closed:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Next 는 Close 뒤에도 남은 message 를 모두 돌려준 다음 io.EOF 를 돌려준다
+func (s *MemorySource) Next(ctx context.Context) ([]byte, error) {
+	select {
+	case msg := <-s.ch:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.closed:
+	}
+
+	select {
+	case msg := <-s.ch:
+		return msg, nil
+	default:
+		return nil, io.EOF
+	}
+}
+
+// Close 는 더 이상 Publish 하지 않는다고 알린다
+func (s *MemorySource) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
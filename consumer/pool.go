@@ -0,0 +1,56 @@
+package consumer
+
+import "context"
+
+/*
+Pool 은 크기가 정해진 buffer 를 최대 count 개까지 빌려준다.
+모두 빌려갔으면 Get 이 기다리므로, 처리 중인 message 수가 count 를 넘지 않는다 (backpressure).
+
+	buf, err := pool.Get(ctx)
+	buf = append(buf, payload...) // source 의 buffer 에서 복사한다
+	...
+	pool.Put(buf)
+
+source 가 읽은 큰 buffer 를 그대로 넘기지 않고 복사하므로 19.go 처럼 backing array 를 붙잡지 않는다.
+*/
+type Pool struct {
+	size int
+	sem  chan struct{}
+	free chan []byte
+}
+
+// NewPool 은 cap 이 size 인 buffer 를 최대 count 개 빌려주는 pool 이다. buffer 는 처음 필요할 때 만든다.
+func NewPool(count, size int) *Pool {
+	return &Pool{
+		size: size,
+		sem:  make(chan struct{}, count),
+		free: make(chan []byte, count),
+	}
+}
+
+// Size 는 buffer 하나의 용량이다
+func (p *Pool) Size() int {
+	return p.size
+}
+
+// Get 은 길이 0, 용량 Size 인 buffer 를 빌린다. 남은 buffer 가 없으면 Put 이나 ctx 취소까지 기다린다.
+func (p *Pool) Get(ctx context.Context) ([]byte, error) {
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case b := <-p.free:
+		return b[:0], nil
+	default:
+		return make([]byte, 0, p.size), nil
+	}
+}
+
+// Put 은 Get 으로 빌린 buffer 를 돌려준다. 돌려준 뒤에는 buffer 를 쓰면 안된다.
+func (p *Pool) Put(b []byte) {
+	p.free <- b[:0]
+	<-p.sem
+}
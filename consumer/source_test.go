@@ -0,0 +1,233 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collect 는 Source 에서 n 개의 message 를 복사해서 읽는다
+func collect(t *testing.T, src Source, n int) []string {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	for len(got) < n {
+		msg, err := src.Next(ctx)
+		if errors.Is(err, ErrTooLong) {
+			got = append(got, "<too long>")
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Next after %v: %v", got, err)
+		}
+		got = append(got, string(msg))
+	}
+
+	return got
+}
+
+func TestMemorySource(t *testing.T) {
+	src := NewMemorySource(2)
+	ctx := context.Background()
+
+	src.Publish(ctx, []byte("a"))
+	src.Publish(ctx, []byte("b"))
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := src.Publish(timeout, []byte("c")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Publish on a full source = %v, want DeadlineExceeded", err)
+	}
+
+	src.Close()
+	if err := src.Publish(ctx, []byte("c")); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Publish after Close = %v, want ErrClosed", err)
+	}
+
+	if got := collect(t, src, 2); !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("got %q, want [a b]", got)
+	}
+	if _, err := src.Next(ctx); err != io.EOF {
+		t.Fatalf("Next after drain = %v, want io.EOF", err)
+	}
+}
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTailFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "one\r\ntwo\n")
+
+	src, err := TailFile(path, WithPollInterval(5*time.Millisecond), WithMaxLineSize(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	if got := collect(t, src, 2); !slices.Equal(got, []string{"one", "two"}) {
+		t.Fatalf("got %q, want [one two]", got)
+	}
+
+	// 새 줄을 기다리고, 나눠서 쓴 줄은 이어붙인다
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		appendFile(t, path, "thr")
+		time.Sleep(20 * time.Millisecond)
+		appendFile(t, path, "ee\n"+strings.Repeat("x", 40)+"\nfour\n")
+	}()
+
+	if got := collect(t, src, 3); !slices.Equal(got, []string{"three", "<too long>", "four"}) {
+		t.Fatalf("got %q, want [three <too long> four]", got)
+	}
+
+	// 잘리면 처음부터 읽는다
+	if err := os.WriteFile(path, []byte("five\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := collect(t, src, 1); !slices.Equal(got, []string{"five"}) {
+		t.Fatalf("after truncate got %q, want [five]", got)
+	}
+
+	// rotate: 새 파일로 바뀌면 새 파일을 읽는다
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "six\n")
+	if got := collect(t, src, 1); !slices.Equal(got, []string{"six"}) {
+		t.Fatalf("after rotate got %q, want [six]", got)
+	}
+}
+
+func TestTailFileCancel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "")
+
+	src, err := TailFile(path, WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := src.Next(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Next = %v, want DeadlineExceeded", err)
+	}
+
+	if _, err := TailFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("TailFile on a missing file succeeded")
+	}
+}
+
+func TestTCPSource(t *testing.T) {
+	src, err := ListenTCP("127.0.0.1:0", WithMaxLineSize(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			conn, err := net.Dial("tcp", src.Addr().String())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer conn.Close()
+
+			fmt.Fprintf(conn, "c%d-1\nc%d-2\r\nc%d-3", i, i, i)
+		}()
+	}
+
+	got := collect(t, src, 9)
+	sort.Strings(got)
+	want := []string{"c0-1", "c0-2", "c0-3", "c1-1", "c1-2", "c1-3", "c2-1", "c2-2", "c2-3"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	wg.Wait()
+
+	if err := src.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.Next(context.Background()); err != io.EOF {
+		t.Fatalf("Next after Close = %v, want io.EOF", err)
+	}
+}
+
+// 19.go 의 consumeMessages 를 TCP -> Consumer -> Sink 로 잇는다
+func TestTCPConsumer(t *testing.T) {
+	src, err := ListenTCP("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		storage [][]byte
+	)
+	c := New(src, SinkFunc(func(ctx context.Context, msg []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		// msg 는 다시 쓰이므로 저장하려면 복사한다
+		storage = append(storage, slices.Clone(msg))
+		if len(storage) == 100 {
+			cancel()
+		}
+		return nil
+	}), WithWorkers(2))
+
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	conn, err := net.Dial("tcp", src.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 100 {
+		fmt.Fprintf(conn, "message %d\n", i)
+	}
+	conn.Close()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run = %v, want context.Canceled", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(storage) != 100 || !strings.HasPrefix(string(storage[0]), "message ") {
+		t.Fatalf("stored %d messages (%q...), want 100", len(storage), storage[:min(len(storage), 1)])
+	}
+}
@@ -0,0 +1,186 @@
+package consumer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+/*
+TCPSource 는 TCP 연결에서 줄 단위 (한 줄이 message 하나) 로 message 를 받는다.
+
+	src, err := consumer.ListenTCP("127.0.0.1:9000")
+	// $ printf 'hello\nworld\n' | nc 127.0.0.1 9000
+
+연결마다 goroutine 이 줄을 읽고, Next 가 그 줄을 가져간 뒤 다음 Next 를 부를 때까지 기다린다.
+그래서 Consumer 가 밀리면 연결을 더 읽지 않고, TCP 흐름 제어로 보내는 쪽도 느려진다 (backpressure).
+*/
+type TCPSource struct {
+	ln   net.Listener
+	opts lineOptions
+
+	lines   chan tcpLine
+	pending chan struct{} // 마지막으로 Next 가 돌려준 줄을 읽은 연결에 다 썼다고 알린다
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	wg        sync.WaitGroup
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+	err   error
+}
+
+type tcpLine struct {
+	data []byte
+	err  error
+	done chan struct{}
+}
+
+func ListenTCP(addr string, opts ...LineOption) (*TCPSource, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("consumer: listen %s: %w", addr, err)
+	}
+
+	return NewTCPSource(ln, opts...), nil
+}
+
+// NewTCPSource 는 ln 으로 들어오는 연결을 읽는다. Close 가 ln 도 닫는다.
+func NewTCPSource(ln net.Listener, opts ...LineOption) *TCPSource {
+	s := &TCPSource{
+		ln:     ln,
+		opts:   newLineOptions(opts),
+		lines:  make(chan tcpLine),
+		closed: make(chan struct{}),
+		conns:  map[net.Conn]struct{}{},
+	}
+
+	s.wg.Add(1)
+	go s.accept()
+
+	return s
+}
+
+func (s *TCPSource) Addr() net.Addr {
+	return s.ln.Addr()
+}
+
+func (s *TCPSource) accept() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			select {
+			case <-s.closed:
+			default:
+				s.mu.Lock()
+				s.err = err
+				s.mu.Unlock()
+				s.shutdown()
+			}
+			return
+		}
+
+		// Close 가 연결을 닫은 뒤에 들어온 연결은 바로 닫는다
+		s.mu.Lock()
+		select {
+		case <-s.closed:
+			s.mu.Unlock()
+			conn.Close()
+			return
+		default:
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.read(conn)
+	}
+}
+
+func (s *TCPSource) read(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	lines := newLineReader(bufio.NewReaderSize(conn, s.opts.maxLine+2), s.opts.maxLine)
+	done := make(chan struct{}, 1)
+
+	for {
+		data, err := lines.next()
+		if err != nil && !errors.Is(err, ErrTooLong) {
+			// 연결이 끊기면 줄바꿈 없는 마지막 줄까지 넘긴다
+			data, err = lines.rest(), nil
+			if data == nil {
+				return
+			}
+		}
+
+		select {
+		case s.lines <- tcpLine{data: data, err: err, done: done}:
+		case <-s.closed:
+			return
+		}
+
+		select {
+		case <-done:
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+func (s *TCPSource) Next(ctx context.Context) ([]byte, error) {
+	if s.pending != nil {
+		s.pending <- struct{}{}
+		s.pending = nil
+	}
+
+	select {
+	case l := <-s.lines:
+		s.pending = l.done
+		return l.data, l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.closed:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+}
+
+// Close 는 listener 와 모든 연결을 닫는다. 이후 Next 는 io.EOF 를 돌려준다.
+func (s *TCPSource) Close() error {
+	err := s.shutdown()
+	s.wg.Wait()
+	return err
+}
+
+func (s *TCPSource) shutdown() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		err = s.ln.Close()
+
+		s.mu.Lock()
+		for conn := range s.conns {
+			conn.Close()
+		}
+		s.mu.Unlock()
+	})
+
+	return err
+}
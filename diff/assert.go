@@ -0,0 +1,28 @@
+package diff
+
+import "reflect"
+
+// TestingT 는 Assert 가 쓰는 *testing.T 의 메서드다
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+/*
+Assert 는 want 와 got 이 다르면 차이를 적고 테스트를 실패시킨다.
+
+	diff.Assert(t, want, got, diff.NilEqualsEmpty())
+	// main.customer mismatch (want != got):
+	// op[1]: 2 != 3
+*/
+func Assert[T any](t TestingT, want, got T, opts ...Option) bool {
+	t.Helper()
+
+	d := Compare(want, got, opts...)
+	if d.Equal() {
+		return true
+	}
+
+	t.Errorf("%s mismatch (want != got):\n%s", reflect.TypeFor[T](), d)
+	return false
+}
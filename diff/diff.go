@@ -0,0 +1,101 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+/*
+20.go 의 customer.equals, GoodEquals (reflect.DeepEqual) 는 false 만 돌려주고 어디가 다른지는 알려주지 않는다.
+같은 타입의 두 값을 따라가면서 다른 곳을 (경로, 왼쪽 값, 오른쪽 값) 목록으로 돌려준다.
+
+	d := diff.Compare(c1, c2, diff.FloatTolerance(1e-9))
+	fmt.Print(d)
+	// id: "x" != "y"
+	// op[1]: 2 != 3
+
+- 경로는 필드 이름, [index], [key] 로 이어진다 (Orders[0].Items[1].Qty, Attrs["age"]). pointer 는 경로에 나오지 않는다
+- slice 길이가 다르면 남는 원소마다 한쪽이 <missing> 인 차이가 된다. map 도 한쪽에만 있는 key 가 그렇다
+- unexported 필드도 비교한다
+- 다른 패키지의 타입이 Equal(T) bool 을 가지면 (time.Time 등) unexported 필드에 있어도 그 Equal 로 비교한다
+- func 는 둘 다 nil 일 때만 같다 (reflect.DeepEqual 과 같다)
+*/
+
+// Missing 은 slice, map 의 한쪽에만 있는 값의 자리를 나타낸다
+const Missing = "<missing>"
+
+// Difference 는 두 값이 다른 곳 하나다. Left, Right 는 값을 사람이 읽을 수 있게 쓴 것이다.
+type Difference struct {
+	Path  string `json:"path"`
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
+
+type Diffs []Difference
+
+// Compare 는 left 와 right 의 차이를 찾는다. 같으면 빈 Diffs 다.
+func Compare(left, right any, opts ...Option) Diffs {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	a, b := reflect.ValueOf(left), reflect.ValueOf(right)
+
+	w := &walker{
+		opts:    o,
+		home:    homePackage(a),
+		visited: map[visit]bool{},
+	}
+	w.compare(nil, a, b)
+
+	return w.diffs
+}
+
+// Equal 은 차이가 없는지 본다
+func (d Diffs) Equal() bool {
+	return len(d) == 0
+}
+
+// String 은 차이를 한 줄에 하나씩 "경로: 왼쪽 != 오른쪽" 으로 쓴다
+func (d Diffs) String() string {
+	var sb strings.Builder
+	for _, diff := range d {
+		path := diff.Path
+		if path == "" {
+			path = "(root)"
+		}
+		sb.WriteString(path + ": " + diff.Left + " != " + diff.Right + "\n")
+	}
+
+	return sb.String()
+}
+
+// MarshalJSON 은 차이가 없어도 null 이 아니라 [] 를 쓴다 (16.go)
+func (d Diffs) MarshalJSON() ([]byte, error) {
+	if len(d) == 0 {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal([]Difference(d))
+}
+
+// homePackage 는 비교하는 값의 타입이 선언된 패키지다. 이 패키지의 타입은 Equal 이 있어도 안으로 들어간다.
+func homePackage(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+
+	t := v.Type()
+	for t.Name() == "" {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return ""
+		}
+	}
+
+	return t.PkgPath()
+}
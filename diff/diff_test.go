@@ -0,0 +1,305 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 20.go 의 customer 와 같은 모양
+type customer struct {
+	id string
+	op []float64
+}
+
+type Address struct {
+	City string
+	Zip  *int
+}
+
+type Order struct {
+	ID    int
+	Price float64
+	Items []string
+	note  string
+}
+
+type Account struct {
+	Name    string
+	Address *Address
+	Orders  []Order
+	Attrs   map[string]int
+	Tags    []string
+	Created time.Time
+	Extra   any
+	Hook    func()
+}
+
+func TestCompareCustomer(t *testing.T) {
+	a := customer{id: "x", op: []float64{1, 2, 3}}
+	b := customer{id: "y", op: []float64{1, 5}}
+
+	d := Compare(a, b)
+	want := Diffs{
+		{Path: "id", Left: `"x"`, Right: `"y"`},
+		{Path: "op[1]", Left: "2", Right: "5"},
+		{Path: "op[2]", Left: "3", Right: Missing},
+	}
+	if !slices.Equal(d, want) {
+		t.Fatalf("Compare = %#v, want %#v", d, want)
+	}
+
+	wantText := "id: \"x\" != \"y\"\nop[1]: 2 != 5\nop[2]: 3 != <missing>\n"
+	if d.String() != wantText {
+		t.Errorf("String =\n%s\nwant\n%s", d, wantText)
+	}
+
+	if !Compare(a, customer{id: "x", op: []float64{1, 2, 3}}).Equal() {
+		t.Error("equal customers have differences")
+	}
+}
+
+// 필드가 모두 unexported 여도 time.Time 은 Equal 로 비교한다
+func TestCompareUnexportedTime(t *testing.T) {
+	type visit struct {
+		at   time.Time
+		seen map[string]time.Time
+	}
+
+	now := time.Now()
+	loc := time.FixedZone("KST", 9*60*60)
+
+	a := visit{at: now, seen: map[string]time.Time{"kim": now}}
+	b := visit{at: now.Round(0).In(loc), seen: map[string]time.Time{"kim": now.UTC()}}
+	if d := Compare(a, b); !d.Equal() {
+		t.Fatalf("same instants have differences:\n%s", d)
+	}
+
+	later := now.Add(time.Second)
+	d := Compare(a, visit{at: later, seen: a.seen})
+	want := Diffs{{Path: "at", Left: now.String(), Right: later.String()}}
+	if !slices.Equal(d, want) {
+		t.Errorf("Compare = %#v, want %#v", d, want)
+	}
+}
+
+func newAccount() Account {
+	zip := 12345
+	return Account{
+		Name:    "kim",
+		Address: &Address{City: "Seoul", Zip: &zip},
+		Orders:  []Order{{ID: 1, Price: 9.9, Items: []string{"apple"}, note: "gift"}},
+		Attrs:   map[string]int{"age": 30, "level": 2},
+		Tags:    []string{},
+		Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Extra:   1,
+	}
+}
+
+func TestCompareNested(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(a *Account)
+		want   Diffs
+	}{
+		{"same", func(a *Account) {}, nil},
+		{"pointer field", func(a *Account) { a.Address.City = "Busan" }, Diffs{{"Address.City", `"Seoul"`, `"Busan"`}}},
+		{"pointer to pointer", func(a *Account) { zip := 1; a.Address.Zip = &zip }, Diffs{{"Address.Zip", "12345", "1"}}},
+		{"nil pointer", func(a *Account) { a.Address = nil }, Diffs{{"Address", `&{City:"Seoul" Zip:&12345}`, "nil"}}},
+		{"slice of struct", func(a *Account) { a.Orders[0].Items[0] = "pear" }, Diffs{{"Orders[0].Items[0]", `"apple"`, `"pear"`}}},
+		{"unexported", func(a *Account) { a.Orders[0].note = "" }, Diffs{{"Orders[0].note", `"gift"`, `""`}}},
+		{"added element", func(a *Account) { a.Orders = append(a.Orders, Order{ID: 2}) }, Diffs{{"Orders[1]", Missing, `{ID:2 Price:0 Items:nil note:""}`}}},
+		{"map value", func(a *Account) { a.Attrs["age"] = 31 }, Diffs{{`Attrs["age"]`, "30", "31"}}},
+		{"map keys", func(a *Account) { delete(a.Attrs, "level"); a.Attrs["zone"] = 1 }, Diffs{
+			{`Attrs["level"]`, "2", Missing},
+			{`Attrs["zone"]`, Missing, "1"},
+		}},
+		{"nil slice", func(a *Account) { a.Tags = nil }, Diffs{{"Tags", "[]", "nil"}}},
+		{"interface type", func(a *Account) { a.Extra = "1" }, Diffs{{"Extra", "int(1)", `string("1")`}}},
+		{"interface nil", func(a *Account) { a.Extra = nil }, Diffs{{"Extra", "int(1)", "nil"}}},
+		{"func", func(a *Account) { a.Hook = func() {} }, nil},
+		// time.Time 은 Equal 로 비교한다 (위치가 달라도 같은 시각이면 같다)
+		{"time zone", func(a *Account) { a.Created = a.Created.In(time.FixedZone("KST", 9*60*60)) }, nil},
+		{"time", func(a *Account) { a.Created = a.Created.Add(time.Hour) }, Diffs{{"Created", "2024-01-02 03:04:05 +0000 UTC", "2024-01-02 04:04:05 +0000 UTC"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newAccount(), newAccount()
+			tt.change(&b)
+
+			got := Compare(a, b)
+			if tt.name == "func" {
+				// func 는 nil 이 아니면 다르다. 값은 주소라서 경로만 확인한다
+				if len(got) != 1 || got[0].Path != "Hook" {
+					t.Fatalf("Compare = %v, want a difference at Hook", got)
+				}
+				return
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Compare =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	a := newAccount()
+	b := newAccount()
+	b.Name = "lee"
+	b.Orders[0].note = ""
+	b.Orders[0].Price = 9.9 + 1e-12
+	b.Tags = nil
+	b.Attrs = map[string]int{}
+	a.Attrs = nil
+
+	got := Compare(a, b)
+	if len(got) != 5 {
+		t.Fatalf("without options got %d differences, want 5:\n%s", len(got), got)
+	}
+
+	got = Compare(a, b,
+		IgnoreFields("Name", "Orders[*].note"),
+		NilEqualsEmpty(),
+		FloatTolerance(1e-9),
+	)
+	if !got.Equal() {
+		t.Errorf("with options got differences:\n%s", got)
+	}
+
+	// 경로 일부만 맞으면 무시하지 않는다
+	if got := Compare(a, b, IgnoreFields("note", "*.note")); len(got) != 5 {
+		t.Errorf("IgnoreFields(note, *.note) ignored %d differences, want none:\n%s", 5-len(got), got)
+	}
+
+	// 무시한 경로 아래는 모두 무시한다
+	b.Address.City = "Busan"
+	if got := Compare(a, b, IgnoreFields("Address")); len(got) != 5 {
+		t.Errorf("IgnoreFields(Address) = \n%s", got)
+	}
+}
+
+func TestFloats(t *testing.T) {
+	nan := math.NaN()
+
+	if d := Compare([]float64{nan}, []float64{nan}); d.Equal() {
+		t.Error("NaN equals NaN without a policy (reflect.DeepEqual says they differ)")
+	}
+	if d := Compare(1.0, 1.05, FloatTolerance(0.1)); !d.Equal() {
+		t.Errorf("1.0 and 1.05 differ within 0.1: %s", d)
+	}
+	if d := Compare(complex(1, 1), complex(1, 1.5), FloatTolerance(0.1)); d.Equal() {
+		t.Error("complex values with different imaginary parts are equal")
+	}
+	if d := Compare(float32(1), float32(2)); !slices.Equal(d, Diffs{{"", "1", "2"}}) {
+		t.Errorf("Compare(float32) = %v", d)
+	}
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+func TestCycle(t *testing.T) {
+	a := &node{Value: 1}
+	a.Next = &node{Value: 2, Next: a}
+	b := &node{Value: 1}
+	b.Next = &node{Value: 3, Next: b}
+
+	want := Diffs{{"Next.Value", "2", "3"}}
+	if got := Compare(a, b); !slices.Equal(got, want) {
+		t.Errorf("Compare = %v, want %v", got, want)
+	}
+}
+
+func TestTypeMismatch(t *testing.T) {
+	want := Diffs{{"", "int(1)", "int64(1)"}}
+	if got := Compare(1, int64(1)); !slices.Equal(got, want) {
+		t.Errorf("Compare = %v, want %v", got, want)
+	}
+
+	if got := Compare(nil, nil); !got.Equal() {
+		t.Errorf("Compare(nil, nil) = %v", got)
+	}
+	if got := Compare(nil, 1); len(got) != 1 || got.String() != "(root): nil != int(1)\n" {
+		t.Errorf("Compare(nil, 1) = %q", got.String())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	d := Compare(customer{id: "x"}, customer{id: "y"})
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"path":"id","left":"\"x\"","right":"\"y\""}]`; string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+
+	data, err = json.Marshal(Compare(1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("json of no differences = %s, want []", data)
+	}
+}
+
+func TestSplitPath(t *testing.T) {
+	tests := map[string][]string{
+		"id":                  {"id"},
+		"Orders[0].Items[*]":  {"Orders", "[0]", "Items", "[*]"},
+		`Attrs["a.b]"].Value`: {"Attrs", `["a.b]"]`, "Value"},
+		`Attrs["q\"]"]`:       {"Attrs", `["q\"]"]`},
+		"*.UpdatedAt":         {"*", "UpdatedAt"},
+		"Matrix[1][2]":        {"Matrix", "[1]", "[2]"},
+	}
+
+	for path, want := range tests {
+		if got := splitPath(path); !slices.Equal(got, want) {
+			t.Errorf("splitPath(%q) = %q, want %q", path, got, want)
+		}
+		if got := joinPath(want); got != path {
+			t.Errorf("joinPath(%q) = %q, want %q", want, got, path)
+		}
+	}
+}
+
+// fakeT 는 Assert 가 남긴 메시지를 모은다
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssert(t *testing.T) {
+	ft := &fakeT{}
+
+	if !Assert(ft, customer{id: "x"}, customer{id: "x", op: []float64{}}, NilEqualsEmpty()) {
+		t.Errorf("Assert failed: %v", ft.errors)
+	}
+
+	if Assert(ft, customer{id: "x", op: []float64{1}}, customer{id: "x", op: []float64{2}}) {
+		t.Fatal("Assert passed on different customers")
+	}
+
+	want := "diff.customer mismatch (want != got):\nop[0]: 1 != 2\n"
+	if len(ft.errors) != 1 || ft.errors[0] != want {
+		t.Errorf("Assert reported %q, want %q", ft.errors, want)
+	}
+
+	if !strings.Contains(Compare(customer{}, customer{op: []float64{}}).String(), "op: nil != []") {
+		t.Error("nil and empty op are not reported without NilEqualsEmpty")
+	}
+}
@@ -0,0 +1,123 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxDepth 보다 깊은 값은 ... 로 줄인다 (순환 구조도 여기서 멈춘다)
+const maxDepth = 5
+
+var stringerType = reflect.TypeFor[fmt.Stringer]()
+
+/*
+format 은 값을 Go 코드와 비슷하게 쓴다.
+
+	"x"                 문자열은 따옴표
+	nil, []             nil 과 빈 slice 를 구분한다
+	&{City:"Seoul"}     pointer 는 가리키는 값
+	map["a":1 "b":2]    map 은 key 순서대로
+
+unexported 필드도 쓰고, fmt.Stringer 는 (time.Time 등) String 을 쓴다.
+*/
+func format(v reflect.Value) string {
+	var sb strings.Builder
+	writeValue(&sb, v, 0)
+	return sb.String()
+}
+
+func writeValue(sb *strings.Builder, v reflect.Value, depth int) {
+	if !v.IsValid() {
+		sb.WriteString("nil")
+		return
+	}
+	if depth > maxDepth {
+		sb.WriteString("...")
+		return
+	}
+
+	if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface && v.CanInterface() && v.Type().Implements(stringerType) {
+		sb.WriteString(v.Interface().(fmt.Stringer).String())
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		sb.WriteString(strconv.Quote(v.String()))
+	case reflect.Pointer:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		sb.WriteByte('&')
+		writeValue(sb, v.Elem(), depth+1)
+	case reflect.Interface:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		writeValue(sb, v.Elem(), depth)
+	case reflect.Struct:
+		sb.WriteByte('{')
+		for i := 0; i < v.NumField(); i++ {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(v.Type().Field(i).Name + ":")
+			writeValue(sb, v.Field(i), depth+1)
+		}
+		sb.WriteByte('}')
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		sb.WriteByte('[')
+		for i := range v.Len() {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			writeValue(sb, v.Index(i), depth+1)
+		}
+		sb.WriteByte(']')
+	case reflect.Map:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, compareKeys)
+
+		sb.WriteString("map[")
+		for i, k := range keys {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			writeValue(sb, k, depth+1)
+			sb.WriteByte(':')
+			writeValue(sb, v.MapIndex(k), depth+1)
+		}
+		sb.WriteByte(']')
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		fmt.Fprintf(sb, "%s(%#x)", v.Type(), v.Pointer())
+	default:
+		// bool, 숫자. reflect.Value 를 넘기면 unexported 필드도 쓸 수 있다.
+		fmt.Fprint(sb, v)
+	}
+}
+
+// typed 는 타입이 다른 두 값을 비교할 때 타입을 붙여서 쓴다
+func typed(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	return v.Type().String() + "(" + format(v) + ")"
+}
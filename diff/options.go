@@ -0,0 +1,122 @@
+package diff
+
+import "strings"
+
+type options struct {
+	ignore         [][]string
+	nilEqualsEmpty bool
+	tolerance      float64
+}
+
+type Option func(*options)
+
+/*
+IgnoreFields 는 경로가 맞는 값을 비교하지 않는다 (그 아래도 모두).
+경로는 Compare 결과와 같은 모양이고, * 는 필드 하나, [*] 는 index 나 key 하나에 맞는다.
+
+	diff.IgnoreFields("id", "Orders[*].note", "*.UpdatedAt")
+*/
+func IgnoreFields(paths ...string) Option {
+	return func(o *options) {
+		for _, p := range paths {
+			o.ignore = append(o.ignore, splitPath(p))
+		}
+	}
+}
+
+// NilEqualsEmpty 는 nil slice (map) 와 빈 slice (map) 를 같다고 본다 (customer.equals 와 같다)
+func NilEqualsEmpty() Option {
+	return func(o *options) {
+		o.nilEqualsEmpty = true
+	}
+}
+
+// FloatTolerance 는 차이가 eps 이하인 float (complex 는 실수부, 허수부 각각) 을 같다고 본다
+func FloatTolerance(eps float64) Option {
+	return func(o *options) {
+		o.tolerance = eps
+	}
+}
+
+func (o options) ignored(path []string) bool {
+	for _, pattern := range o.ignore {
+		if matchPath(pattern, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchPath(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+
+	for i, p := range pattern {
+		switch {
+		case p == path[i]:
+		case p == "*" && !strings.HasPrefix(path[i], "["):
+		case p == "[*]" && strings.HasPrefix(path[i], "["):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// joinPath 는 경로 조각을 Orders[0].Price 처럼 잇는다
+func joinPath(path []string) string {
+	var sb strings.Builder
+	for i, seg := range path {
+		if i > 0 && !strings.HasPrefix(seg, "[") {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(seg)
+	}
+
+	return sb.String()
+}
+
+// splitPath 는 joinPath 의 반대다. [] 안의 따옴표 문자열에 있는 . 이나 ] 는 나누지 않는다.
+func splitPath(p string) []string {
+	var segs []string
+
+	for i := 0; i < len(p); {
+		switch p[i] {
+		case '.':
+			i++
+		case '[':
+			end := indexEnd(p, i)
+			segs = append(segs, p[i:end])
+			i = end
+		default:
+			end := i
+			for end < len(p) && p[end] != '.' && p[end] != '[' {
+				end++
+			}
+			segs = append(segs, p[i:end])
+			i = end
+		}
+	}
+
+	return segs
+}
+
+// indexEnd 는 p[start] 의 [ 에 맞는 ] 다음 위치다
+func indexEnd(p string, start int) int {
+	quoted := false
+	for i := start + 1; i < len(p); i++ {
+		switch {
+		case quoted && p[i] == '\\':
+			i++
+		case p[i] == '"':
+			quoted = !quoted
+		case p[i] == ']' && !quoted:
+			return i + 1
+		}
+	}
+
+	return len(p)
+}
@@ -0,0 +1,266 @@
+package diff
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"unsafe"
+)
+
+type walker struct {
+	opts options
+	home string
+	// visited 는 이미 비교 중인 pointer 쌍이다 (순환 구조에서 멈춘다)
+	visited map[visit]bool
+	diffs   Diffs
+}
+
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+func (w *walker) report(path []string, left, right string) {
+	w.diffs = append(w.diffs, Difference{Path: joinPath(path), Left: left, Right: right})
+}
+
+func (w *walker) compare(path []string, a, b reflect.Value) {
+	if w.opts.ignored(path) {
+		return
+	}
+
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			w.report(path, typed(a), typed(b))
+		}
+		return
+	}
+
+	if a.Type() != b.Type() {
+		w.report(path, typed(a), typed(b))
+		return
+	}
+
+	if eq, ok := w.equalMethod(a, b); ok {
+		if !eq {
+			w.report(path, format(a), format(b))
+		}
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if !w.floatEqual(a.Float(), b.Float()) {
+			w.report(path, format(a), format(b))
+		}
+	case reflect.Complex64, reflect.Complex128:
+		ca, cb := a.Complex(), b.Complex()
+		if !w.floatEqual(real(ca), real(cb)) || !w.floatEqual(imag(ca), imag(cb)) {
+			w.report(path, format(a), format(b))
+		}
+	case reflect.Pointer:
+		w.pointer(path, a, b)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				w.report(path, typed(a.Elem()), typed(b.Elem()))
+			}
+			return
+		}
+		w.compare(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		a, b = addressable(a), addressable(b)
+		for i := 0; i < a.NumField(); i++ {
+			f := a.Type().Field(i)
+			if f.Name == "_" {
+				continue
+			}
+			w.compare(append(path, f.Name), exported(a.Field(i)), exported(b.Field(i)))
+		}
+	case reflect.Slice:
+		if w.nilMismatch(path, a, b) {
+			return
+		}
+		if a.Len() == b.Len() && (a.Len() == 0 || a.UnsafePointer() == b.UnsafePointer()) {
+			return
+		}
+		w.list(path, a, b)
+	case reflect.Array:
+		w.list(path, a, b)
+	case reflect.Map:
+		if w.nilMismatch(path, a, b) {
+			return
+		}
+		if a.UnsafePointer() == b.UnsafePointer() {
+			return
+		}
+		w.mapping(path, a, b)
+	case reflect.Func:
+		// reflect.DeepEqual 처럼 둘 다 nil 일 때만 같다
+		if !a.IsNil() || !b.IsNil() {
+			w.report(path, format(a), format(b))
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			w.report(path, format(a), format(b))
+		}
+	default:
+		// bool, int, uint, string
+		if !a.Equal(b) {
+			w.report(path, format(a), format(b))
+		}
+	}
+}
+
+func (w *walker) floatEqual(a, b float64) bool {
+	return a == b || math.Abs(a-b) <= w.opts.tolerance
+}
+
+func (w *walker) pointer(path []string, a, b reflect.Value) {
+	if a.Pointer() == b.Pointer() {
+		return
+	}
+	if a.IsNil() || b.IsNil() {
+		w.report(path, format(a), format(b))
+		return
+	}
+
+	v := visit{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+	if w.visited[v] {
+		return
+	}
+	w.visited[v] = true
+
+	w.compare(path, a.Elem(), b.Elem())
+}
+
+// nilMismatch 는 한쪽만 nil 인 slice, map 을 차이로 적는다 (NilEqualsEmpty 면 빈 것과 같다)
+func (w *walker) nilMismatch(path []string, a, b reflect.Value) bool {
+	if a.IsNil() == b.IsNil() {
+		return false
+	}
+
+	if w.opts.nilEqualsEmpty && a.Len() == 0 && b.Len() == 0 {
+		return true
+	}
+
+	if a.Len() == 0 && b.Len() == 0 {
+		w.report(path, format(a), format(b))
+		return true
+	}
+
+	return false
+}
+
+// list 는 slice, array 를 같은 index 끼리 비교하고, 한쪽에만 있는 원소는 Missing 과 비교한다
+func (w *walker) list(path []string, a, b reflect.Value) {
+	for i := range max(a.Len(), b.Len()) {
+		p := append(path, "["+strconv.Itoa(i)+"]")
+		switch {
+		case i >= a.Len():
+			if !w.opts.ignored(p) {
+				w.report(p, Missing, format(b.Index(i)))
+			}
+		case i >= b.Len():
+			if !w.opts.ignored(p) {
+				w.report(p, format(a.Index(i)), Missing)
+			}
+		default:
+			w.compare(p, a.Index(i), b.Index(i))
+		}
+	}
+}
+
+// mapping 은 key 를 정렬해서 비교한다 (결과가 항상 같은 순서가 된다)
+func (w *walker) mapping(path []string, a, b reflect.Value) {
+	keys := a.MapKeys()
+	for _, k := range b.MapKeys() {
+		if !a.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	slices.SortFunc(keys, compareKeys)
+
+	for _, k := range keys {
+		p := append(path, "["+format(k)+"]")
+		va, vb := a.MapIndex(k), b.MapIndex(k)
+		switch {
+		case !va.IsValid():
+			if !w.opts.ignored(p) {
+				w.report(p, Missing, format(vb))
+			}
+		case !vb.IsValid():
+			if !w.opts.ignored(p) {
+				w.report(p, format(va), Missing)
+			}
+		default:
+			w.compare(p, va, vb)
+		}
+	}
+}
+
+func compareKeys(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	}
+
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// addressable 은 루트나 map 값처럼 주소가 없는 struct 를 주소가 있는 값으로 복사한다.
+// 그래야 unexported 필드도 주소로 다시 읽을 수 있다.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+/*
+exported 는 unexported 필드에서 읽은 값을 같은 주소에서 다시 읽어 Interface, Call 을 쓸 수 있게 한다.
+customer 처럼 필드가 모두 unexported 인 타입 안의 time.Time 도 Equal 로 비교하기 위한 것이다.
+*/
+func exported(v reflect.Value) reflect.Value {
+	if v.CanInterface() || !v.CanAddr() {
+		return v
+	}
+
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+/*
+equalMethod 는 다른 패키지의 타입이 Equal(T) bool 을 가지면 그것으로 비교한다.
+time.Time 처럼 안을 들여다보면 의미 없는 차이 (위치, monotonic clock) 가 나오는 타입을 위한 것이다.
+비교하는 값과 같은 패키지의 타입은 (eqgen 으로 생성한 Equal 이 있어도) 필드까지 들어가야 이유를 알 수 있다.
+*/
+func (w *walker) equalMethod(a, b reflect.Value) (equal, ok bool) {
+	t := a.Type()
+	if t.PkgPath() == "" || t.PkgPath() == w.home || !a.CanInterface() || !b.CanInterface() {
+		return false, false
+	}
+
+	m, found := t.MethodByName("Equal")
+	if !found {
+		return false, false
+	}
+
+	mt := m.Type
+	if mt.NumIn() != 2 || mt.In(1) != t || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+
+	return m.Func.Call([]reflect.Value{a, b})[0].Bool(), true
+}
@@ -0,0 +1,101 @@
+/*
+eqgen 은 struct 의 Equal 메서드를 생성한다.
+
+	//go:generate go run github.com/zkfmapf123/100/eqgen/cmd/eqgen -out customer_equal.go
+
+-type 이 없으면 //eqgen:equal 이 붙은 struct 를 모두 생성한다.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/zkfmapf123/100/eqgen"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated struct type names (default: structs annotated with "+eqgen.Directive+")")
+	nan := flag.String("nan", "unequal", "NaN policy for -type: equal or unequal")
+	out := flag.String("out", "equal_gen.go", "output file")
+	flag.Parse()
+
+	policy, err := eqgen.ParseNaNPolicy(*nan)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: false,
+	}
+
+	// 이전에 생성한 파일은 Equal 이 겹치지 않도록 빈 파일로 바꿔서 읽는다
+	path, err := filepath.Abs(filepath.Join(dir, *out))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if old, err := os.ReadFile(path); err == nil && strings.HasPrefix(string(old), "// Code generated by eqgen") {
+		cfg.Overlay = map[string][]byte{path: stub(old)}
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pkg := pkgs[0]
+
+	// 생성된 Equal 을 부르는 코드는 타입 에러가 나므로 넘어간다 (필드 타입이 잘못되면 Generate 가 알려준다)
+	for _, err := range pkg.Errors {
+		if err.Kind != packages.TypeError {
+			packages.PrintErrors(pkgs)
+			os.Exit(1)
+		}
+	}
+
+	var targets []eqgen.Target
+	if *typeNames != "" {
+		for _, name := range strings.Split(*typeNames, ",") {
+			targets = append(targets, eqgen.Target{Name: strings.TrimSpace(name), NaN: policy})
+		}
+	} else if targets, err = eqgen.Annotated(pkg); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := eqgen.Generate(pkg, targets)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("eqgen: wrote", filepath.Join(dir, *out))
+}
+
+// stub 은 생성된 파일에서 package 줄만 남긴다 (build tag 가 있으면 그대로 둔다)
+func stub(src []byte) []byte {
+	var keep []string
+	for _, line := range strings.Split(string(src), "\n") {
+		keep = append(keep, line)
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+	}
+
+	return []byte(strings.Join(keep, "\n") + "\n")
+}
@@ -0,0 +1,479 @@
+package eqgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+/*
+20.go 의 customer.equals 처럼 손으로 쓰던 비교 함수를 생성한다. reflect.DeepEqual 보다 훨씬 빠르다.
+
+	//go:generate go run github.com/zkfmapf123/100/eqgen/cmd/eqgen -out customer_equal.go
+
+	//eqgen:equal
+	type customer struct {
+		id    string
+		op    []float64
+		cache map[string]int `eq:"-"` // 비교하지 않는다
+	}
+
+	func (a customer) Equal(b customer) bool // 생성된다
+
+- slice, map 은 길이와 원소를 비교한다 (nil 과 빈 slice 는 같다)
+- pointer 는 가리키는 값을 비교한다 (둘 다 nil 이면 같다)
+- Equal(T) bool 메서드가 있거나 //eqgen:equal 이 붙은 타입은 그 Equal 을 부른다 (time.Time 등)
+- 그 외 중첩 struct 는 필드를 펼쳐서 비교한다
+- float 은 기본적으로 == 와 같이 NaN != NaN 이다. //eqgen:equal nan=equal 이면 NaN 끼리 같다
+- interface 필드는 reflect.DeepEqual 로 비교한다
+- func 필드는 비교할 수 없으므로 eq:"-" 로 빼야 한다
+*/
+
+// Directive 는 Equal 을 생성할 struct 에 붙이는 주석이다
+const Directive = "//eqgen:equal"
+
+// NaNPolicy 는 float 필드의 NaN 을 어떻게 비교할지 정한다
+type NaNPolicy int
+
+const (
+	// NaNUnequal 은 == 와 같다 (NaN 은 자기 자신과도 다르다)
+	NaNUnequal NaNPolicy = iota
+	// NaNEqual 은 NaN 끼리 같다고 본다
+	NaNEqual
+)
+
+func ParseNaNPolicy(s string) (NaNPolicy, error) {
+	switch s {
+	case "", "unequal":
+		return NaNUnequal, nil
+	case "equal":
+		return NaNEqual, nil
+	}
+
+	return 0, fmt.Errorf("eqgen: unknown nan policy %q (want equal or unequal)", s)
+}
+
+// Target 은 Equal 을 생성할 struct 하나다
+type Target struct {
+	Name string
+	NaN  NaNPolicy
+}
+
+// Annotated 는 pkg 에서 //eqgen:equal 이 붙은 struct 를 선언 순서대로 찾는다
+func Annotated(pkg *packages.Package) ([]Target, error) {
+	var targets []Target
+
+	for _, f := range pkg.Syntax {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				doc := ts.Doc
+				if doc == nil && len(gd.Specs) == 1 {
+					doc = gd.Doc
+				}
+
+				args, ok := directive(doc)
+				if !ok {
+					continue
+				}
+
+				t := Target{Name: ts.Name.Name}
+				for _, arg := range args {
+					value, found := strings.CutPrefix(arg, "nan=")
+					if !found {
+						return nil, fmt.Errorf("eqgen: %s: unknown directive argument %q", ts.Name.Name, arg)
+					}
+
+					p, err := ParseNaNPolicy(value)
+					if err != nil {
+						return nil, err
+					}
+					t.NaN = p
+				}
+				targets = append(targets, t)
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+func directive(doc *ast.CommentGroup) ([]string, bool) {
+	if doc == nil {
+		return nil, false
+	}
+
+	for _, c := range doc.List {
+		if rest, ok := strings.CutPrefix(c.Text, Directive); ok && (rest == "" || rest[0] == ' ') {
+			return strings.Fields(rest), true
+		}
+	}
+
+	return nil, false
+}
+
+// Generate 는 pkg 의 targets 에 대한 Equal 메서드를 하나의 파일로 만든다
+func Generate(pkg *packages.Package, targets []Target) ([]byte, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("eqgen: no struct to generate in %s (add %s or use -type)", pkg.PkgPath, Directive)
+	}
+
+	g := &generator{
+		pkg:       pkg.Types,
+		imports:   map[string]bool{},
+		generated: map[*types.TypeName]bool{},
+	}
+
+	names := make([]*types.TypeName, 0, len(targets))
+	for _, t := range targets {
+		tn, ok := pkg.Types.Scope().Lookup(t.Name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("eqgen: type %s not found in %s", t.Name, pkg.PkgPath)
+		}
+		names = append(names, tn)
+		g.generated[tn] = true
+	}
+
+	var body bytes.Buffer
+	for i, t := range targets {
+		src, err := g.method(names[i], t.NaN)
+		if err != nil {
+			return nil, err
+		}
+		body.WriteString(src)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by eqgen; DO NOT EDIT.\n\npackage " + pkg.Name + "\n")
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for p := range g.imports {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+
+		buf.WriteString("\nimport (\n")
+		for _, p := range paths {
+			buf.WriteString("\t" + strconv.Quote(p) + "\n")
+		}
+		buf.WriteString(")\n")
+	}
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("eqgen: format generated code: %w\n%s", err, buf.Bytes())
+	}
+
+	return src, nil
+}
+
+type generator struct {
+	pkg     *types.Package
+	imports map[string]bool
+	// generated 는 이번에 Equal 을 생성하는 타입이다 (아직 메서드가 없어도 부를 수 있다)
+	generated map[*types.TypeName]bool
+
+	nan       NaNPolicy
+	buf       *bytes.Buffer
+	depth     int
+	expanding map[*types.Named]bool
+}
+
+func (g *generator) method(tn *types.TypeName, nan NaNPolicy) (string, error) {
+	named, ok := tn.Type().(*types.Named)
+	if !ok {
+		return "", fmt.Errorf("eqgen: %s is an alias", tn.Name())
+	}
+	if named.TypeParams().Len() > 0 {
+		return "", fmt.Errorf("eqgen: %s: generic types are not supported", tn.Name())
+	}
+
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return "", fmt.Errorf("eqgen: %s is not a struct", tn.Name())
+	}
+
+	g.nan = nan
+	g.buf = &bytes.Buffer{}
+	g.depth = 0
+	g.expanding = map[*types.Named]bool{named: true}
+
+	if err := g.fields("a", "b", st, tn.Name()); err != nil {
+		return "", err
+	}
+
+	doc := "a 와 b 의 모든 필드를 비교한다"
+	if nan == NaNEqual {
+		doc += " (NaN 끼리는 같다)"
+	}
+
+	return fmt.Sprintf("\n// Equal 은 %s\nfunc (a %s) Equal(b %s) bool {\n%s\treturn true\n}\n", doc, tn.Name(), tn.Name(), g.buf.String()), nil
+}
+
+func (g *generator) emit(format string, args ...any) {
+	fmt.Fprintf(g.buf, format+"\n", args...)
+}
+
+// fields 는 struct 의 필드를 하나씩 비교한다 (eq:"-" 는 건너뛴다)
+func (g *generator) fields(x, y string, st *types.Struct, path string) error {
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if f.Name() == "_" || excluded(st.Tag(i)) {
+			continue
+		}
+
+		fpath := path + "." + f.Name()
+		if !f.Exported() && f.Pkg() != g.pkg {
+			return fmt.Errorf("eqgen: %s: unexported field of another package cannot be compared", fpath)
+		}
+
+		if err := g.compare(selector(x, f.Name()), selector(y, f.Name()), f.Type(), fpath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func excluded(tag string) bool {
+	return reflect.StructTag(tag).Get("eq") == "-"
+}
+
+// compare 는 x 와 y 가 다르면 false 를 돌려주는 문장을 만든다
+func (g *generator) compare(x, y string, t types.Type, path string) error {
+	if t == types.Typ[types.Invalid] {
+		return fmt.Errorf("eqgen: %s: invalid type (does the package compile?)", path)
+	}
+
+	if g.hasEqual(t) {
+		g.emit("if !%s(%s) {\nreturn false\n}", selector(x, "Equal"), y)
+		return nil
+	}
+
+	if g.shallow(t) {
+		g.emit("if %s != %s {\nreturn false\n}", x, y)
+		return nil
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return g.basic(x, y, t, u)
+	case *types.Pointer:
+		g.emit("if %s != %s {", x, y)
+		g.emit("if %s == nil || %s == nil {\nreturn false\n}", x, y)
+		var err error
+		if st, ok := u.Elem().Underlying().(*types.Struct); ok && !g.hasEqual(u.Elem()) && !g.shallow(u.Elem()) {
+			// 필드 선택은 pointer 를 자동으로 따라간다
+			err = g.expand(x, y, u.Elem(), st, path)
+		} else {
+			err = g.compare("*"+x, "*"+y, u.Elem(), path)
+		}
+		g.emit("}")
+		return err
+	case *types.Struct:
+		return g.expand(x, y, t, u, path)
+	case *types.Array:
+		return g.loop(x, y, u.Elem(), path)
+	case *types.Slice:
+		return g.slice(x, y, u, path)
+	case *types.Map:
+		return g.mapping(x, y, u, path)
+	case *types.Interface:
+		g.imports["reflect"] = true
+		g.emit("if !reflect.DeepEqual(%s, %s) {\nreturn false\n}", x, y)
+		return nil
+	case *types.Signature:
+		return fmt.Errorf("eqgen: %s: func fields cannot be compared; exclude it with `eq:\"-\"`", path)
+	}
+
+	return fmt.Errorf("eqgen: %s: unsupported type %s", path, t)
+}
+
+func (g *generator) basic(x, y string, t types.Type, b *types.Basic) error {
+	switch {
+	case b.Info()&types.IsFloat != 0 && g.nan == NaNEqual:
+		g.imports["math"] = true
+		g.emit("if %s != %s && !(math.IsNaN(%s) && math.IsNaN(%s)) {\nreturn false\n}", x, y, convert(x, t, types.Float64), convert(y, t, types.Float64))
+	case b.Info()&types.IsComplex != 0 && g.nan == NaNEqual:
+		g.imports["math/cmplx"] = true
+		g.emit("if %s != %s && !(cmplx.IsNaN(%s) && cmplx.IsNaN(%s)) {\nreturn false\n}", x, y, convert(x, t, types.Complex128), convert(y, t, types.Complex128))
+	default:
+		g.emit("if %s != %s {\nreturn false\n}", x, y)
+	}
+
+	return nil
+}
+
+// expand 는 중첩 struct 를 펼친다. 자기 자신을 다시 만나면 (재귀 타입) 펼칠 수 없다.
+func (g *generator) expand(x, y string, t types.Type, st *types.Struct, path string) error {
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		if g.expanding[named] {
+			return fmt.Errorf("eqgen: %s: recursive type %s needs its own Equal (add %s)", path, named.Obj().Name(), Directive)
+		}
+		g.expanding[named] = true
+		defer delete(g.expanding, named)
+	}
+
+	return g.fields(x, y, st, path)
+}
+
+func (g *generator) slice(x, y string, s *types.Slice, path string) error {
+	if b, ok := s.Elem().(*types.Basic); ok && b.Kind() == types.Byte {
+		g.imports["bytes"] = true
+		g.emit("if !bytes.Equal(%s, %s) {\nreturn false\n}", x, y)
+		return nil
+	}
+
+	if g.shallow(s.Elem()) {
+		g.imports["slices"] = true
+		g.emit("if !slices.Equal(%s, %s) {\nreturn false\n}", x, y)
+		return nil
+	}
+
+	g.emit("if len(%s) != len(%s) {\nreturn false\n}", x, y)
+	return g.loop(x, y, s.Elem(), path)
+}
+
+func (g *generator) loop(x, y string, elem types.Type, path string) error {
+	i := g.name("i")
+	g.depth++
+	defer func() { g.depth-- }()
+
+	g.emit("for %s := range %s {", i, x)
+	err := g.compare(index(x, i), index(y, i), elem, path+"[]")
+	g.emit("}")
+	return err
+}
+
+func (g *generator) mapping(x, y string, m *types.Map, path string) error {
+	if g.shallow(m.Elem()) {
+		g.imports["maps"] = true
+		g.emit("if !maps.Equal(%s, %s) {\nreturn false\n}", x, y)
+		return nil
+	}
+
+	k, va, vb, ok := g.name("k"), g.name("va"), g.name("vb"), g.name("ok")
+	g.depth++
+	defer func() { g.depth-- }()
+
+	g.emit("if len(%s) != len(%s) {\nreturn false\n}", x, y)
+	g.emit("for %s, %s := range %s {", k, va, x)
+	g.emit("%s, %s := %s", vb, ok, index(y, k))
+	g.emit("if !%s {\nreturn false\n}", ok)
+	err := g.compare(va, vb, m.Elem(), path+"[]")
+	g.emit("}")
+	return err
+}
+
+// name 은 중첩 loop 마다 다른 변수 이름이다 (i, i1, i2 ...)
+func (g *generator) name(base string) string {
+	if g.depth == 0 {
+		return base
+	}
+
+	return base + strconv.Itoa(g.depth)
+}
+
+/*
+hasEqual 은 t 를 t.Equal(u) 로 비교할지 본다
+
+  - 이번에 생성하는 타입
+  - 값 메서드 Equal(T) bool 이 있는 타입 (time.Time 등)
+*/
+func (g *generator) hasEqual(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	if g.generated[named.Obj()] {
+		return true
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(t, false, named.Obj().Pkg(), "Equal")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if _, ptr := sig.Recv().Type().(*types.Pointer); ptr {
+		return false
+	}
+	if sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+
+	return types.Identical(sig.Params().At(0).Type(), t) && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
+/*
+shallow 는 == 로 비교해도 생성한 비교와 같은 결과인지 본다.
+pointer (주소 비교), interface (panic 가능), Equal 메서드, eq:"-" 필드, NaN 을 같게 보는 float 이 들어있으면 아니다.
+*/
+func (g *generator) shallow(t types.Type) bool {
+	if g.hasEqual(t) || !types.Comparable(t) {
+		return false
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return g.nan == NaNUnequal || u.Info()&(types.IsFloat|types.IsComplex) == 0
+	case *types.Pointer, *types.Interface:
+		return false
+	case *types.Array:
+		return g.shallow(u.Elem())
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if excluded(u.Tag(i)) || !g.shallow(u.Field(i).Type()) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return true
+}
+
+// selector 는 x.name 이다 (*x 는 괄호로 감싼다)
+func selector(x, name string) string {
+	if strings.HasPrefix(x, "*") {
+		return "(" + x + ")." + name
+	}
+
+	return x + "." + name
+}
+
+// convert 는 x 를 kind 로 바꾼다 (이미 kind 면 그대로 둔다)
+func convert(x string, t types.Type, kind types.BasicKind) string {
+	if types.Identical(t, types.Typ[kind]) {
+		return x
+	}
+
+	return types.Typ[kind].Name() + "(" + x + ")"
+}
+
+func index(x, i string) string {
+	if strings.HasPrefix(x, "*") {
+		return "(" + x + ")[" + i + "]"
+	}
+
+	return x + "[" + i + "]"
+}
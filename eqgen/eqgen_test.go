@@ -0,0 +1,93 @@
+package eqgen
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+const mode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+	packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+func load(t *testing.T, pattern string, overlay map[string][]byte) *packages.Package {
+	t.Helper()
+
+	cfg := &packages.Config{Mode: mode, Overlay: overlay}
+
+	pkgs, err := packages.Load(cfg, pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatalf("%s has errors", pattern)
+	}
+
+	return pkgs[0]
+}
+
+// example/equal_gen.go 가 golden 이다. 생성된 Equal 이 실제로 맞는지는 example 의 테스트가 확인한다.
+func TestGenerate(t *testing.T) {
+	golden, err := filepath.Abs(filepath.Join("example", "equal_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 이미 생성된 Equal 없이 읽는다
+	pkg := load(t, "./example", map[string][]byte{golden: []byte("package example\n")})
+
+	targets, err := Annotated(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Target{{Name: "Customer"}, {Name: "Address"}, {Name: "Measurement", NaN: NaNEqual}, {Name: "Node"}}
+	if !slices.Equal(targets, want) {
+		t.Fatalf("Annotated = %v, want %v", targets, want)
+	}
+
+	src, err := Generate(pkg, targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *update {
+		if err := os.WriteFile(golden, src, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	old, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(src, old) {
+		t.Errorf("generated code differs from %s; run go test -update\n%s", golden, src)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	pkg := load(t, "./testdata/bad", nil)
+
+	for _, name := range []string{"Missing", "ID", "Handler", "Tree", "Wrapper", "Pair"} {
+		if _, err := Generate(pkg, []Target{{Name: name}}); err == nil {
+			t.Errorf("Generate(%q) = nil error", name)
+		}
+	}
+
+	if _, err := Generate(pkg, nil); err == nil {
+		t.Error("Generate with no targets = nil error")
+	}
+
+	if _, err := Annotated(pkg); err == nil {
+		t.Error("Annotated accepted nan=sometimes")
+	}
+}
@@ -0,0 +1,159 @@
+// Code generated by eqgen; DO NOT EDIT.
+
+package example
+
+import (
+	"bytes"
+	"maps"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"slices"
+)
+
+// Equal 은 a 와 b 의 모든 필드를 비교한다
+func (a Customer) Equal(b Customer) bool {
+	if a.ID != b.ID {
+		return false
+	}
+	if !slices.Equal(a.Tags, b.Tags) {
+		return false
+	}
+	if !slices.Equal(a.Scores, b.Scores) {
+		return false
+	}
+	if !bytes.Equal(a.Raw, b.Raw) {
+		return false
+	}
+	if !maps.Equal(a.Attrs, b.Attrs) {
+		return false
+	}
+	if a.Address != b.Address {
+		if a.Address == nil || b.Address == nil {
+			return false
+		}
+		if !(*a.Address).Equal(*b.Address) {
+			return false
+		}
+	}
+	if len(a.Orders) != len(b.Orders) {
+		return false
+	}
+	for i := range a.Orders {
+		if a.Orders[i].ID != b.Orders[i].ID {
+			return false
+		}
+		if a.Orders[i].Price != b.Orders[i].Price {
+			return false
+		}
+		if a.Orders[i].Items != b.Orders[i].Items {
+			return false
+		}
+		if a.Orders[i].Ref != b.Orders[i].Ref {
+			if a.Orders[i].Ref == nil || b.Orders[i].Ref == nil {
+				return false
+			}
+			if *a.Orders[i].Ref != *b.Orders[i].Ref {
+				return false
+			}
+		}
+	}
+	if len(a.Labels) != len(b.Labels) {
+		return false
+	}
+	for k, va := range a.Labels {
+		vb, ok := b.Labels[k]
+		if !ok {
+			return false
+		}
+		if !slices.Equal(va, vb) {
+			return false
+		}
+	}
+	if !a.Created.Equal(b.Created) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Extra, b.Extra) {
+		return false
+	}
+	return true
+}
+
+// Equal 은 a 와 b 의 모든 필드를 비교한다
+func (a Address) Equal(b Address) bool {
+	if a.City != b.City {
+		return false
+	}
+	if a.Zip != b.Zip {
+		if a.Zip == nil || b.Zip == nil {
+			return false
+		}
+		if *a.Zip != *b.Zip {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal 은 a 와 b 의 모든 필드를 비교한다 (NaN 끼리는 같다)
+func (a Measurement) Equal(b Measurement) bool {
+	if a.Value != b.Value && !(math.IsNaN(a.Value) && math.IsNaN(b.Value)) {
+		return false
+	}
+	if len(a.Samples) != len(b.Samples) {
+		return false
+	}
+	for i := range a.Samples {
+		if a.Samples[i] != b.Samples[i] && !(math.IsNaN(float64(a.Samples[i])) && math.IsNaN(float64(b.Samples[i]))) {
+			return false
+		}
+	}
+	if len(a.ByKey) != len(b.ByKey) {
+		return false
+	}
+	for k, va := range a.ByKey {
+		vb, ok := b.ByKey[k]
+		if !ok {
+			return false
+		}
+		if va != vb && !(math.IsNaN(va) && math.IsNaN(vb)) {
+			return false
+		}
+	}
+	for i := range a.Point {
+		if a.Point[i] != b.Point[i] && !(math.IsNaN(a.Point[i]) && math.IsNaN(b.Point[i])) {
+			return false
+		}
+	}
+	if a.Peak != b.Peak {
+		if a.Peak == nil || b.Peak == nil {
+			return false
+		}
+		if *a.Peak != *b.Peak && !(math.IsNaN(*a.Peak) && math.IsNaN(*b.Peak)) {
+			return false
+		}
+	}
+	if a.Phase != b.Phase && !(cmplx.IsNaN(a.Phase) && cmplx.IsNaN(b.Phase)) {
+		return false
+	}
+	if a.Temp != b.Temp && !(math.IsNaN(float64(a.Temp)) && math.IsNaN(float64(b.Temp))) {
+		return false
+	}
+	return true
+}
+
+// Equal 은 a 와 b 의 모든 필드를 비교한다
+func (a Node) Equal(b Node) bool {
+	if a.Value != b.Value {
+		return false
+	}
+	if a.Next != b.Next {
+		if a.Next == nil || b.Next == nil {
+			return false
+		}
+		if !(*a.Next).Equal(*b.Next) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package example
+
+import "time"
+
+//go:generate go run github.com/zkfmapf123/100/eqgen/cmd/eqgen
+
+/*
+eqgen 이 지원하는 필드를 모두 담은 예제. equal_gen.go 는 eqgen 으로 생성했고, eqgen 의 golden 으로도 쓴다.
+*/
+
+//eqgen:equal
+type Customer struct {
+	ID      string
+	Tags    []string
+	Scores  []float64
+	Raw     []byte
+	Attrs   map[string]int
+	Address *Address
+	Orders  []Order
+	Labels  map[string][]string
+	Created time.Time
+	Extra   any
+
+	cache    map[string]string `eq:"-"`
+	OnChange func()            `eq:"-"`
+}
+
+//eqgen:equal
+type Address struct {
+	City string
+	Zip  *int
+}
+
+// Order 에는 Equal 이 없으므로 Customer.Equal 안에 펼쳐진다
+type Order struct {
+	ID    int
+	Price float64
+	Items [2]Item
+	Ref   *Item
+	note  string `eq:"-"`
+}
+
+type Item struct {
+	Name string
+	Qty  int
+}
+
+//eqgen:equal nan=equal
+type Measurement struct {
+	Value   float64
+	Samples []float32
+	ByKey   map[string]float64
+	Point   [2]float64
+	Peak    *float64
+	Phase   complex128
+	Temp    Celsius
+}
+
+type Celsius float64
+
+// Node 는 자기 자신을 가리킨다. Next 는 생성된 Node.Equal 을 다시 부른다.
+//
+//eqgen:equal
+type Node struct {
+	Value int
+	Next  *Node
+}
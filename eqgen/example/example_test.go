@@ -0,0 +1,138 @@
+package example
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newCustomer() Customer {
+	zip := 12345
+	return Customer{
+		ID:      "c1",
+		Tags:    []string{"vip", "kr"},
+		Scores:  []float64{1.5, 2.5},
+		Raw:     []byte("raw"),
+		Attrs:   map[string]int{"age": 30},
+		Address: &Address{City: "Seoul", Zip: &zip},
+		Orders: []Order{
+			{ID: 1, Price: 9.9, Items: [2]Item{{"apple", 2}, {"pear", 1}}, Ref: &Item{"apple", 2}},
+		},
+		Labels:  map[string][]string{"team": {"a", "b"}},
+		Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Extra:   map[string]any{"k": []int{1}},
+	}
+}
+
+func TestCustomerEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Customer)
+		equal  bool
+	}{
+		{"same", func(c *Customer) {}, true},
+		{"id", func(c *Customer) { c.ID = "c2" }, false},
+		{"tag", func(c *Customer) { c.Tags[1] = "us" }, false},
+		{"score", func(c *Customer) { c.Scores = append(c.Scores, 3) }, false},
+		{"raw", func(c *Customer) { c.Raw[0] = 'R' }, false},
+		{"attr", func(c *Customer) { c.Attrs["age"] = 31 }, false},
+		{"attr key", func(c *Customer) { c.Attrs = map[string]int{"agee": 30} }, false},
+		{"address city", func(c *Customer) { c.Address.City = "Busan" }, false},
+		{"address zip", func(c *Customer) { zip := 1; c.Address.Zip = &zip }, false},
+		{"address nil", func(c *Customer) { c.Address = nil }, false},
+		{"order price", func(c *Customer) { c.Orders[0].Price = 10 }, false},
+		{"order item", func(c *Customer) { c.Orders[0].Items[1].Qty = 5 }, false},
+		{"order ref", func(c *Customer) { c.Orders[0].Ref = &Item{"apple", 3} }, false},
+		{"label", func(c *Customer) { c.Labels["team"] = []string{"a"} }, false},
+		{"created", func(c *Customer) { c.Created = c.Created.Add(time.Second) }, false},
+		{"extra", func(c *Customer) { c.Extra = map[string]any{"k": []int{2}} }, false},
+
+		// 가리키는 값이 같으면 다른 pointer 여도 같다
+		{"address copy", func(c *Customer) { a := *c.Address; c.Address = &a }, true},
+		// time.Time 은 == 가 아니라 Equal 로 비교한다
+		{"created in other zone", func(c *Customer) { c.Created = c.Created.In(time.FixedZone("KST", 9*60*60)) }, true},
+		// eq:"-" 필드는 무시한다
+		{"cache", func(c *Customer) { c.cache = map[string]string{"x": "y"} }, true},
+		{"on change", func(c *Customer) { c.OnChange = func() {} }, true},
+		{"order note", func(c *Customer) { c.Orders[0].note = "gift" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newCustomer(), newCustomer()
+			tt.change(&b)
+
+			if got := a.Equal(b); got != tt.equal {
+				t.Errorf("a.Equal(b) = %v, want %v", got, tt.equal)
+			}
+			if got := b.Equal(a); got != tt.equal {
+				t.Errorf("b.Equal(a) = %v, want %v", got, tt.equal)
+			}
+		})
+	}
+}
+
+// nil 과 빈 slice, map 은 같다 (reflect.DeepEqual 과 다르다)
+func TestNilAndEmpty(t *testing.T) {
+	a := Customer{}
+	b := Customer{Tags: []string{}, Attrs: map[string]int{}, Orders: []Order{}, Labels: map[string][]string{}, Raw: []byte{}}
+
+	if !a.Equal(b) {
+		t.Error("nil and empty collections are not equal")
+	}
+	if reflect.DeepEqual(a, b) {
+		t.Error("reflect.DeepEqual treats nil and empty as equal")
+	}
+}
+
+func TestMeasurementNaN(t *testing.T) {
+	nan := math.NaN()
+	peakA, peakB := nan, nan
+	a := Measurement{
+		Value:   nan,
+		Samples: []float32{1, float32(nan)},
+		ByKey:   map[string]float64{"x": nan},
+		Point:   [2]float64{nan, 1},
+		Peak:    &peakA,
+		Phase:   complex(nan, 0),
+		Temp:    Celsius(nan),
+	}
+	b := a
+	b.Peak = &peakB
+
+	if !a.Equal(b) {
+		t.Error("nan=equal: NaN fields are not equal")
+	}
+
+	b.Point[1] = 2
+	if a.Equal(b) {
+		t.Error("different Point are equal")
+	}
+
+	// 기본 정책은 == 와 같다
+	c := Customer{Scores: []float64{nan}}
+	if c.Equal(c) {
+		t.Error("default policy: NaN equals NaN")
+	}
+}
+
+func TestNodeRecursion(t *testing.T) {
+	list := func(values ...int) *Node {
+		var head *Node
+		for i := len(values) - 1; i >= 0; i-- {
+			head = &Node{Value: values[i], Next: head}
+		}
+		return head
+	}
+
+	if !list(1, 2, 3).Equal(*list(1, 2, 3)) {
+		t.Error("same lists are not equal")
+	}
+	if list(1, 2, 3).Equal(*list(1, 2)) {
+		t.Error("lists of different length are equal")
+	}
+	if list(1, 2, 3).Equal(*list(1, 2, 4)) {
+		t.Error("different lists are equal")
+	}
+}
@@ -0,0 +1,33 @@
+package bad
+
+import "bytes"
+
+type Handler struct {
+	Name string
+	Func func()
+}
+
+// node 는 Equal 없이 자기 자신을 가리키므로 Tree.Equal 안에 펼칠 수 없다
+type Tree struct {
+	Root *node
+}
+
+type node struct {
+	Value    int
+	Children []*node
+}
+
+type Wrapper struct {
+	Buf bytes.Buffer
+}
+
+type Pair[T any] struct {
+	A, B T
+}
+
+type ID string
+
+//eqgen:equal nan=sometimes
+type Policy struct {
+	Value float64
+}
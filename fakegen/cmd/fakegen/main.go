@@ -0,0 +1,84 @@
+/*
+fakegen 은 인터페이스의 fake 를 생성한다.
+
+	//go:generate go run github.com/zkfmapf123/100/fakegen/cmd/fakegen -type CustomerStorage -out customerstorage_fake_test.go
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/zkfmapf123/100/fakegen"
+)
+
+func main() {
+	path, err := run(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("fakegen: wrote", path)
+}
+
+// run 은 인자를 읽어 fake 를 생성하고 쓴 파일의 경로를 돌려준다
+func run(args []string) (string, error) {
+	fs := flag.NewFlagSet("fakegen", flag.ContinueOnError)
+	typeName := fs.String("type", "", "interface type name")
+	out := fs.String("out", "", "output file (default <type>_fake.go)")
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+
+	if *typeName == "" {
+		return "", errors.New("fakegen: -type is required")
+	}
+
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: false,
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return "", err
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return "", errors.New("fakegen: package has errors")
+	}
+
+	src, err := fakegen.Generate(pkgs[0], *typeName)
+	if err != nil {
+		return "", err
+	}
+
+	path := *out
+	if path == "" {
+		path = strings.ToLower(*typeName) + "_fake.go"
+	}
+	// 상대 경로만 패키지 디렉토리 기준이다
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
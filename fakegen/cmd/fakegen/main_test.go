@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunOut(t *testing.T) {
+	dir := filepath.Join("..", "..", "testdata", "repo")
+	abs := filepath.Join(t.TempDir(), "repo_fake.go")
+
+	tests := []struct {
+		name string
+		out  string
+		want string
+	}{
+		{"absolute", abs, abs},
+		{"relative", "repo_fake_test.go", filepath.Join(dir, "repo_fake_test.go")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := run([]string{"-type", "Repo", "-out", tt.out, dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { os.Remove(got) })
+
+			if got != tt.want {
+				t.Errorf("wrote %s, want %s", got, tt.want)
+			}
+			if _, err := os.Stat(tt.want); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package fake
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+/*
+fakegen 이 생성한 fake 가 공통으로 쓰는 런타임
+
+- Recorder : 모든 호출을 순서대로 기록하고 호출 여부, 순서를 검사한다
+- Matcher  : 인자 비교 (Any, Eq, TypeOf, Func)
+- Stubs    : 인자에 맞는 동작을 찾는다 (나중에 추가한 것이 우선)
+*/
+
+// Call 은 fake 메서드 호출 한번이다
+type Call struct {
+	Method string
+	Args   []any
+}
+
+func (c Call) String() string {
+	args := make([]string, 0, len(c.Args))
+	for _, a := range c.Args {
+		args = append(args, fmt.Sprintf("%#v", a))
+	}
+
+	return fmt.Sprintf("%s(%s)", c.Method, strings.Join(args, ", "))
+}
+
+// TB 는 testing.TB 중 assertion 에 필요한 부분이다
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Recorder 는 생성된 fake 에 embed 된다
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *Recorder) Record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls 는 모든 호출을 순서대로 돌려준다
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Call, len(r.calls))
+	copy(out, r.calls)
+	return out
+}
+
+func (r *Recorder) CallsTo(method string) []Call {
+	var out []Call
+	for _, c := range r.Calls() {
+		if c.Method == method {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+func (r *Recorder) CallCount(method string) int {
+	return len(r.CallsTo(method))
+}
+
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+// AssertCalled 는 method 가 matchers 에 맞는 인자로 한번 이상 호출되었는지 확인한다
+func (r *Recorder) AssertCalled(t TB, method string, matchers ...Matcher) bool {
+	t.Helper()
+
+	calls := r.CallsTo(method)
+	for _, c := range calls {
+		if MatchAll(matchers, c.Args) {
+			return true
+		}
+	}
+
+	t.Errorf("fake: %s(%s) was not called; calls to %s: %v", method, describe(matchers), method, calls)
+	return false
+}
+
+func (r *Recorder) AssertNotCalled(t TB, method string) bool {
+	t.Helper()
+
+	if calls := r.CallsTo(method); len(calls) > 0 {
+		t.Errorf("fake: %s was called %d times: %v", method, len(calls), calls)
+		return false
+	}
+
+	return true
+}
+
+// AssertOrder 는 methods 가 이 순서대로 호출되었는지 확인한다 (사이에 다른 호출이 있어도 된다)
+func (r *Recorder) AssertOrder(t TB, methods ...string) bool {
+	t.Helper()
+
+	calls := r.Calls()
+	next := 0
+	for _, c := range calls {
+		if next < len(methods) && c.Method == methods[next] {
+			next++
+		}
+	}
+
+	if next < len(methods) {
+		t.Errorf("fake: expected call order %v, missing %s after %v; calls: %v", methods, methods[next], methods[:next], calls)
+		return false
+	}
+
+	return true
+}
+
+// Matcher 는 인자 하나를 비교한다
+type Matcher interface {
+	Match(v any) bool
+	String() string
+}
+
+type matcher struct {
+	desc  string
+	match func(any) bool
+}
+
+func (m matcher) Match(v any) bool { return m.match(v) }
+func (m matcher) String() string   { return m.desc }
+
+// Any 는 모든 값과 맞는다
+func Any() Matcher {
+	return matcher{"_", func(any) bool { return true }}
+}
+
+// Eq 는 reflect.DeepEqual 로 비교한다
+func Eq(want any) Matcher {
+	return matcher{fmt.Sprintf("%#v", want), func(v any) bool { return reflect.DeepEqual(v, want) }}
+}
+
+// TypeOf 는 값의 동적 타입이 T 인지 확인한다
+func TypeOf[T any]() Matcher {
+	var zero T
+	return matcher{fmt.Sprintf("%T", zero), func(v any) bool {
+		_, ok := v.(T)
+		return ok
+	}}
+}
+
+// Func 는 fn 으로 비교한다. 타입이 T 가 아니면 맞지 않는다.
+func Func[T any](desc string, fn func(T) bool) Matcher {
+	return matcher{desc, func(v any) bool {
+		t, ok := v.(T)
+		return ok && fn(t)
+	}}
+}
+
+// MatchAll 은 인자마다 matcher 를 적용한다. matcher 가 모자라면 나머지 인자는 모두 맞는다.
+func MatchAll(matchers []Matcher, args []any) bool {
+	if len(matchers) > len(args) {
+		return false
+	}
+
+	for i, m := range matchers {
+		if !m.Match(args[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describe(matchers []Matcher) string {
+	parts := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		parts = append(parts, m.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Stubs 는 메서드 하나의 동작 목록이다. F 는 그 메서드의 함수 타입이다.
+type Stubs[F any] struct {
+	mu      sync.Mutex
+	entries []stub[F]
+}
+
+type stub[F any] struct {
+	matchers []Matcher
+	fn       F
+}
+
+func (s *Stubs[F]) Add(matchers []Matcher, fn F) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, stub[F]{matchers: matchers, fn: fn})
+}
+
+// Find 는 args 와 맞는 동작 중 가장 나중에 추가한 것을 돌려준다
+func (s *Stubs[F]) Find(args ...any) (F, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		if MatchAll(s.entries[i].matchers, args) {
+			return s.entries[i].fn, true
+		}
+	}
+
+	var zero F
+	return zero, false
+}
@@ -0,0 +1,74 @@
+package fakegen
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+const mode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+	packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+
+func load(t *testing.T, overlay map[string][]byte) *packages.Package {
+	t.Helper()
+
+	cfg := &packages.Config{Mode: mode, Overlay: overlay}
+
+	pkgs, err := packages.Load(cfg, "./testdata/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatal("testdata has errors")
+	}
+
+	return pkgs[0]
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate(load(t, nil), "Repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "repo_fake.go.golden")
+	if *update {
+		if err := os.WriteFile(golden, src, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(src, want) {
+		t.Errorf("generated code differs from %s; run go test -update\n%s", golden, src)
+	}
+
+	// 생성된 코드가 원래 패키지와 함께 컴파일되는지 확인한다
+	path, err := filepath.Abs(filepath.Join("testdata", "repo", "repo_fake.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	load(t, map[string][]byte{path: src})
+}
+
+func TestGenerateErrors(t *testing.T) {
+	pkg := load(t, nil)
+
+	for _, name := range []string{"Missing", "string"} {
+		if _, err := Generate(pkg, name); err == nil {
+			t.Errorf("Generate(%q) = nil error", name)
+		}
+	}
+}
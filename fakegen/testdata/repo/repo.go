@@ -0,0 +1,12 @@
+package repo
+
+import "context"
+
+// Repo 는 제네릭 인터페이스와 가변인자 메서드를 가진 예제다
+type Repo[K comparable, V any] interface {
+	Get(ctx context.Context, key K) (V, bool, error)
+	Put(ctx context.Context, key K, value V) error
+	Delete(keys ...K) int
+	Len() int
+	Close()
+}
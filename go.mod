@@ -2,10 +2,14 @@ module github.com/zkfmapf123/100
 
 go 1.24.3
 
+require golang.org/x/tools v0.42.0
+
 require (
 	github.com/fatih/color v1.18.0 // indirect
 	github.com/inancgumus/prettyslice v0.0.0-20190305220808-d802ba58098f // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/sys v0.25.0 // indirect
+	golang.org/x/mod v0.33.0 // indirect
+	golang.org/x/sync v0.19.0 // indirect
+	golang.org/x/sys v0.41.0 // indirect
 )
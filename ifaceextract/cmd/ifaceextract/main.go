@@ -0,0 +1,87 @@
+/*
+ifaceextract 는 consumer 패키지가 provider 타입에서 실제로 호출하는 메서드만으로
+consumer 측 인터페이스를 만들고, 파라미터/필드 타입을 그 인터페이스로 바꾼다.
+
+	go run ./ifaceextract/cmd/ifaceextract -type example.com/shop/provider.C -name customerStorage ./consumer
+	go run ./ifaceextract/cmd/ifaceextract -w -type ... -name ... ./consumer
+
+-lonely 는 구현이 하나뿐이고 선언한 패키지에서 쓰이지 않는 제공자 측 인터페이스를 보고한다.
+
+	go run ./ifaceextract/cmd/ifaceextract -lonely ./...
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/zkfmapf123/100/ifaceextract"
+)
+
+func main() {
+	var (
+		typeName = flag.String("type", "", "provider type as import/path.Type")
+		name     = flag.String("name", "", "name of the interface to generate in the consumer package")
+		write    = flag.Bool("w", false, "write the result to the source files instead of printing it")
+		lonely   = flag.Bool("lonely", false, "report provider-side interfaces with a single implementation")
+	)
+	flag.Parse()
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedModule,
+	}
+
+	pkgs, err := packages.Load(cfg, flag.Args()...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		os.Exit(1)
+	}
+
+	if *lonely {
+		for _, l := range ifaceextract.LonelyInterfaces(pkgs) {
+			fmt.Println(l)
+		}
+		return
+	}
+
+	if *typeName == "" || *name == "" || len(pkgs) != 1 {
+		log.Fatal("ifaceextract: -type, -name and exactly one consumer package are required")
+	}
+
+	provider, err := ifaceextract.FindType(pkgs[0], *typeName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	res, err := ifaceextract.Extract(pkgs[0], provider, *name)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, s := range res.Skipped {
+		fmt.Fprintln(os.Stderr, "skipped:", s)
+	}
+
+	if *write {
+		if err := res.Write(); err != nil {
+			log.Fatal(err)
+		}
+
+		for _, r := range res.Rewritten {
+			fmt.Println("rewrote:", r)
+		}
+		return
+	}
+
+	for path, src := range res.Files {
+		fmt.Printf("// ----- %s -----\n%s\n", path, src)
+	}
+}
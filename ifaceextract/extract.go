@@ -0,0 +1,367 @@
+package ifaceextract
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+/*
+4.interface/users.go 처럼 인터페이스를 사용자(consumer) 측에 두도록 도와준다.
+
+1. consumer 패키지가 provider 타입(예: *provider.C)의 어떤 메서드를 실제로 호출하는지 모은다
+2. 그 메서드만 가진 작은 인터페이스를 consumer 패키지에 생성한다
+3. provider 타입을 받는 파라미터와 구조체 필드 중, 메서드 호출에만 쓰이는 것은 인터페이스로 바꾼다
+*/
+
+// Result 는 Extract 의 결과다. Files 는 새로 쓰거나 바뀐 파일 내용이다.
+type Result struct {
+	Interface string
+	Methods   []*types.Func
+	Files     map[string][]byte
+	Rewritten []string // 인터페이스로 바꾼 위치
+	Skipped   []string // 메서드 호출 외에 쓰여서 바꾸지 못한 위치와 이유
+}
+
+// FindType 은 "import/path.Type" 형태의 이름을 pkg 와 그 import 에서 찾는다
+func FindType(pkg *packages.Package, qualified string) (*types.Named, error) {
+	i := strings.LastIndex(qualified, ".")
+	if i < 0 {
+		return nil, fmt.Errorf("ifaceextract: provider type %q must be import/path.Type", qualified)
+	}
+
+	path, name := qualified[:i], qualified[i+1:]
+
+	var found *types.Package
+	if pkg.PkgPath == path {
+		found = pkg.Types
+	}
+	for _, imp := range pkg.Imports {
+		if imp.PkgPath == path {
+			found = imp.Types
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("ifaceextract: %s does not import %s", pkg.PkgPath, path)
+	}
+
+	tn, ok := found.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("ifaceextract: type %s not found in %s", name, path)
+	}
+
+	named, ok := tn.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("ifaceextract: %s is not a named type", qualified)
+	}
+
+	return named, nil
+}
+
+// holder 는 provider 타입을 담고 있는 파라미터나 구조체 필드다
+type holder struct {
+	v       *types.Var
+	field   *ast.Field
+	file    *ast.File
+	invalid string // 메서드 호출 외의 용도로 쓰인 첫 위치
+}
+
+// Extract 는 consumer 가 provider 에서 호출하는 메서드로 name 인터페이스를 만든다
+func Extract(consumer *packages.Package, provider *types.Named, name string) (*Result, error) {
+	if provider.Obj().Pkg() == consumer.Types {
+		return nil, errors.New("ifaceextract: provider and consumer are the same package")
+	}
+
+	if consumer.Types.Scope().Lookup(name) != nil {
+		return nil, fmt.Errorf("ifaceextract: %s is already declared in %s", name, consumer.PkgPath)
+	}
+
+	info := consumer.TypesInfo
+	methods := map[*types.Func]bool{}
+	methodX := map[ast.Expr]bool{} // provider 메서드 호출의 receiver 표현식
+
+	for _, f := range consumer.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			s := info.Selections[sel]
+			if s == nil || s.Kind() != types.MethodVal || !isProvider(s.Recv(), provider) {
+				return true
+			}
+
+			methods[s.Obj().(*types.Func)] = true
+			methodX[ast.Unparen(sel.X)] = true
+			return true
+		})
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("ifaceextract: %s calls no methods of %s", consumer.PkgPath, provider.Obj().Name())
+	}
+
+	holders := findHolders(consumer, provider)
+	checkHolderUses(consumer, holders, methodX)
+
+	res := &Result{Interface: name, Files: map[string][]byte{}}
+	for m := range methods {
+		res.Methods = append(res.Methods, m)
+	}
+	sort.Slice(res.Methods, func(i, j int) bool {
+		return res.Methods[i].Name() < res.Methods[j].Name()
+	})
+
+	src, err := generate(consumer, provider, name, res.Methods)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(consumer.GoFiles[0])
+	res.Files[filepath.Join(dir, strings.ToLower(name)+".go")] = src
+
+	if err := rewrite(consumer, provider, name, holders, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func isProvider(t types.Type, provider *types.Named) bool {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	named, ok := t.(*types.Named)
+	return ok && named.Origin() == provider.Origin()
+}
+
+// findHolders 는 타입이 T 또는 *T 인 파라미터와 구조체 필드를 찾는다
+func findHolders(consumer *packages.Package, provider *types.Named) map[*types.Var]*holder {
+	holders := map[*types.Var]*holder{}
+
+	for _, f := range consumer.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			var lists []*ast.FieldList
+			switch n := n.(type) {
+			case *ast.FuncType:
+				lists = append(lists, n.Params)
+			case *ast.StructType:
+				lists = append(lists, n.Fields)
+			default:
+				return true
+			}
+
+			for _, list := range lists {
+				for _, field := range list.List {
+					for _, id := range field.Names {
+						v, ok := consumer.TypesInfo.Defs[id].(*types.Var)
+						if ok && isProvider(v.Type(), provider) {
+							holders[v] = &holder{v: v, field: field, file: f}
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	return holders
+}
+
+// checkHolderUses 는 holder 가 메서드 호출, 대입, 구조체 리터럴 키 외에 쓰이면 invalid 로 표시한다
+func checkHolderUses(consumer *packages.Package, holders map[*types.Var]*holder, methodX map[ast.Expr]bool) {
+	info := consumer.TypesInfo
+	allowed := map[ast.Expr]bool{}
+
+	for _, f := range consumer.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					allowed[ast.Unparen(lhs)] = true
+				}
+			case *ast.KeyValueExpr:
+				allowed[n.Key] = true
+			}
+			return true
+		})
+	}
+
+	mark := func(v *types.Var, e ast.Expr) {
+		h, ok := holders[v]
+		if !ok || h.invalid != "" || methodX[e] || allowed[e] {
+			return
+		}
+
+		h.invalid = consumer.Fset.Position(e.Pos()).String()
+	}
+
+	for _, f := range consumer.Syntax {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SelectorExpr:
+				if s := info.Selections[n]; s != nil && s.Kind() == types.FieldVal {
+					mark(s.Obj().(*types.Var), n)
+				}
+			case *ast.Ident:
+				if v, ok := info.Uses[n].(*types.Var); ok && !v.IsField() {
+					mark(v, n)
+				}
+			}
+			return true
+		})
+	}
+}
+
+func generate(consumer *packages.Package, provider *types.Named, name string, methods []*types.Func) ([]byte, error) {
+	imports := map[string]string{}
+	qualifier := func(p *types.Package) string {
+		if p == consumer.Types {
+			return ""
+		}
+		imports[p.Path()] = p.Name()
+		return p.Name()
+	}
+
+	var body bytes.Buffer
+	for _, m := range methods {
+		sig := types.TypeString(m.Type(), qualifier)
+		fmt.Fprintf(&body, "\t%s%s\n", m.Name(), strings.TrimPrefix(sig, "func"))
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\n", consumer.Name)
+
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for p := range imports {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+
+		buf.WriteString("import (\n")
+		for _, p := range paths {
+			fmt.Fprintf(&buf, "\t%q\n", p)
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&buf, "// %s 는 이 패키지가 %s.%s 에서 실제로 호출하는 메서드만 가진다 (ifaceextract 로 생성)\n",
+		name, provider.Obj().Pkg().Name(), provider.Obj().Name())
+	fmt.Fprintf(&buf, "type %s interface {\n%s}\n", name, body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+type edit struct {
+	start, end int
+}
+
+// rewrite 는 조건을 만족하는 holder 의 타입 표현식을 인터페이스 이름으로 바꾼다
+func rewrite(consumer *packages.Package, provider *types.Named, name string, holders map[*types.Var]*holder, res *Result) error {
+	edits := map[*ast.File]map[*ast.Field]edit{}
+
+	for _, h := range holders {
+		pos := consumer.Fset.Position(h.v.Pos()).String()
+		if h.invalid != "" {
+			res.Skipped = append(res.Skipped, fmt.Sprintf("%s: %s is used at %s other than calling methods", pos, h.v.Name(), h.invalid))
+			continue
+		}
+
+		// 같은 필드 선언(a, b *C)의 이름이 모두 바꿀 수 있어야 한다
+		ok := true
+		for _, id := range h.field.Names {
+			other, _ := consumer.TypesInfo.Defs[id].(*types.Var)
+			if oh := holders[other]; oh == nil || oh.invalid != "" {
+				ok = false
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		if edits[h.file] == nil {
+			edits[h.file] = map[*ast.Field]edit{}
+		}
+
+		tf := consumer.Fset.File(h.field.Type.Pos())
+		edits[h.file][h.field] = edit{start: tf.Offset(h.field.Type.Pos()), end: tf.Offset(h.field.Type.End())}
+		res.Rewritten = append(res.Rewritten, pos+": "+h.v.Name())
+	}
+
+	sort.Strings(res.Rewritten)
+	sort.Strings(res.Skipped)
+
+	for f, fieldEdits := range edits {
+		path := consumer.Fset.File(f.Pos()).Name()
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		sorted := make([]edit, 0, len(fieldEdits))
+		for _, e := range fieldEdits {
+			sorted = append(sorted, e)
+		}
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].start > sorted[j].start })
+
+		for _, e := range sorted {
+			src = append(src[:e.start:e.start], append([]byte(name), src[e.end:]...)...)
+		}
+
+		out, err := dropUnusedImport(path, src, provider.Obj().Pkg().Path())
+		if err != nil {
+			return err
+		}
+
+		res.Files[path] = out
+	}
+
+	return nil
+}
+
+func dropUnusedImport(path string, src []byte, importPath string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("ifaceextract: rewritten %s does not parse: %w", path, err)
+	}
+
+	if !astutil.UsesImport(f, importPath) {
+		astutil.DeleteImport(fset, f, importPath)
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Write 는 Result 의 파일을 디스크에 쓴다
+func (r *Result) Write() error {
+	for path, src := range r.Files {
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
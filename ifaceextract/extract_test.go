@@ -0,0 +1,143 @@
+package ifaceextract
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func load(t *testing.T, patterns ...string) []*packages.Package {
+	t.Helper()
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedModule,
+		Dir: filepath.Join("testdata", "shop"),
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatal("testdata has errors")
+	}
+
+	return pkgs
+}
+
+func TestExtract(t *testing.T) {
+	pkgs := load(t, "./consumer")
+
+	provider, err := FindType(pkgs[0], "example.com/shop/provider.C")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := Extract(pkgs[0], provider, "customerStorage")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	methods := make([]string, 0, len(res.Methods))
+	for _, m := range res.Methods {
+		methods = append(methods, m.Name())
+	}
+
+	// Get, Set, Validate 등 consumer 가 부르지 않는 메서드는 빠진다
+	if want := []string{"GetUserId", "Save", "SetCustomerName"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("methods = %v, want %v", methods, want)
+	}
+
+	for _, want := range []string{"consumer.go:8:2: store", "consumer.go:23:13: c"} {
+		if !containsSuffix(res.Rewritten, want) {
+			t.Errorf("Rewritten = %v, want an entry ending with %q", res.Rewritten, want)
+		}
+	}
+
+	for _, want := range []string{"consumer.go:11:17: c is used at", "consumer.go:28:12: c is used at"} {
+		if !containsPart(res.Skipped, want) {
+			t.Errorf("Skipped = %v, want an entry containing %q", res.Skipped, want)
+		}
+	}
+
+	for path, src := range res.Files {
+		golden := filepath.Join("testdata", "golden", filepath.Base(path)+".golden")
+		if *update {
+			if err := os.WriteFile(golden, src, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(src, want) {
+			t.Errorf("%s differs from %s:\n%s", path, golden, src)
+		}
+	}
+}
+
+func TestExtractErrors(t *testing.T) {
+	pkgs := load(t, "./consumer")
+
+	if _, err := FindType(pkgs[0], "example.com/shop/provider.Missing"); err == nil {
+		t.Error("FindType(Missing) = nil error")
+	}
+
+	if _, err := FindType(pkgs[0], "example.com/other.C"); err == nil {
+		t.Error("FindType(not imported) = nil error")
+	}
+
+	provider, err := FindType(pkgs[0], "example.com/shop/provider.C")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Extract(pkgs[0], provider, "Service"); err == nil {
+		t.Error("Extract with an existing name = nil error")
+	}
+}
+
+func TestLonelyInterfaces(t *testing.T) {
+	var got []string
+	for _, l := range LonelyInterfaces(load(t, "./...")) {
+		got = append(got, l.Interface.Name()+" -> "+l.Impl.Name())
+	}
+
+	// validator 는 provider 안에서 쓰이므로 보고하지 않는다
+	if want := []string{"CustomerStorage -> C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LonelyInterfaces = %v, want %v", got, want)
+	}
+}
+
+func containsSuffix(list []string, suffix string) bool {
+	for _, s := range list {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsPart(list []string, part string) bool {
+	for _, s := range list {
+		if strings.Contains(s, part) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+package ifaceextract
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Lonely 는 제공자 측에 선언되었지만 구현이 하나뿐이고 선언한 패키지에서 쓰이지 않는 인터페이스다
+type Lonely struct {
+	Interface *types.TypeName
+	Impl      *types.TypeName
+	Position  string
+}
+
+func (l Lonely) String() string {
+	return fmt.Sprintf("%s: interface %s has a single implementation %s and no consumers in %s; define it on the consumer side",
+		l.Position, l.Interface.Name(), types.TypeString(l.Impl.Type(), nil), l.Interface.Pkg().Path())
+}
+
+/*
+LonelyInterfaces 는 4.interface/provider.go 의 주석처럼 "제공자 측에 둔" 인터페이스를 찾는다.
+
+  - pkgs 와 그 의존 패키지 중 main 모듈의 모든 named 타입을 구현 후보로 본다
+  - 구현(T 또는 *T)이 정확히 하나이고
+  - 선언한 패키지 안에서 인터페이스 이름이 한번도 쓰이지 않으면 보고한다
+
+표준 라이브러리나 다른 모듈의 인터페이스 (hash.Hash32 등) 는 보지 않는다.
+main 모듈을 알려면 packages.NeedModule 로 불러와야 한다.
+*/
+func LonelyInterfaces(pkgs []*packages.Package) []Lonely {
+	mainModules := map[string]bool{}
+	for _, p := range pkgs {
+		if p.Module != nil && p.Module.Main {
+			mainModules[p.Module.Path] = true
+		}
+	}
+
+	var all []*packages.Package
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		if p.Types != nil && p.TypesInfo != nil && p.Module != nil && mainModules[p.Module.Path] {
+			all = append(all, p)
+		}
+	})
+
+	var concrete []*types.TypeName
+	for _, p := range all {
+		scope := p.Types.Scope()
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if ok && !tn.IsAlias() && !types.IsInterface(tn.Type()) {
+				concrete = append(concrete, tn)
+			}
+		}
+	}
+
+	var found []Lonely
+	for _, p := range all {
+		used := map[*types.TypeName]bool{}
+		for _, obj := range p.TypesInfo.Uses {
+			if tn, ok := obj.(*types.TypeName); ok {
+				used[tn] = true
+			}
+		}
+
+		scope := p.Types.Scope()
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || tn.IsAlias() || used[tn] {
+				continue
+			}
+
+			iface, ok := tn.Type().Underlying().(*types.Interface)
+			if !ok || iface.NumMethods() == 0 || !iface.IsMethodSet() {
+				continue
+			}
+
+			impls := implementations(iface, concrete)
+			if len(impls) != 1 {
+				continue
+			}
+
+			found = append(found, Lonely{
+				Interface: tn,
+				Impl:      impls[0],
+				Position:  p.Fset.Position(tn.Pos()).String(),
+			})
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Position < found[j].Position
+	})
+
+	return found
+}
+
+func implementations(iface *types.Interface, concrete []*types.TypeName) []*types.TypeName {
+	var impls []*types.TypeName
+	for _, tn := range concrete {
+		// 제네릭 타입은 인스턴스화 없이 판단할 수 없다
+		if named, ok := tn.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+			continue
+		}
+
+		if types.Implements(tn.Type(), iface) || types.Implements(types.NewPointer(tn.Type()), iface) {
+			impls = append(impls, tn)
+		}
+	}
+
+	return impls
+}
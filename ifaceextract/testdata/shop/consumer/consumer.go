@@ -0,0 +1,30 @@
+package consumer
+
+import (
+	"example.com/shop/provider"
+)
+
+type Service struct {
+	store *provider.C
+}
+
+func NewService(c *provider.C) *Service {
+	return &Service{store: c}
+}
+
+func (s *Service) Rename(id int, name string) error {
+	if _, err := s.store.GetUserId(id); err != nil {
+		return err
+	}
+
+	return s.store.SetCustomerName(name)
+}
+
+func Import(c *provider.C, cs []provider.Customer) error {
+	return c.Save(cs)
+}
+
+// 필드에 접근하므로 인터페이스로 바꿀 수 없다
+func Limit(c *provider.C) int {
+	return c.Limit
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	// flate.Resetter 처럼 표준 라이브러리의 인터페이스는 후보가 아니다
+	_ "compress/flate"
+)
+
+type Customer struct {
+	ID   int
+	Name string
+}
+
+// ❌ 제공자 측 인터페이스 (구현은 C 하나, 이 패키지에서 쓰이지 않음)
+type CustomerStorage interface {
+	GetUserId(id int) (int, error)
+	SetCustomerName(name string) error
+}
+
+// 이 패키지 안에서 쓰이므로 보고하지 않는다
+type validator interface {
+	Validate() error
+}
+
+func Check(v validator) error {
+	return v.Validate()
+}
+
+type C struct {
+	Limit int
+}
+
+func (c *C) Get(id string) (any, error)                  { return "", nil }
+func (c *C) Set(id string, v any) error                  { return nil }
+func (c *C) GetUserId(id int) (int, error)               { return 0, nil }
+func (c *C) GetCustomerName(name string) (string, error) { return "", nil }
+func (c *C) SetUserId(id int) error                      { return nil }
+func (c *C) SetCustomerName(name string) error           { return nil }
+func (c *C) Save(cs []Customer) error                    { return nil }
+func (c *C) Validate() error                             { return nil }
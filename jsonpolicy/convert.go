@@ -0,0 +1,532 @@
+package jsonpolicy
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+/*
+정책을 적용하는 방법
+
+encoding/json 의 태그 처리는 그대로 두고, 값을 "그림자 타입" 으로 복사한 뒤 json.Marshal 에 넘긴다.
+
+  - struct 는 reflect.StructOf 로 만든 그림자 struct 가 된다. json 이름과 옵션은 태그로 옮기고,
+    empty-as-omit 필드에는 omitempty 를 붙인다
+  - nil-as-empty 인 nil slice, map 은 복사할 때 빈 slice, map 으로 바꾼다
+  - 재귀 타입 (type Node struct{ Children []*Node }) 은 StructOf 로 만들 수 없으므로 재귀하는 자리를 any 로 둔다
+
+그림자 struct 는 (타입, 전역 정책) 별로 한번만 만든다.
+*/
+
+var (
+	marshalerType     = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	anyType           = reflect.TypeFor[any]()
+)
+
+type planKey struct {
+	typ    reflect.Type
+	global Policy
+}
+
+// fieldPlan 은 그림자 struct 의 필드 하나가 원래 값의 어디에서 오는지다
+type fieldPlan struct {
+	index     []int
+	policy    Policy
+	viaPtr    bool // embedded 포인터를 거친다 (nil 이면 json 처럼 필드를 생략한다)
+	omitempty bool // 원래 태그의 omitempty
+}
+
+type structPlan struct {
+	typ    reflect.Type
+	fields []fieldPlan
+}
+
+var plans sync.Map // planKey -> *structPlan
+
+// builder 는 Marshal 한번 동안 만들고 있는 struct 를 기억해서 재귀 타입을 찾는다
+type builder struct {
+	building map[planKey]bool
+}
+
+func convert(v any, global Policy) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	b := &builder{building: map[planKey]bool{}}
+	rv := reflect.ValueOf(v)
+
+	dst, err := b.typeOf(rv.Type(), global)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := b.value(rv, dst, global, global)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Interface(), nil
+}
+
+func isMarshaler(t reflect.Type) bool {
+	return t.Implements(marshalerType) || t.Implements(textMarshalerType) ||
+		(t.Kind() != reflect.Pointer && (reflect.PointerTo(t).Implements(marshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)))
+}
+
+// plain 은 복사할 필요 없이 그대로 json 에 넘길 수 있는 타입이다
+func plain(t reflect.Type) bool {
+	if isMarshaler(t) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct, reflect.Interface, reflect.Pointer:
+		return false
+	}
+
+	return true
+}
+
+// typeOf 는 t 의 그림자 타입이다
+func (b *builder) typeOf(t reflect.Type, global Policy) (reflect.Type, error) {
+	if plain(t) {
+		return t, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return anyType, nil
+
+	case reflect.Pointer:
+		elem, err := b.typeOf(t.Elem(), global)
+		if err != nil || elem == anyType {
+			return elem, err
+		}
+		return reflect.PointerTo(elem), nil
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && plain(t.Elem()) {
+			return t, nil // []byte 는 base64 문자열
+		}
+
+		elem, err := b.typeOf(t.Elem(), global)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(elem), nil
+
+	case reflect.Array:
+		elem, err := b.typeOf(t.Elem(), global)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.ArrayOf(t.Len(), elem), nil
+
+	case reflect.Map:
+		elem, err := b.typeOf(t.Elem(), global)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.MapOf(t.Key(), elem), nil
+
+	case reflect.Struct:
+		plan, err := b.plan(t, global)
+		if err != nil {
+			return nil, err
+		}
+		if plan == nil {
+			// 만들고 있는 중인 struct 를 다시 만났다 (재귀 타입)
+			return anyType, nil
+		}
+		return plan.typ, nil
+	}
+
+	return t, nil
+}
+
+func (b *builder) plan(t reflect.Type, global Policy) (*structPlan, error) {
+	key := planKey{typ: t, global: global}
+	if p, ok := plans.Load(key); ok {
+		return p.(*structPlan), nil
+	}
+
+	if b.building[key] {
+		return nil, nil
+	}
+	b.building[key] = true
+	defer delete(b.building, key)
+
+	fields := jsonFields(t)
+	sfs := make([]reflect.StructField, 0, len(fields))
+	fps := make([]fieldPlan, 0, len(fields))
+
+	for i, f := range fields {
+		policy := global
+		if tag, ok := f.field.Tag.Lookup("jsonpolicy"); ok {
+			p, err := ParsePolicy(tag)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t, f.field.Name, err)
+			}
+			policy = p
+		}
+
+		ft, err := b.typeOf(f.field.Type, global)
+		if err != nil {
+			return nil, err
+		}
+
+		omit := f.omitempty || (policy == EmptyAsOmit && container(f.field.Type))
+		if f.viaPtr {
+			ft = reflect.PointerTo(ft)
+			omit = true
+		}
+
+		tag := f.name
+		if omit {
+			tag += ",omitempty"
+		}
+		if f.quoted {
+			tag += ",string"
+		}
+
+		sfs = append(sfs, reflect.StructField{
+			Name: fmt.Sprintf("F%d", i),
+			Type: ft,
+			Tag:  reflect.StructTag(fmt.Sprintf("json:%q", tag)),
+		})
+		fps = append(fps, fieldPlan{index: f.index, policy: policy, viaPtr: f.viaPtr, omitempty: f.omitempty})
+	}
+
+	p := &structPlan{typ: reflect.StructOf(sfs), fields: fps}
+	actual, _ := plans.LoadOrStore(key, p)
+
+	return actual.(*structPlan), nil
+}
+
+// container 는 정책이 적용되는 타입이다 (slice, map)
+func container(t reflect.Type) bool {
+	if isMarshaler(t) {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map:
+		return true
+	}
+
+	return false
+}
+
+// value 는 v 를 그림자 타입 dst 의 값으로 복사한다. p 는 v 에 적용할 정책이다.
+func (b *builder) value(v reflect.Value, dst reflect.Type, p, global Policy) (reflect.Value, error) {
+	if !v.IsValid() {
+		return reflect.Zero(dst), nil
+	}
+
+	if dst.Kind() == reflect.Interface {
+		if v.Kind() == reflect.Interface && v.IsNil() {
+			return reflect.Zero(dst), nil
+		}
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+
+		// 재귀 타입 자리의 nil 포인터는 nil interface 로 둬야 omitempty 가 생략한다
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return reflect.Zero(dst), nil
+		}
+
+		t, err := b.typeOf(v.Type(), global)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return b.value(v, t, p, global)
+	}
+
+	if dst == v.Type() && plain(dst) {
+		return v, nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(dst), nil
+		}
+
+		elem, err := b.value(v.Elem(), dst.Elem(), p, global)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		ptr := reflect.New(dst.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+
+	case reflect.Slice:
+		if v.IsNil() {
+			if p == NilAsEmpty {
+				return reflect.MakeSlice(dst, 0, 0), nil
+			}
+			return reflect.Zero(dst), nil
+		}
+
+		if dst == v.Type() && dst.Elem().Kind() == reflect.Uint8 && plain(dst.Elem()) {
+			return v, nil // []byte
+		}
+
+		out := reflect.MakeSlice(dst, v.Len(), v.Len())
+		for i := range v.Len() {
+			elem, err := b.value(v.Index(i), dst.Elem(), p, global)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, nil
+
+	case reflect.Array:
+		out := reflect.New(dst).Elem()
+		for i := range v.Len() {
+			elem, err := b.value(v.Index(i), dst.Elem(), p, global)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, nil
+
+	case reflect.Map:
+		if v.IsNil() {
+			if p == NilAsEmpty {
+				return reflect.MakeMap(dst), nil
+			}
+			return reflect.Zero(dst), nil
+		}
+
+		out := reflect.MakeMapWithSize(dst, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			elem, err := b.value(iter.Value(), dst.Elem(), p, global)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.SetMapIndex(iter.Key(), elem)
+		}
+		return out, nil
+
+	case reflect.Struct:
+		return b.structValue(v, dst, global)
+	}
+
+	return v, nil
+}
+
+func (b *builder) structValue(v reflect.Value, dst reflect.Type, global Policy) (reflect.Value, error) {
+	plan, err := b.plan(v.Type(), global)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if plan == nil || plan.typ != dst {
+		return reflect.Value{}, fmt.Errorf("jsonpolicy: internal error: no plan for %s", v.Type())
+	}
+
+	out := reflect.New(dst).Elem()
+	for i, f := range plan.fields {
+		src, ok := fieldByIndex(v, f.index)
+		if !ok {
+			continue // nil embedded 포인터 아래의 필드
+		}
+
+		ft := dst.Field(i).Type
+		if !f.viaPtr {
+			fv, err := b.value(src, ft, f.policy, global)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Field(i).Set(fv)
+			continue
+		}
+
+		if f.omitempty && empty(src) {
+			continue
+		}
+
+		fv, err := b.value(src, ft.Elem(), f.policy, global)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		ptr := reflect.New(ft.Elem())
+		ptr.Elem().Set(fv)
+		out.Field(i).Set(ptr)
+	}
+
+	return out, nil
+}
+
+// fieldByIndex 는 embedded 포인터가 nil 이면 false 를 돌려준다
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// empty 는 encoding/json 의 omitempty 기준이다
+func empty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+
+	return false
+}
+
+// jsonField 는 encoding/json 이 struct 에서 보는 필드 하나다
+type jsonField struct {
+	name      string
+	tagged    bool
+	index     []int
+	depth     int
+	field     reflect.StructField
+	omitempty bool
+	quoted    bool
+	viaPtr    bool
+}
+
+/*
+jsonFields 는 encoding/json 과 같은 규칙으로 필드를 고른다
+
+  - unexported 필드와 json:"-" 는 제외
+  - 이름 태그가 없는 embedded struct 는 펼친다
+  - 같은 이름이 여러개면 가장 얕은 것, 그중 태그가 있는 것 하나만 남기고 결정할 수 없으면 모두 버린다
+*/
+func jsonFields(t reflect.Type) []jsonField {
+	type embedded struct {
+		typ    reflect.Type
+		index  []int
+		viaPtr bool
+	}
+
+	var fields []jsonField
+	visited := map[reflect.Type]bool{}
+	next := []embedded{{typ: t}}
+
+	for depth := 0; len(next) > 0; depth++ {
+		current := next
+		next = nil
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+
+			for i := range e.typ.NumField() {
+				sf := e.typ.Field(i)
+
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+
+				if sf.Anonymous {
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(slices.Clone(e.index), i)
+
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					next = append(next, embedded{typ: ft, index: index, viaPtr: e.viaPtr || sf.Type.Kind() == reflect.Pointer})
+					continue
+				}
+
+				f := jsonField{name: name, tagged: name != "", index: index, depth: depth, field: sf, viaPtr: e.viaPtr}
+				if f.name == "" {
+					f.name = sf.Name
+				}
+
+				for _, opt := range strings.Split(opts, ",") {
+					switch opt {
+					case "omitempty":
+						f.omitempty = true
+					case "string":
+						f.quoted = true
+					}
+				}
+
+				fields = append(fields, f)
+			}
+		}
+	}
+
+	return dominant(fields)
+}
+
+func dominant(fields []jsonField) []jsonField {
+	byName := make(map[string][]jsonField, len(fields))
+	for _, f := range fields {
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	out := make([]jsonField, 0, len(byName))
+	for _, fs := range byName {
+		minDepth := fs[0].depth
+		for _, f := range fs {
+			minDepth = min(minDepth, f.depth)
+		}
+
+		var shallow, tagged []jsonField
+		for _, f := range fs {
+			if f.depth == minDepth {
+				shallow = append(shallow, f)
+				if f.tagged {
+					tagged = append(tagged, f)
+				}
+			}
+		}
+
+		switch {
+		case len(shallow) == 1:
+			out = append(out, shallow[0])
+		case len(tagged) == 1:
+			out = append(out, tagged[0])
+		}
+	}
+
+	slices.SortFunc(out, func(a, b jsonField) int {
+		return slices.Compare(a.index, b.index)
+	})
+
+	return out
+}
@@ -0,0 +1,137 @@
+package jsonpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+/*
+16.go 에서 본 것처럼 encoding/json 은 nil slice 를 null, empty slice 를 [] 로 쓴다.
+null 을 처리하지 못하는 API 클라이언트를 위해 nil/empty slice (와 map) 를 어떻게 쓸지 정하는 encoding/json 래퍼다.
+
+	type User struct {
+		Names  []string `json:"names" jsonpolicy:"nil-as-empty"`  // null 대신 []
+		Tags   []string `json:"tags" jsonpolicy:"empty-as-omit"`  // nil, [] 모두 생략
+		Groups []string `json:"groups"`                           // 전역 정책을 따른다
+	}
+
+	data, err := jsonpolicy.Marshal(u, jsonpolicy.WithPolicy(jsonpolicy.NilAsEmpty))
+
+- 필드 태그가 전역 정책보다 우선한다
+- 필드의 정책은 그 필드 안의 slice, map 원소 ([][]string 의 안쪽) 까지 적용되고, 중첩 struct 의 필드는 자기 태그나 전역 정책을 따른다
+- empty-as-omit 은 struct 필드에서만 생략할 수 있다. slice 원소나 map 값 위치에서는 nil-as-null 처럼 쓴다
+- json.Marshaler, encoding.TextMarshaler 를 구현한 타입은 건드리지 않는다
+- 원래 omitempty 가 있는 필드는 nil-as-empty 여도 생략된다
+*/
+
+type Policy int
+
+const (
+	// NilAsNull 은 encoding/json 과 같다: nil -> null, empty -> []
+	NilAsNull Policy = iota
+	// NilAsEmpty 는 nil 도 [] (map 은 {}) 로 쓴다
+	NilAsEmpty
+	// EmptyAsOmit 은 nil 과 empty 모두 필드를 생략한다 (omitempty)
+	EmptyAsOmit
+)
+
+var policyNames = map[Policy]string{
+	NilAsNull:   "nil-as-null",
+	NilAsEmpty:  "nil-as-empty",
+	EmptyAsOmit: "empty-as-omit",
+}
+
+func (p Policy) String() string {
+	if name, ok := policyNames[p]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Policy(%d)", int(p))
+}
+
+// ParsePolicy 는 태그나 설정에 쓰는 이름 (nil-as-null, nil-as-empty, empty-as-omit) 을 읽는다
+func ParsePolicy(s string) (Policy, error) {
+	for p, name := range policyNames {
+		if name == s {
+			return p, nil
+		}
+	}
+
+	return 0, fmt.Errorf("jsonpolicy: unknown policy %q (want nil-as-null, nil-as-empty or empty-as-omit)", s)
+}
+
+type options struct {
+	policy Policy
+	indent string
+	prefix string
+}
+
+type Option func(*options)
+
+// WithPolicy 는 태그가 없는 필드에 쓸 전역 정책이다 (기본은 NilAsNull)
+func WithPolicy(p Policy) Option {
+	return func(o *options) {
+		o.policy = p
+	}
+}
+
+func WithIndent(prefix, indent string) Option {
+	return func(o *options) {
+		o.prefix, o.indent = prefix, indent
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Marshal 은 정책을 적용한 뒤 json.Marshal 한다
+func Marshal(v any, opts ...Option) ([]byte, error) {
+	o := newOptions(opts)
+
+	converted, err := convert(v, o.policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.indent != "" || o.prefix != "" {
+		return json.MarshalIndent(converted, o.prefix, o.indent)
+	}
+
+	return json.Marshal(converted)
+}
+
+// Encoder 는 json.Encoder 처럼 스트림에 값을 하나씩 쓴다
+type Encoder struct {
+	w    io.Writer
+	opts options
+}
+
+func NewEncoder(w io.Writer, opts ...Option) *Encoder {
+	return &Encoder{w: w, opts: newOptions(opts)}
+}
+
+// Encode 는 json.Encoder.Encode 와 같이 값 뒤에 줄바꿈을 쓴다
+func (e *Encoder) Encode(v any) error {
+	converted, err := convert(v, e.opts.policy)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent(e.opts.prefix, e.opts.indent)
+	if err := enc.Encode(converted); err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,271 @@
+package jsonpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 16.go 의 GetUserNilSlice, GetUserEmptySlice 가 돌려주는 값
+type User struct {
+	Name   string   `json:"name"`
+	Names  []string `json:"names"`
+	Tags   []string `json:"tags,omitempty"`
+	Scores map[string]int
+}
+
+func TestGlobalPolicy(t *testing.T) {
+	tests := []struct {
+		policy Policy
+		user   User
+		want   string
+	}{
+		{NilAsNull, User{Name: "a"}, `{"name":"a","names":null,"Scores":null}`},
+		{NilAsNull, User{Name: "a", Names: []string{}, Scores: map[string]int{}}, `{"name":"a","names":[],"Scores":{}}`},
+		{NilAsEmpty, User{Name: "a"}, `{"name":"a","names":[],"Scores":{}}`},
+		{NilAsEmpty, User{Name: "a", Names: []string{"x"}}, `{"name":"a","names":["x"],"Scores":{}}`},
+		{EmptyAsOmit, User{Name: "a"}, `{"name":"a"}`},
+		{EmptyAsOmit, User{Name: "a", Names: []string{}, Scores: map[string]int{}}, `{"name":"a"}`},
+		{EmptyAsOmit, User{Name: "a", Names: []string{"x"}}, `{"name":"a","names":["x"]}`},
+	}
+
+	for _, tt := range tests {
+		got, err := Marshal(tt.user, WithPolicy(tt.policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(got) != tt.want {
+			t.Errorf("%s: Marshal(%+v) = %s, want %s", tt.policy, tt.user, got, tt.want)
+		}
+	}
+}
+
+// 기본 정책은 encoding/json 과 같다
+func TestDefaultMatchesEncodingJSON(t *testing.T) {
+	type inner struct {
+		A []int `json:"a,omitempty"`
+		B *int  `json:",omitempty"`
+		C int   `json:"c,string"`
+	}
+
+	type embedded struct {
+		E  string
+		ID int `json:"id"`
+	}
+
+	type value struct {
+		embedded
+		*inner
+		ID      string `json:"id"` // embedded 의 id 보다 얕으므로 이긴다
+		Skip    string `json:"-"`
+		private int
+		Any     any
+		Arr     [2][]int
+		Bytes   []byte
+		Time    time.Time
+		Nested  map[string][]inner
+	}
+
+	vals := []value{
+		{},
+		{inner: &inner{C: 3}, ID: "x", Any: []string(nil), Bytes: []byte("hi"), Nested: map[string][]inner{"k": {{A: []int{1}}}}},
+	}
+
+	for _, v := range vals {
+		want, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Errorf("Marshal = %s\nencoding/json = %s", got, want)
+		}
+	}
+}
+
+type Item struct {
+	ID   string   `json:"id"`
+	Tags []string `json:"tags"`
+}
+
+type Order struct {
+	Items    []Item            `json:"items" jsonpolicy:"nil-as-empty"`
+	Notes    []string          `json:"notes" jsonpolicy:"empty-as-omit"`
+	Meta     map[string]string `json:"meta" jsonpolicy:"nil-as-null"`
+	Children [][]string        `json:"children" jsonpolicy:"nil-as-empty"`
+	ByID     map[string]Item   `json:"by_id"`
+}
+
+func TestFieldTags(t *testing.T) {
+	o := Order{
+		Children: [][]string{nil, {"a"}},
+		ByID:     map[string]Item{"1": {ID: "1"}},
+	}
+
+	// 태그가 전역 정책보다 우선하고, 중첩 struct (Item) 의 필드는 전역 정책을 따른다
+	got, err := Marshal(o, WithPolicy(EmptyAsOmit))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"items":[],"meta":null,"children":[[],["a"]],"by_id":{"1":{"id":"1"}}}`
+	if string(got) != want {
+		t.Errorf("Marshal = %s\nwant      %s", got, want)
+	}
+
+	got, err = Marshal(o, WithPolicy(NilAsEmpty))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = `{"items":[],"meta":null,"children":[[],["a"]],"by_id":{"1":{"id":"1","tags":[]}}}`
+	if string(got) != want {
+		t.Errorf("Marshal = %s\nwant      %s", got, want)
+	}
+}
+
+// 제네릭 컨테이너도 인스턴스화된 struct 일 뿐이다
+type Page[T any] struct {
+	Items []T          `json:"items" jsonpolicy:"nil-as-empty"`
+	Next  *Page[T]     `json:"next,omitempty"`
+	Index map[string]T `json:"index"`
+}
+
+func TestGeneric(t *testing.T) {
+	p := Page[Item]{Next: &Page[Item]{Items: []Item{{ID: "a"}}}}
+
+	got, err := Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"items":[],"next":{"items":[{"id":"a","tags":null}],"index":null},"index":null}`
+	if string(got) != want {
+		t.Errorf("Marshal = %s\nwant      %s", got, want)
+	}
+}
+
+type Node struct {
+	Name     string  `json:"name"`
+	Children []*Node `json:"children"`
+}
+
+func TestRecursive(t *testing.T) {
+	n := &Node{Name: "root", Children: []*Node{{Name: "leaf"}}}
+
+	got, err := Marshal(n, WithPolicy(NilAsEmpty))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"name":"root","children":[{"name":"leaf","children":[]}]}`
+	if string(got) != want {
+		t.Errorf("Marshal = %s\nwant      %s", got, want)
+	}
+}
+
+type custom []string
+
+func (custom) MarshalJSON() ([]byte, error) { return []byte(`"custom"`), nil }
+
+func TestMarshalerUntouched(t *testing.T) {
+	v := struct {
+		C custom    `json:"c"`
+		T time.Time `json:"t"`
+	}{}
+
+	got, err := Marshal(v, WithPolicy(EmptyAsOmit))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"c":"custom","t":"0001-01-01T00:00:00Z"}`; string(got) != want {
+		t.Errorf("Marshal = %s, want %s", got, want)
+	}
+}
+
+func TestInvalidTag(t *testing.T) {
+	v := struct {
+		S []string `jsonpolicy:"nil-as-zero"`
+	}{}
+
+	if _, err := Marshal(v); err == nil || !strings.Contains(err.Error(), `unknown policy "nil-as-zero"`) {
+		t.Errorf("Marshal = %v, want unknown policy error", err)
+	}
+}
+
+// round trip: 정책으로 쓴 JSON 을 encoding/json 으로 다시 읽었을 때 각 정책의 의미가 유지되는지
+func TestRoundTrip(t *testing.T) {
+	in := Order{Notes: []string{}, ByID: map[string]Item{"1": {ID: "1", Tags: []string{}}}}
+
+	tests := []struct {
+		policy Policy
+		check  func(t *testing.T, out Order)
+	}{
+		{NilAsNull, func(t *testing.T, out Order) {
+			if out.Items == nil || len(out.Items) != 0 {
+				t.Errorf("Items = %#v, want empty (tag nil-as-empty)", out.Items)
+			}
+			if out.Meta != nil || out.ByID["1"].Tags == nil {
+				t.Errorf("Meta = %#v, ByID = %#v", out.Meta, out.ByID)
+			}
+		}},
+		{NilAsEmpty, func(t *testing.T, out Order) {
+			if out.Children == nil || out.ByID["1"].Tags == nil {
+				t.Errorf("Children = %#v, ByID = %#v, want empty non-nil", out.Children, out.ByID)
+			}
+		}},
+		{EmptyAsOmit, func(t *testing.T, out Order) {
+			if out.Notes != nil || out.ByID["1"].Tags != nil {
+				t.Errorf("Notes = %#v, ByID = %#v, want omitted (nil)", out.Notes, out.ByID)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewEncoder(&buf, WithPolicy(tt.policy)).Encode(in); err != nil {
+				t.Fatal(err)
+			}
+
+			var out Order
+			if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+				t.Fatal(err)
+			}
+
+			tt.check(t, out)
+
+			// 한번 더 돌려도 같은 JSON 이 나와야 한다
+			again, err := Marshal(out, WithPolicy(tt.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(strings.TrimSpace(buf.String()), string(again)) {
+				t.Errorf("second round = %s, first = %s", again, buf.String())
+			}
+		})
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	for _, p := range []Policy{NilAsNull, NilAsEmpty, EmptyAsOmit} {
+		got, err := ParsePolicy(p.String())
+		if err != nil || got != p {
+			t.Errorf("ParsePolicy(%q) = %v, %v", p, got, err)
+		}
+	}
+
+	if _, err := ParsePolicy("null"); err == nil {
+		t.Error("ParsePolicy(null) = nil error")
+	}
+}
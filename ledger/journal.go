@@ -0,0 +1,111 @@
+package ledger
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindOpen     Kind = "open"     // To 계좌를 연다. Amount 는 overdraft 한도다
+	KindDeposit  Kind = "deposit"  // To 에 Amount 를 더한다
+	KindWithdraw Kind = "withdraw" // From 에서 Amount 를 뺀다
+	KindTransfer Kind = "transfer" // From 에서 To 로 Amount 를 옮긴다
+)
+
+// Entry 는 성공한 거래 하나다. Seq 는 1 부터 빠짐없이 늘어난다 (journal 에 실패한 번호만 빠진다).
+type Entry struct {
+	Seq    uint64    `json:"seq"`
+	Kind   Kind      `json:"kind"`
+	From   AccountID `json:"from,omitempty"`
+	To     AccountID `json:"to,omitempty"`
+	Amount int64     `json:"amount"`
+	At     time.Time `json:"at"`
+}
+
+/*
+Journal 은 거래를 덧붙이기만 하는 기록이다.
+Append 는 계좌를 잠근 채로 불리므로, 서로 다른 계좌의 거래는 Seq 순서와 다르게 들어올 수 있다.
+같은 계좌의 거래는 항상 Seq 순서로 들어온다. Replay 는 Seq 로 정렬해서 적용한다.
+*/
+type Journal interface {
+	Append(e Entry) error
+}
+
+// MemoryJournal 은 메모리에 남기는 Journal 이다
+type MemoryJournal struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+func (j *MemoryJournal) Append(e Entry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries = append(j.entries, e)
+	return nil
+}
+
+// Entries 는 지금까지의 거래를 Seq 순서로 복사해서 돌려준다
+func (j *MemoryJournal) Entries() []Entry {
+	j.mu.Lock()
+	entries := slices.Clone(j.entries)
+	j.mu.Unlock()
+
+	sortEntries(entries)
+	return entries
+}
+
+// JSONJournal 은 거래를 한 줄에 하나씩 JSON 으로 쓴다 (파일에 덧붙이기)
+type JSONJournal struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewJSONJournal(w io.Writer) *JSONJournal {
+	return &JSONJournal{enc: json.NewEncoder(w)}
+}
+
+func (j *JSONJournal) Append(e Entry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.enc.Encode(e)
+}
+
+// ReadJournal 은 JSONJournal 이 쓴 거래를 읽어서 Seq 순서로 돌려준다
+func ReadJournal(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("ledger: journal line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("ledger: read journal: %w", err)
+	}
+
+	sortEntries(entries)
+	return entries, nil
+}
+
+func sortEntries(entries []Entry) {
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+}
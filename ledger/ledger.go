@@ -0,0 +1,325 @@
+package ledger
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/*
+21.go 는 전역 []Account 를 index 로 바꾸고 (balanceB) 아무 동기화도 하지 않는다.
+여러 goroutine 이 잔액을 바꿔도 안전한 계좌 원장이다.
+
+	l := ledger.New(ledger.WithJournal(journal))
+	l.Open("kim", ledger.WithOverdraft(10_000))
+	l.Deposit("kim", 1000)
+	l.Transfer("kim", "lee", 500) // 두 계좌가 한번에 바뀐다
+
+- 계좌마다 Mutex 가 있고, Transfer 는 두 계좌를 ID 순서로 잠근다 (교착 상태가 없다)
+- 잔액은 -overdraft 한도 아래로 내려가지 않는다 (기본 한도 0)
+- 성공한 거래만 journal 에 순서 번호 (Seq) 와 함께 남는다. 잔액을 바꾸기 전에 남기므로 journal 에 실패하면 거래도 없다
+- Snapshot 은 모든 거래를 잠깐 멈추고 찍는다. Restore 는 Snapshot 과 그 뒤의 journal 로 원장을 다시 만든다
+*/
+
+var (
+	ErrUnknownAccount    = errors.New("ledger: unknown account")
+	ErrDuplicateAccount  = errors.New("ledger: duplicate account")
+	ErrInsufficientFunds = errors.New("ledger: insufficient funds")
+	ErrInvalidAmount     = errors.New("ledger: invalid amount")
+	ErrSameAccount       = errors.New("ledger: transfer to the same account")
+)
+
+type AccountID string
+
+// Account 는 한 시점의 계좌다
+type Account struct {
+	ID        AccountID `json:"id"`
+	Balance   int64     `json:"balance"`
+	Overdraft int64     `json:"overdraft"` // 잔액은 -Overdraft 까지 내려갈 수 있다
+}
+
+type account struct {
+	mu sync.Mutex
+	Account
+}
+
+type Ledger struct {
+	// mu 는 Open, Snapshot 이 Lock 으로, 거래는 RLock 으로 잡는다. 계좌별 잠금은 그 안에서 잡는다.
+	mu       sync.RWMutex
+	accounts map[AccountID]*account
+
+	seq     atomic.Uint64
+	journal Journal
+	now     func() time.Time
+}
+
+type options struct {
+	journal Journal
+	now     func() time.Time
+}
+
+type Option func(*options)
+
+// WithJournal 은 거래를 남길 journal 이다 (기본은 남기지 않는다)
+func WithJournal(j Journal) Option {
+	return func(o *options) {
+		o.journal = j
+	}
+}
+
+// WithClock 은 Entry.At 에 쓸 시각이다 (기본 time.Now)
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+func New(opts ...Option) *Ledger {
+	o := options{now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Ledger{
+		accounts: map[AccountID]*account{},
+		journal:  o.journal,
+		now:      o.now,
+	}
+}
+
+type AccountOption func(*Account)
+
+// WithOverdraft 는 잔액이 -limit 까지 내려갈 수 있게 한다
+func WithOverdraft(limit int64) AccountOption {
+	return func(a *Account) {
+		a.Overdraft = limit
+	}
+}
+
+func (l *Ledger) Open(id AccountID, opts ...AccountOption) (Entry, error) {
+	acc := Account{ID: id}
+	for _, opt := range opts {
+		opt(&acc)
+	}
+	if acc.Overdraft < 0 {
+		return Entry{}, fmt.Errorf("ledger: open %s with overdraft %d: %w", id, acc.Overdraft, ErrInvalidAmount)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.accounts[id]; ok {
+		return Entry{}, fmt.Errorf("%w: %s", ErrDuplicateAccount, id)
+	}
+
+	e, err := l.record(Entry{Kind: KindOpen, To: id, Amount: acc.Overdraft})
+	if err != nil {
+		return Entry{}, err
+	}
+
+	l.accounts[id] = &account{Account: acc}
+	return e, nil
+}
+
+func (l *Ledger) Deposit(id AccountID, amount int64) (Entry, error) {
+	if amount <= 0 {
+		return Entry{}, fmt.Errorf("ledger: deposit %d to %s: %w", amount, id, ErrInvalidAmount)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	acc, err := l.lookup(id)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	if err := acc.canDeposit(amount); err != nil {
+		return Entry{}, err
+	}
+
+	e, err := l.record(Entry{Kind: KindDeposit, To: id, Amount: amount})
+	if err != nil {
+		return Entry{}, err
+	}
+
+	acc.Balance += amount
+	return e, nil
+}
+
+func (l *Ledger) Withdraw(id AccountID, amount int64) (Entry, error) {
+	if amount <= 0 {
+		return Entry{}, fmt.Errorf("ledger: withdraw %d from %s: %w", amount, id, ErrInvalidAmount)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	acc, err := l.lookup(id)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	if err := acc.canWithdraw(amount); err != nil {
+		return Entry{}, err
+	}
+
+	e, err := l.record(Entry{Kind: KindWithdraw, From: id, Amount: amount})
+	if err != nil {
+		return Entry{}, err
+	}
+
+	acc.Balance -= amount
+	return e, nil
+}
+
+// Transfer 는 from 에서 빼고 to 에 더하는 것을 한번에 한다. 다른 goroutine 은 중간 상태를 보지 못한다.
+func (l *Ledger) Transfer(from, to AccountID, amount int64) (Entry, error) {
+	if amount <= 0 {
+		return Entry{}, fmt.Errorf("ledger: transfer %d from %s to %s: %w", amount, from, to, ErrInvalidAmount)
+	}
+	if from == to {
+		return Entry{}, fmt.Errorf("%w: %s", ErrSameAccount, from)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	src, err := l.lookup(from)
+	if err != nil {
+		return Entry{}, err
+	}
+	dst, err := l.lookup(to)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	// 항상 ID 순서로 잠가서 A->B, B->A 가 동시에 와도 서로 기다리지 않는다
+	first, second := src, dst
+	if to < from {
+		first, second = dst, src
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+
+	if err := src.canWithdraw(amount); err != nil {
+		return Entry{}, err
+	}
+	if err := dst.canDeposit(amount); err != nil {
+		return Entry{}, err
+	}
+
+	e, err := l.record(Entry{Kind: KindTransfer, From: from, To: to, Amount: amount})
+	if err != nil {
+		return Entry{}, err
+	}
+
+	src.Balance -= amount
+	dst.Balance += amount
+	return e, nil
+}
+
+func (l *Ledger) Account(id AccountID) (Account, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	acc, err := l.lookup(id)
+	if err != nil {
+		return Account{}, err
+	}
+
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	return acc.Account, nil
+}
+
+func (l *Ledger) Balance(id AccountID) (int64, error) {
+	acc, err := l.Account(id)
+	return acc.Balance, err
+}
+
+// Total 은 모든 계좌의 잔액 합이다. Transfer 로는 바뀌지 않는다.
+func (l *Ledger) Total() int64 {
+	var total int64
+	for _, acc := range l.Snapshot().Accounts {
+		total += acc.Balance
+	}
+
+	return total
+}
+
+func (l *Ledger) lookup(id AccountID) (*account, error) {
+	acc, ok := l.accounts[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAccount, id)
+	}
+
+	return acc, nil
+}
+
+// record 는 거래에 순서 번호를 붙여 journal 에 남긴다. 관련된 계좌를 잠근 채로 불러야 한다.
+func (l *Ledger) record(e Entry) (Entry, error) {
+	e.Seq = l.seq.Add(1)
+	e.At = l.now()
+
+	if l.journal != nil {
+		if err := l.journal.Append(e); err != nil {
+			return Entry{}, fmt.Errorf("ledger: journal %s %d: %w", e.Kind, e.Seq, err)
+		}
+	}
+
+	return e, nil
+}
+
+func (a *account) canWithdraw(amount int64) error {
+	// Balance-amount < -Overdraft 를 넘침 없이 비교한다 (amount, Overdraft >= 0)
+	if a.Balance < amount-a.Overdraft {
+		return fmt.Errorf("ledger: withdraw %d from %s (balance %d, overdraft %d): %w", amount, a.ID, a.Balance, a.Overdraft, ErrInsufficientFunds)
+	}
+
+	return nil
+}
+
+func (a *account) canDeposit(amount int64) error {
+	if a.Balance > math.MaxInt64-amount {
+		return fmt.Errorf("ledger: deposit %d to %s overflows balance %d: %w", amount, a.ID, a.Balance, ErrInvalidAmount)
+	}
+
+	return nil
+}
+
+// Snapshot 은 한 시점의 모든 계좌와 그 시점까지의 마지막 Seq 다
+type Snapshot struct {
+	Seq      uint64    `json:"seq"`
+	Accounts []Account `json:"accounts"`
+}
+
+// Snapshot 은 거래를 잠깐 멈추고 모든 계좌를 ID 순서로 복사한다
+func (l *Ledger) Snapshot() Snapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s := Snapshot{Seq: l.seq.Load(), Accounts: make([]Account, 0, len(l.accounts))}
+	for _, acc := range l.accounts {
+		s.Accounts = append(s.Accounts, acc.Account)
+	}
+	slices.SortFunc(s.Accounts, func(a, b Account) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return s
+}
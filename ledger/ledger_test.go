@@ -0,0 +1,322 @@
+package ledger
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func fixedClock() time.Time { return epoch }
+
+// newLedger 는 kim (overdraft 100), lee, park 계좌를 열고 kim 에 1000 을 넣은 원장이다
+func newLedger(t *testing.T, opts ...Option) *Ledger {
+	t.Helper()
+
+	l := New(append([]Option{WithClock(fixedClock)}, opts...)...)
+	must(t)(l.Open("kim", WithOverdraft(100)))
+	must(t)(l.Open("lee"))
+	must(t)(l.Open("park"))
+	must(t)(l.Deposit("kim", 1000))
+
+	return l
+}
+
+// must 는 must(t)(l.Deposit(...)) 처럼 거래가 실패하면 테스트를 멈춘다
+func must(t *testing.T) func(Entry, error) {
+	return func(_ Entry, err error) {
+		t.Helper()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func balances(t *testing.T, l *Ledger) map[AccountID]int64 {
+	t.Helper()
+
+	got := map[AccountID]int64{}
+	for _, acc := range l.Snapshot().Accounts {
+		got[acc.ID] = acc.Balance
+	}
+	return got
+}
+
+func TestOperations(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      func(l *Ledger) (Entry, error)
+		wantErr error
+		want    map[AccountID]int64
+	}{
+		{
+			name: "deposit",
+			op:   func(l *Ledger) (Entry, error) { return l.Deposit("lee", 50) },
+			want: map[AccountID]int64{"kim": 1000, "lee": 50, "park": 0},
+		},
+		{
+			name: "withdraw",
+			op:   func(l *Ledger) (Entry, error) { return l.Withdraw("kim", 300) },
+			want: map[AccountID]int64{"kim": 700, "lee": 0, "park": 0},
+		},
+		{
+			name: "withdraw into overdraft",
+			op:   func(l *Ledger) (Entry, error) { return l.Withdraw("kim", 1100) },
+			want: map[AccountID]int64{"kim": -100, "lee": 0, "park": 0},
+		},
+		{
+			name:    "withdraw past overdraft",
+			op:      func(l *Ledger) (Entry, error) { return l.Withdraw("kim", 1101) },
+			wantErr: ErrInsufficientFunds,
+		},
+		{
+			name:    "withdraw without overdraft",
+			op:      func(l *Ledger) (Entry, error) { return l.Withdraw("lee", 1) },
+			wantErr: ErrInsufficientFunds,
+		},
+		{
+			name: "transfer",
+			op:   func(l *Ledger) (Entry, error) { return l.Transfer("kim", "lee", 400) },
+			want: map[AccountID]int64{"kim": 600, "lee": 400, "park": 0},
+		},
+		{
+			name: "transfer into overdraft",
+			op:   func(l *Ledger) (Entry, error) { return l.Transfer("kim", "park", 1100) },
+			want: map[AccountID]int64{"kim": -100, "lee": 0, "park": 1100},
+		},
+		{
+			name:    "transfer past overdraft",
+			op:      func(l *Ledger) (Entry, error) { return l.Transfer("kim", "lee", 1101) },
+			wantErr: ErrInsufficientFunds,
+		},
+		{
+			name:    "transfer to the same account",
+			op:      func(l *Ledger) (Entry, error) { return l.Transfer("kim", "kim", 1) },
+			wantErr: ErrSameAccount,
+		},
+		{
+			name:    "transfer to an unknown account",
+			op:      func(l *Ledger) (Entry, error) { return l.Transfer("kim", "choi", 1) },
+			wantErr: ErrUnknownAccount,
+		},
+		{
+			name:    "zero amount",
+			op:      func(l *Ledger) (Entry, error) { return l.Deposit("kim", 0) },
+			wantErr: ErrInvalidAmount,
+		},
+		{
+			name:    "negative amount",
+			op:      func(l *Ledger) (Entry, error) { return l.Transfer("lee", "kim", -10) },
+			wantErr: ErrInvalidAmount,
+		},
+		{
+			name:    "overflow",
+			op:      func(l *Ledger) (Entry, error) { return l.Deposit("kim", math.MaxInt64) },
+			wantErr: ErrInvalidAmount,
+		},
+		{
+			name:    "duplicate account",
+			op:      func(l *Ledger) (Entry, error) { return l.Open("lee") },
+			wantErr: ErrDuplicateAccount,
+		},
+		{
+			name:    "negative overdraft",
+			op:      func(l *Ledger) (Entry, error) { return l.Open("choi", WithOverdraft(-1)) },
+			wantErr: ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &MemoryJournal{}
+			l := newLedger(t, WithJournal(j))
+			before := balances(t, l)
+
+			e, err := tt.op(l)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			want := tt.want
+			if tt.wantErr != nil {
+				// 실패한 거래는 잔액도 journal 도 바꾸지 않는다
+				want = before
+				if n := len(j.Entries()); n != 4 {
+					t.Errorf("journal has %d entries after a failed operation, want 4", n)
+				}
+			} else if e.Seq != 5 || !e.At.Equal(epoch) {
+				t.Errorf("entry = %+v, want Seq 5 at %v", e, epoch)
+			}
+
+			got := balances(t, l)
+			if len(got) != len(want) {
+				t.Fatalf("balances = %v, want %v", got, want)
+			}
+			for id, b := range want {
+				if got[id] != b {
+					t.Errorf("balance %s = %d, want %d", id, got[id], b)
+				}
+			}
+		})
+	}
+}
+
+type failingJournal struct{ err error }
+
+func (j failingJournal) Append(Entry) error { return j.err }
+
+// journal 에 남기지 못한 거래는 하지 않는다
+func TestJournalFailure(t *testing.T) {
+	l := newLedger(t)
+	l.journal = failingJournal{err: errors.New("disk full")}
+
+	if _, err := l.Transfer("kim", "lee", 10); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("Transfer err = %v, want disk full", err)
+	}
+	if _, err := l.Open("choi"); err == nil {
+		t.Fatal("Open succeeded without journal")
+	}
+
+	if b, _ := l.Balance("kim"); b != 1000 {
+		t.Errorf("kim = %d after a failed transfer, want 1000", b)
+	}
+	if _, err := l.Account("choi"); !errors.Is(err, ErrUnknownAccount) {
+		t.Errorf("Account(choi) err = %v, want %v", err, ErrUnknownAccount)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	var buf bytes.Buffer
+	l := newLedger(t, WithJournal(NewJSONJournal(&buf)))
+	must(t)(l.Transfer("kim", "lee", 700))
+	must(t)(l.Withdraw("lee", 200))
+	must(t)(l.Transfer("kim", "park", 350))
+
+	entries, err := ReadJournal(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 7 {
+		t.Fatalf("read %d entries, want 7", len(entries))
+	}
+
+	r, err := Replay(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := l.Snapshot()
+	if got := r.Snapshot(); got.Seq != want.Seq || !slices.Equal(got.Accounts, want.Accounts) {
+		t.Fatalf("replayed %+v, want %+v", got, want)
+	}
+
+	// 다시 만든 원장의 새 거래는 다음 번호를 받는다
+	e, err := r.Deposit("park", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Seq != 8 {
+		t.Errorf("Seq after replay = %d, want 8", e.Seq)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	j := &MemoryJournal{}
+	l := newLedger(t, WithJournal(j))
+	must(t)(l.Transfer("kim", "lee", 500))
+
+	snap := l.Snapshot()
+
+	must(t)(l.Open("choi", WithOverdraft(50)))
+	must(t)(l.Transfer("choi", "park", 50))
+	must(t)(l.Transfer("lee", "kim", 100))
+
+	// Snapshot 이전의 거래는 건너뛴다
+	r, err := Restore(snap, j.Entries())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := l.Snapshot()
+	if got := r.Snapshot(); got.Seq != want.Seq || !slices.Equal(got.Accounts, want.Accounts) {
+		t.Fatalf("restored %+v, want %+v", got, want)
+	}
+}
+
+func TestRestoreCorrupt(t *testing.T) {
+	open := func(seq uint64, id AccountID, overdraft int64) Entry {
+		return Entry{Seq: seq, Kind: KindOpen, To: id, Amount: overdraft}
+	}
+
+	tests := []struct {
+		name    string
+		entries []Entry
+		wantErr error
+	}{
+		{
+			name: "overdraft",
+			entries: []Entry{
+				open(1, "kim", 10),
+				{Seq: 2, Kind: KindWithdraw, From: "kim", Amount: 11},
+			},
+			wantErr: ErrInsufficientFunds,
+		},
+		{
+			name: "unknown account",
+			entries: []Entry{
+				open(1, "kim", 0),
+				{Seq: 2, Kind: KindTransfer, From: "kim", To: "lee", Amount: 1},
+			},
+			wantErr: ErrUnknownAccount,
+		},
+		{
+			name:    "duplicate seq",
+			entries: []Entry{open(1, "kim", 0), open(1, "lee", 0)},
+		},
+		{
+			name:    "duplicate account",
+			entries: []Entry{open(1, "kim", 0), open(2, "kim", 0)},
+			wantErr: ErrDuplicateAccount,
+		},
+		{
+			name:    "unknown kind",
+			entries: []Entry{open(1, "kim", 0), {Seq: 2, Kind: "refund", To: "kim", Amount: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Replay(tt.entries)
+			if !errors.Is(err, ErrCorruptJournal) {
+				t.Fatalf("err = %v, want %v", err, ErrCorruptJournal)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadJournal(t *testing.T) {
+	// 계좌가 다른 거래는 Seq 순서와 다르게 쓰일 수 있다
+	in := `{"seq":2,"kind":"deposit","to":"kim","amount":5,"at":"2024-01-02T03:04:05Z"}
+
+{"seq":1,"kind":"open","to":"kim","amount":0,"at":"2024-01-02T03:04:05Z"}
+`
+	entries, err := ReadJournal(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Seq != 1 || entries[1].Seq != 2 {
+		t.Fatalf("entries = %+v, want Seq 1, 2", entries)
+	}
+
+	if _, err := ReadJournal(strings.NewReader(in + "{\"seq\":\n")); err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Errorf("err = %v, want an error at line 4", err)
+	}
+}
@@ -0,0 +1,234 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"testing/quick"
+)
+
+var ids = []AccountID{"a", "b", "c", "d", "e"}
+
+// op 은 testing/quick 이 만드는 임의의 거래 하나다. 실패하는 거래 (잔액 부족, 없는 계좌) 도 섞인다.
+type op struct {
+	Kind     Kind
+	From, To AccountID
+	Amount   int64
+}
+
+type ops []op
+
+func (ops) Generate(r *rand.Rand, size int) reflect.Value {
+	kinds := []Kind{KindOpen, KindDeposit, KindWithdraw, KindTransfer, KindTransfer}
+
+	s := make(ops, r.Intn(size*4+1))
+	for i := range s {
+		s[i] = op{
+			Kind:   kinds[r.Intn(len(kinds))],
+			From:   ids[r.Intn(len(ids))],
+			To:     ids[r.Intn(len(ids))],
+			Amount: r.Int63n(200) - 10, // 가끔 0, 음수
+		}
+	}
+	return reflect.ValueOf(s)
+}
+
+func (o op) do(l *Ledger) (Entry, error) {
+	switch o.Kind {
+	case KindOpen:
+		return l.Open(o.To, WithOverdraft(max(o.Amount, 0)))
+	case KindDeposit:
+		return l.Deposit(o.To, o.Amount)
+	case KindWithdraw:
+		return l.Withdraw(o.From, o.Amount)
+	default:
+		return l.Transfer(o.From, o.To, o.Amount)
+	}
+}
+
+func quickConfig(t *testing.T) *quick.Config {
+	n := 500
+	if testing.Short() {
+		n = 50
+	}
+
+	seed := rand.Int63()
+	t.Logf("seed %d", seed)
+	return &quick.Config{MaxCount: n, Rand: rand.New(rand.NewSource(seed))}
+}
+
+/*
+어떤 거래 순서든:
+  - 잔액 합은 성공한 deposit 합 - withdraw 합이다 (transfer 는 합을 바꾸지 않는다)
+  - 어느 계좌도 -overdraft 아래로 내려가지 않는다
+  - journal 을 Replay 하면, 또 중간 Snapshot 에 나머지 journal 을 Restore 하면 같은 원장이 된다
+*/
+func TestPropertyInvariants(t *testing.T) {
+	property := func(s ops) bool {
+		j := &MemoryJournal{}
+		l := New(WithJournal(j), WithClock(fixedClock))
+
+		var total int64
+		var mid Snapshot
+		for i, o := range s {
+			if i == len(s)/2 {
+				mid = l.Snapshot()
+			}
+
+			if _, err := o.do(l); err != nil {
+				continue
+			}
+			switch o.Kind {
+			case KindDeposit:
+				total += o.Amount
+			case KindWithdraw:
+				total -= o.Amount
+			}
+		}
+
+		final := l.Snapshot()
+		if err := checkSnapshot(final, total); err != nil {
+			t.Log(err)
+			return false
+		}
+
+		entries := j.Entries()
+		if final.Seq != uint64(len(entries)) {
+			t.Logf("Seq %d, journal has %d entries", final.Seq, len(entries))
+			return false
+		}
+
+		replayed, err := Replay(entries)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		restored, err := Restore(mid, entries)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+
+		for name, r := range map[string]*Ledger{"replay": replayed, "restore": restored} {
+			got := r.Snapshot()
+			if got.Seq != final.Seq || !slices.Equal(got.Accounts, final.Accounts) {
+				t.Logf("%s = %+v, want %+v", name, got, final)
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, quickConfig(t)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkSnapshot(s Snapshot, total int64) error {
+	var sum int64
+	for _, acc := range s.Accounts {
+		if acc.Balance < -acc.Overdraft {
+			return fmt.Errorf("%s balance %d is below overdraft %d", acc.ID, acc.Balance, acc.Overdraft)
+		}
+		sum += acc.Balance
+	}
+	if sum != total {
+		return fmt.Errorf("total %d, want %d", sum, total)
+	}
+
+	return nil
+}
+
+/*
+여러 goroutine 이 같은 계좌들 사이로 동시에 (A->B, B->A 도) 옮긴다.
+go test -race 로 돌리면 잠금 없이 잔액을 읽고 쓰는 곳이 드러난다.
+중간에 찍은 Snapshot 도 항상 합이 같아야 한다 (transfer 의 절반만 보이면 안 된다).
+*/
+func TestConcurrentTransfers(t *testing.T) {
+	const (
+		workers = 32
+		initial = 1000
+	)
+	perWorker := 5000
+	if testing.Short() {
+		perWorker = 500
+	}
+
+	j := &MemoryJournal{}
+	l := New(WithJournal(j))
+	for _, id := range ids {
+		must(t)(l.Open(id, WithOverdraft(100)))
+		must(t)(l.Deposit(id, initial))
+	}
+	total := initial * int64(len(ids))
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	snapshots := make(chan error, 1)
+
+	go func() {
+		defer close(snapshots)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			if err := checkSnapshot(l.Snapshot(), total); err != nil {
+				snapshots <- err
+				return
+			}
+		}
+	}()
+
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			r := rand.New(rand.NewSource(int64(w)))
+			for range perWorker {
+				from, to := ids[r.Intn(len(ids))], ids[r.Intn(len(ids))]
+				_, err := l.Transfer(from, to, r.Int63n(300)+1)
+				if err != nil && !errors.Is(err, ErrInsufficientFunds) && !errors.Is(err, ErrSameAccount) {
+					t.Error(err)
+					return
+				}
+				if r.Intn(50) == 0 {
+					if _, err := l.Balance(from); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(done)
+	if err := <-snapshots; err != nil {
+		t.Fatal(err)
+	}
+
+	final := l.Snapshot()
+	if err := checkSnapshot(final, total); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.Total(); got != total {
+		t.Errorf("Total = %d, want %d", got, total)
+	}
+
+	replayed, err := Replay(j.Entries())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := replayed.Snapshot(); got.Seq != final.Seq || !slices.Equal(got.Accounts, final.Accounts) {
+		t.Fatalf("replayed %+v, want %+v", got, final)
+	}
+}
@@ -0,0 +1,115 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrCorruptJournal = errors.New("ledger: corrupt journal")
+
+// Replay 는 빈 원장에 journal 의 거래를 모두 적용한다
+func Replay(entries []Entry, opts ...Option) (*Ledger, error) {
+	return Restore(Snapshot{}, entries, opts...)
+}
+
+/*
+Restore 는 Snapshot 에 그 뒤의 거래 (Seq > s.Seq) 를 Seq 순서로 적용한다.
+적용할 때도 overdraft 규칙을 검사하므로, 규칙을 어기는 journal 은 ErrCorruptJournal 이 된다.
+opts 의 journal 에는 다시 남기지 않고, Restore 뒤의 새 거래만 남긴다.
+*/
+func Restore(s Snapshot, entries []Entry, opts ...Option) (*Ledger, error) {
+	l := New(opts...)
+
+	for _, acc := range s.Accounts {
+		if _, ok := l.accounts[acc.ID]; ok {
+			return nil, fmt.Errorf("%w: snapshot has account %s twice", ErrCorruptJournal, acc.ID)
+		}
+		l.accounts[acc.ID] = &account{Account: acc}
+	}
+
+	entries = slices.Clone(entries)
+	sortEntries(entries)
+
+	last := s.Seq
+	for _, e := range entries {
+		if e.Seq <= s.Seq {
+			continue
+		}
+		if e.Seq == last {
+			return nil, fmt.Errorf("%w: entry %d appears twice", ErrCorruptJournal, e.Seq)
+		}
+
+		if err := l.apply(e); err != nil {
+			return nil, fmt.Errorf("%w: entry %d (%s): %w", ErrCorruptJournal, e.Seq, e.Kind, err)
+		}
+		last = e.Seq
+	}
+
+	l.seq.Store(last)
+	return l, nil
+}
+
+// apply 는 Restore 중에 (원장을 아직 아무도 보지 않을 때) 거래 하나를 잠금 없이 적용한다
+func (l *Ledger) apply(e Entry) error {
+	switch e.Kind {
+	case KindOpen:
+		if _, ok := l.accounts[e.To]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateAccount, e.To)
+		}
+		if e.Amount < 0 {
+			return ErrInvalidAmount
+		}
+		l.accounts[e.To] = &account{Account: Account{ID: e.To, Overdraft: e.Amount}}
+		return nil
+	}
+
+	if e.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	switch e.Kind {
+	case KindDeposit:
+		dst, err := l.lookup(e.To)
+		if err != nil {
+			return err
+		}
+		if err := dst.canDeposit(e.Amount); err != nil {
+			return err
+		}
+		dst.Balance += e.Amount
+	case KindWithdraw:
+		src, err := l.lookup(e.From)
+		if err != nil {
+			return err
+		}
+		if err := src.canWithdraw(e.Amount); err != nil {
+			return err
+		}
+		src.Balance -= e.Amount
+	case KindTransfer:
+		if e.From == e.To {
+			return ErrSameAccount
+		}
+		src, err := l.lookup(e.From)
+		if err != nil {
+			return err
+		}
+		dst, err := l.lookup(e.To)
+		if err != nil {
+			return err
+		}
+		if err := src.canWithdraw(e.Amount); err != nil {
+			return err
+		}
+		if err := dst.canDeposit(e.Amount); err != nil {
+			return err
+		}
+		src.Balance -= e.Amount
+		dst.Balance += e.Amount
+	default:
+		return fmt.Errorf("unknown kind %q", e.Kind)
+	}
+
+	return nil
+}
@@ -0,0 +1,368 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+/*
+3.go 의 GoodInitPattern 처럼 초기화 함수를 손으로 호출하는 대신,
+컴포넌트를 이름과 의존성으로 등록하고 순서대로 시작/종료한다.
+
+	app := lifecycle.New()
+	app.Register(lifecycle.Component{Name: "config", Start: ...})
+	app.Register(lifecycle.Component{Name: "database", DependsOn: []string{"config"}, Start: ..., Stop: ...})
+
+	if err := app.Run(ctx); err != nil { ... } // log.Fatal 대신 에러를 돌려준다
+
+- 의존성 순서(topological order)대로 시작한다
+- 시작 도중 실패하면 이미 시작된 컴포넌트를 역순으로 종료한다 (rollback)
+- SIGINT/SIGTERM 또는 ctx 취소 시 역순으로 종료한다
+- 각 단계의 에러는 모두 모아서 하나의 에러로 돌려준다
+*/
+
+var (
+	ErrDuplicate         = errors.New("lifecycle: duplicate component")
+	ErrUnknownDependency = errors.New("lifecycle: unknown dependency")
+	ErrCycle             = errors.New("lifecycle: dependency cycle")
+	ErrAlreadyStarted    = errors.New("lifecycle: already started")
+)
+
+// Component 는 시작/종료 순서를 관리받는 하나의 구성요소다
+type Component struct {
+	Name      string
+	DependsOn []string
+
+	// Start 의 ctx 는 시작이 실패하거나 제한시간이 지날 때만 취소된다 (제한시간이면 context.Cause 가 DeadlineExceeded).
+	// 성공한 뒤에는 취소되지 않으므로 ctx 로 띄운 goroutine 은 Stop 에서 정리한다.
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error // nil 이면 종료할 것이 없다
+
+	// 0 이면 App 의 기본 타임아웃을 사용한다
+	StartTimeout time.Duration
+	StopTimeout  time.Duration
+}
+
+// Phase 는 에러가 발생한 단계다
+type Phase string
+
+const (
+	PhaseStart Phase = "start"
+	PhaseStop  Phase = "stop"
+)
+
+// StepError 는 어떤 컴포넌트의 어떤 단계에서 실패했는지 담는다
+type StepError struct {
+	Component string
+	Phase     Phase
+	Err       error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("lifecycle: %s %s: %v", e.Phase, e.Component, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+type Option func(a *App)
+
+// WithStartTimeout 은 컴포넌트 하나의 기본 시작 제한시간이다
+func WithStartTimeout(d time.Duration) Option {
+	return func(a *App) {
+		a.startTimeout = d
+	}
+}
+
+// WithStopTimeout 은 컴포넌트 하나의 기본 종료 제한시간이다
+func WithStopTimeout(d time.Duration) Option {
+	return func(a *App) {
+		a.stopTimeout = d
+	}
+}
+
+// WithSignals 는 Run 이 종료를 시작할 시그널이다 (기본 SIGINT, SIGTERM)
+func WithSignals(sigs ...os.Signal) Option {
+	return func(a *App) {
+		a.signals = sigs
+	}
+}
+
+type App struct {
+	startTimeout time.Duration
+	stopTimeout  time.Duration
+	signals      []os.Signal
+
+	mu         sync.Mutex
+	components []Component
+	byName     map[string]int
+	started    []Component // 시작된 순서
+	running    bool
+}
+
+func New(opts ...Option) *App {
+	a := &App{
+		startTimeout: 15 * time.Second,
+		stopTimeout:  15 * time.Second,
+		signals:      []os.Signal{os.Interrupt, syscall.SIGTERM},
+		byName:       map[string]int{},
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// Register 는 컴포넌트를 추가한다. 의존성 검사는 Start 시점에 한다.
+func (a *App) Register(c Component) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if c.Name == "" {
+		return errors.New("lifecycle: component name is empty")
+	}
+
+	if c.Start == nil {
+		return fmt.Errorf("lifecycle: component %q has no Start func", c.Name)
+	}
+
+	if _, ok := a.byName[c.Name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicate, c.Name)
+	}
+
+	a.byName[c.Name] = len(a.components)
+	a.components = append(a.components, c)
+	return nil
+}
+
+// Order 는 컴포넌트가 시작될 순서를 돌려준다.
+// 의존성이 없는 컴포넌트끼리는 등록한 순서를 따른다.
+func (a *App) Order() ([]string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	order, err := a.order()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(order))
+	for _, c := range order {
+		names = append(names, c.Name)
+	}
+
+	return names, nil
+}
+
+func (a *App) order() ([]Component, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(a.components))
+	order := make([]Component, 0, len(a.components))
+
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		c := a.components[i]
+		path = append(path, c.Name)
+
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrCycle, strings.Join(path, " -> "))
+		}
+
+		state[i] = visiting
+		for _, dep := range c.DependsOn {
+			j, ok := a.byName[dep]
+			if !ok {
+				return fmt.Errorf("%w: %q requires %q", ErrUnknownDependency, c.Name, dep)
+			}
+
+			if err := visit(j, path); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+
+		order = append(order, c)
+		return nil
+	}
+
+	for i := range a.components {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// Start 는 의존성 순서대로 컴포넌트를 시작한다.
+// 하나라도 실패하면 이미 시작된 컴포넌트를 역순으로 종료하고 모든 에러를 모아서 돌려준다.
+func (a *App) Start(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.running {
+		return ErrAlreadyStarted
+	}
+
+	order, err := a.order()
+	if err != nil {
+		return err
+	}
+
+	a.running = true
+	for _, c := range order {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(&StepError{Component: c.Name, Phase: PhaseStart, Err: err}, a.stop(context.WithoutCancel(ctx)))
+		}
+
+		pending, err := step(ctx, c.Start, orDefault(c.StartTimeout, a.startTimeout))
+		if err != nil {
+			// 제한시간이 지나도 Start 는 계속 돌고 있으므로 rollback 에서 같이 종료한다.
+			// Stop 이 Start 와 동시에 돌지 않게 Start 가 끝나기를 (StopTimeout 까지) 기다린다.
+			if pending != nil {
+				wait(pending, orDefault(c.StopTimeout, a.stopTimeout))
+				a.started = append(a.started, c)
+			}
+			return errors.Join(&StepError{Component: c.Name, Phase: PhaseStart, Err: err}, a.stop(context.WithoutCancel(ctx)))
+		}
+
+		a.started = append(a.started, c)
+	}
+
+	return nil
+}
+
+// Stop 은 시작된 컴포넌트를 시작의 역순으로 종료한다.
+// 중간에 실패해도 나머지 컴포넌트는 계속 종료한다.
+func (a *App) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.stop(ctx)
+}
+
+func (a *App) stop(ctx context.Context) error {
+	var errs []error
+
+	for i := len(a.started) - 1; i >= 0; i-- {
+		c := a.started[i]
+		if c.Stop == nil {
+			continue
+		}
+
+		if _, err := step(ctx, c.Stop, orDefault(c.StopTimeout, a.stopTimeout)); err != nil {
+			errs = append(errs, &StepError{Component: c.Name, Phase: PhaseStop, Err: err})
+		}
+	}
+
+	a.started = nil
+	a.running = false
+	return errors.Join(errs...)
+}
+
+// Run 은 모든 컴포넌트를 시작한 뒤, ctx 가 취소되거나 종료 시그널을 받으면 종료한다.
+// 시작 도중에 받은 시그널도 시작을 멈추고 이미 시작된 컴포넌트를 역순으로 종료한다.
+func (a *App) Run(ctx context.Context) error {
+	sigCtx, stop := signal.NotifyContext(ctx, a.signals...)
+	defer stop()
+
+	if err := a.Start(sigCtx); err != nil {
+		return err
+	}
+
+	<-sigCtx.Done()
+
+	// 종료 단계는 이미 취소된 ctx 와 상관없이 각자의 제한시간을 가진다
+	return a.Stop(context.WithoutCancel(ctx))
+}
+
+/*
+step 은 fn 을 제한시간 안에 실행한다. fn 이 ctx 를 무시하더라도 제한시간이 지나면 돌아온다.
+
+fn 이 받는 ctx 는 fn 이 실패하거나, 제한시간이 지나거나, 그 전에 parent 가 취소될 때만 취소된다.
+성공한 뒤에는 parent 가 취소돼도 그대로라서 Start 가 ctx 로 띄운 goroutine 이 바로 죽지 않는다.
+pending 은 fn 이 끝나기 전에 돌아왔을 때 fn 의 결과를 받는 채널이다 (끝났으면 nil).
+*/
+func step(parent context.Context, fn func(context.Context) error, timeout time.Duration) (pending <-chan error, err error) {
+	ctx, cancel := context.WithCancelCause(context.WithoutCancel(parent))
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+
+	finished := func(err error) (<-chan error, error) {
+		if err != nil {
+			cancel(err)
+		}
+		return nil, err
+	}
+
+	var cause error
+	select {
+	case err := <-done:
+		return finished(err)
+	case <-expired:
+		cause = context.DeadlineExceeded
+	case <-parent.Done():
+		cause = parent.Err()
+	}
+
+	// 같은 순간에 끝났다면 fn 의 결과를 쓴다
+	select {
+	case err := <-done:
+		return finished(err)
+	default:
+		cancel(cause)
+		return done, cause
+	}
+}
+
+// wait 는 pending 이 끝나기를 timeout 까지 기다린다 (0 이면 끝날 때까지)
+func wait(pending <-chan error, timeout time.Duration) {
+	if timeout <= 0 {
+		<-pending
+		return
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-pending:
+	case <-timer.C:
+	}
+}
+
+func orDefault(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+
+	return fallback
+}
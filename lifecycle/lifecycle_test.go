@@ -0,0 +1,347 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// recorder 는 컴포넌트가 시작/종료된 순서를 기록한다
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) component(name string, deps ...string) Component {
+	return Component{
+		Name:      name,
+		DependsOn: deps,
+		Start:     r.record("start " + name),
+		Stop:      r.record("stop " + name),
+	}
+}
+
+func (r *recorder) record(event string) func(context.Context) error {
+	return func(context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.events = append(r.events, event)
+		return nil
+	}
+}
+
+func mustRegister(t *testing.T, a *App, cs ...Component) {
+	t.Helper()
+	for _, c := range cs {
+		if err := a.Register(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStartStopOrder(t *testing.T) {
+	r := &recorder{}
+	a := New()
+
+	// 등록 순서와 상관없이 config -> database -> server 순서로 시작해야 한다
+	mustRegister(t, a,
+		r.component("server", "database"),
+		r.component("database", "config"),
+		r.component("config"),
+	)
+
+	if err := a.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"start config", "start database", "start server",
+		"stop server", "stop database", "stop config",
+	}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Errorf("events = %v, want %v", r.events, want)
+	}
+}
+
+func TestOrderKeepsRegistrationOrder(t *testing.T) {
+	r := &recorder{}
+	a := New()
+	mustRegister(t, a, r.component("b"), r.component("a"), r.component("c", "a"))
+
+	got, err := a.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"b", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Order() = %v, want %v", got, want)
+	}
+}
+
+func TestCycle(t *testing.T) {
+	r := &recorder{}
+	a := New()
+	mustRegister(t, a,
+		r.component("config", "server"),
+		r.component("database", "config"),
+		r.component("server", "database"),
+	)
+
+	err := a.Start(context.Background())
+	if !errors.Is(err, ErrCycle) {
+		t.Fatalf("Start() = %v, want ErrCycle", err)
+	}
+
+	if want := "lifecycle: dependency cycle: config -> server -> database -> config"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+
+	if len(r.events) != 0 {
+		t.Errorf("components started despite cycle: %v", r.events)
+	}
+}
+
+func TestUnknownDependency(t *testing.T) {
+	r := &recorder{}
+	a := New()
+	mustRegister(t, a, r.component("database", "config"))
+
+	if err := a.Start(context.Background()); !errors.Is(err, ErrUnknownDependency) {
+		t.Fatalf("Start() = %v, want ErrUnknownDependency", err)
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	r := &recorder{}
+	a := New()
+	mustRegister(t, a, r.component("config"))
+
+	if err := a.Register(r.component("config")); !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("Register() = %v, want ErrDuplicate", err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	r := &recorder{}
+	a := New()
+
+	errConnect := errors.New("connection refused")
+	errClose := errors.New("close failed")
+
+	config := r.component("config")
+	config.Stop = func(context.Context) error { return errClose }
+
+	database := r.component("database", "config")
+	database.Start = func(context.Context) error { return errConnect }
+
+	mustRegister(t, a, config, database, r.component("server", "database"))
+
+	err := a.Start(context.Background())
+
+	// 시작 에러와 rollback 중 발생한 종료 에러를 모두 돌려준다
+	if !errors.Is(err, errConnect) || !errors.Is(err, errClose) {
+		t.Fatalf("Start() = %v, want both start and stop errors", err)
+	}
+
+	var step *StepError
+	if !errors.As(err, &step) || step.Component != "database" || step.Phase != PhaseStart {
+		t.Errorf("first StepError = %+v, want database start", step)
+	}
+
+	if want := []string{"start config"}; !reflect.DeepEqual(r.events, want) {
+		t.Errorf("events = %v, want %v", r.events, want)
+	}
+
+	// rollback 이후에는 다시 시작할 수 있다
+	if err := a.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() after rollback = %v, want nil", err)
+	}
+}
+
+func TestStartTimeout(t *testing.T) {
+	r := &recorder{}
+	a := New(WithStartTimeout(10 * time.Millisecond))
+
+	slow := Component{
+		Name: "database",
+		Start: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	mustRegister(t, a, r.component("config"), slow)
+
+	start := time.Now()
+	err := a.Start(context.Background())
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Start() = %v, want DeadlineExceeded", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Start took %s, want about 10ms", elapsed)
+	}
+
+	if want := []string{"start config", "stop config"}; !reflect.DeepEqual(r.events, want) {
+		t.Errorf("events = %v, want %v", r.events, want)
+	}
+}
+
+// 제한시간이 지나도 Start 가 돌고 있으면 rollback 에서 그 컴포넌트도 종료한다
+func TestStartTimeoutStopsRunningComponent(t *testing.T) {
+	r := &recorder{}
+	a := New(WithStartTimeout(10*time.Millisecond), WithStopTimeout(10*time.Millisecond))
+
+	release := make(chan struct{})
+	defer close(release)
+
+	stuck := r.component("database", "config")
+	stuck.Start = func(context.Context) error {
+		<-release // ctx 를 무시한다
+		return nil
+	}
+
+	mustRegister(t, a, r.component("config"), stuck)
+
+	if err := a.Start(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Start() = %v, want DeadlineExceeded", err)
+	}
+
+	want := []string{"start config", "stop database", "stop config"}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Errorf("events = %v, want %v", r.events, want)
+	}
+}
+
+// Stop 은 제한시간이 지난 Start 가 끝난 뒤에 부른다
+func TestStartTimeoutWaitsForStartBeforeStop(t *testing.T) {
+	r := &recorder{}
+	a := New(WithStartTimeout(10 * time.Millisecond))
+
+	slow := r.component("database")
+	slow.Start = func(context.Context) error {
+		time.Sleep(50 * time.Millisecond) // ctx 를 무시한다
+		return r.record("start database returned")(nil)
+	}
+	mustRegister(t, a, slow)
+
+	if err := a.Start(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Start() = %v, want DeadlineExceeded", err)
+	}
+
+	if want := []string{"start database returned", "stop database"}; !reflect.DeepEqual(r.events, want) {
+		t.Errorf("events = %v, want %v", r.events, want)
+	}
+}
+
+// Start 가 ctx 로 띄운 goroutine 은 Start 가 끝난 뒤에도 돈다
+func TestStartContextOutlivesStart(t *testing.T) {
+	a := New()
+
+	cancelled := make(chan struct{})
+	worker := Component{
+		Name: "worker",
+		Start: func(ctx context.Context) error {
+			go func() {
+				<-ctx.Done()
+				close(cancelled)
+			}()
+			return nil
+		},
+	}
+	mustRegister(t, a, worker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := a.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	select {
+	case <-cancelled:
+		t.Error("Start ctx was cancelled after a successful Start")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestStartTwice(t *testing.T) {
+	a := New()
+	mustRegister(t, a, (&recorder{}).component("config"))
+
+	if err := a.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Start(context.Background()); !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("second Start() = %v, want ErrAlreadyStarted", err)
+	}
+}
+
+func TestRunStopsOnCancel(t *testing.T) {
+	r := &recorder{}
+	a := New()
+	mustRegister(t, a, r.component("config"), r.component("database", "config"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- a.Run(ctx)
+	}()
+
+	// 모든 컴포넌트가 시작될 때까지 기다린다
+	for {
+		r.mu.Lock()
+		n := len(r.events)
+		r.mu.Unlock()
+		if n == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"start config", "start database", "stop database", "stop config"}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Errorf("events = %v, want %v", r.events, want)
+	}
+}
+
+// 시작 도중에 받은 시그널로도 이미 시작된 컴포넌트를 역순으로 종료한다
+func TestRunSignalDuringStart(t *testing.T) {
+	r := &recorder{}
+	a := New(WithSignals(syscall.SIGUSR1))
+
+	slow := Component{Name: "server", DependsOn: []string{"database"}}
+	slow.Start = func(ctx context.Context) error {
+		if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+			return err
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	mustRegister(t, a, r.component("config"), r.component("database", "config"), slow)
+
+	if err := a.Run(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() = %v, want Canceled", err)
+	}
+
+	want := []string{"start config", "start database", "stop database", "stop config"}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Errorf("events = %v, want %v", r.events, want)
+	}
+}
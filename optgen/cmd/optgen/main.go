@@ -0,0 +1,34 @@
+/*
+optgen 은 구조체 태그(default, min, max, required)로부터
+Builder 와 Functional Options 를 생성한다.
+
+	//go:generate go run github.com/zkfmapf123/100/optgen/cmd/optgen -type Config
+	//go:generate go run github.com/zkfmapf123/100/optgen/cmd/optgen -type ServerConfig -prefix WithServer
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/zkfmapf123/100/optgen"
+)
+
+func main() {
+	typeName := flag.String("type", "", "struct type name to generate options for")
+	dir := flag.String("dir", ".", "package directory")
+	prefix := flag.String("prefix", "With", "prefix of the generated option functions")
+	flag.Parse()
+
+	if *typeName == "" {
+		log.Fatal("optgen: -type is required")
+	}
+
+	out, err := optgen.Run(*dir, *typeName, *prefix)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("optgen: wrote", out)
+}
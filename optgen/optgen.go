@@ -0,0 +1,559 @@
+package optgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+/*
+11.함수현_패턴 의 ConfigBuilder, WithPort 를 손으로 만들지 않고
+구조체 태그로부터 Builder 와 Functional Options 를 생성한다.
+
+	//go:generate go run github.com/zkfmapf123/100/optgen/cmd/optgen -type Config
+	type Config struct {
+		port    int           `default:"8080" min:"1" max:"65535"`
+		host    string        `required:"true"`
+		timeout time.Duration `default:"3s" min:"0s"`
+		debug   bool          `opt:"-"` // 생성하지 않는다
+	}
+
+생성 결과
+
+	NewConfig(WithPort(9000), WithHost("localhost"))         // Functional Options
+	new(ConfigBuilder).Port(9000).Host("localhost").Build()  // Builder
+
+두 방법 모두 같은 검증(min, max, required)과 기본값(default)을 거친다.
+*/
+
+// Field 는 생성 대상 구조체의 필드 하나다
+type Field struct {
+	Name     string // 구조체 필드 이름
+	Method   string // Builder 메서드 이름 (Port)
+	Param    string // 파라미터 이름
+	Type     string // 소스에 적힌 타입
+	Kind     kind
+	Default  string // Go 표현식
+	Min, Max string // Go 표현식
+	Required bool
+}
+
+type kind int
+
+const (
+	kindOther kind = iota
+	kindNumber
+	kindDuration
+	kindString
+	kindBool
+	kindSlice
+	kindMap
+)
+
+// Struct 는 생성에 필요한 구조체 정보다
+type Struct struct {
+	Package string
+	Name    string
+	Fields  []Field
+
+	// 옵션 함수 이름 앞에 붙는다 (기본 With -> WithPort)
+	// 같은 패키지에 여러 구조체를 생성할 때 겹치지 않게 바꾼다
+	OptionPrefix string
+
+	// 생성된 파일의 import ("net/http", yaml "gopkg.in/yaml.v3")
+	Imports []string
+}
+
+// Parse 는 dir 의 Go 파일에서 typeName 구조체를 찾는다 (테스트 파일과 생성된 파일은 제외)
+func Parse(dir, typeName string) (*Struct, error) {
+	fset := token.NewFileSet()
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range matches {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		if ast.IsGenerated(f) {
+			continue
+		}
+
+		if st := findStruct(f, typeName); st != nil {
+			return newStruct(f, typeName, st)
+		}
+	}
+
+	return nil, fmt.Errorf("optgen: struct %s not found in %s", typeName, dir)
+}
+
+func findStruct(f *ast.File, name string) *ast.StructType {
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != name {
+				continue
+			}
+
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				return st
+			}
+		}
+	}
+
+	return nil
+}
+
+func newStruct(file *ast.File, name string, st *ast.StructType) (*Struct, error) {
+	s := &Struct{Package: file.Name.Name, Name: name, OptionPrefix: "With"}
+
+	// 필드 타입이 쓰는 패키지 (http.Client -> http)
+	used := map[string]bool{}
+
+	for _, af := range st.Fields.List {
+		var tag reflect.StructTag
+		if af.Tag != nil {
+			raw, err := strconv.Unquote(af.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(raw)
+		}
+
+		if tag.Get("opt") == "-" {
+			continue
+		}
+
+		if len(af.Names) == 0 {
+			return nil, fmt.Errorf("optgen: %s: embedded field %s is not supported", name, types.ExprString(af.Type))
+		}
+
+		ast.Inspect(af.Type, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					used[id.Name] = true
+				}
+				return false
+			}
+			return true
+		})
+
+		for _, id := range af.Names {
+			f, err := newField(id.Name, types.ExprString(af.Type), tag)
+			if err != nil {
+				return nil, fmt.Errorf("optgen: %s.%s: %w", name, id.Name, err)
+			}
+			if f.Required || f.Validated() {
+				used["fmt"] = true
+			}
+			s.Fields = append(s.Fields, f)
+		}
+	}
+
+	specs := map[string]string{"fmt": strconv.Quote("fmt")}
+	for _, is := range file.Imports {
+		p, err := strconv.Unquote(is.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if is.Name != nil {
+			specs[is.Name.Name] = is.Name.Name + " " + is.Path.Value
+		} else {
+			specs[importName(p)] = is.Path.Value
+		}
+	}
+
+	for pkg := range used {
+		spec, ok := specs[pkg]
+		if !ok {
+			return nil, fmt.Errorf("optgen: %s: cannot find the import of package %s", name, pkg)
+		}
+		s.Imports = append(s.Imports, spec)
+	}
+	sort.Slice(s.Imports, func(i, j int) bool {
+		return importPath(s.Imports[i]) < importPath(s.Imports[j])
+	})
+
+	// 생성된 함수 안의 변수 (o, cfg, opts, b) 나 패키지 이름과 겹치는 파라미터는 v 로 바꾼다
+	for i, f := range s.Fields {
+		if reserved[f.Param] || used[f.Param] {
+			s.Fields[i].Param = "v"
+		}
+	}
+
+	return s, nil
+}
+
+// reserved 는 생성된 코드가 파라미터와 같은 scope 에서 쓰는 이름이다
+var reserved = map[string]bool{"o": true, "opt": true, "opts": true, "cfg": true, "b": true, "err": true}
+
+// importName 은 이름 없이 import 한 패키지의 이름을 경로로 짐작한다 (gopkg.in/yaml.v3 -> yaml, .../v2 -> 앞 요소)
+func importName(p string) string {
+	elems := strings.Split(p, "/")
+	name := elems[len(elems)-1]
+
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexAny(name, ".-"); i > 0 {
+		name = name[:i]
+	}
+
+	return name
+}
+
+// importPath 는 `yaml "gopkg.in/yaml.v3"` 에서 경로만 꺼낸다
+func importPath(spec string) string {
+	p, _ := strconv.Unquote(spec[strings.IndexByte(spec, '"'):])
+	return p
+}
+
+func newField(name, typ string, tag reflect.StructTag) (Field, error) {
+	exported := upperFirst(name)
+
+	f := Field{
+		Name:     name,
+		Method:   exported,
+		Param:    param(name),
+		Type:     typ,
+		Kind:     kindOf(typ),
+		Required: tag.Get("required") == "true",
+	}
+
+	var err error
+	if v, ok := tag.Lookup("default"); ok {
+		if f.Default, err = literal(f.Kind, v); err != nil {
+			return f, fmt.Errorf("default: %w", err)
+		}
+	}
+
+	for _, bound := range []struct {
+		key string
+		dst *string
+	}{{"min", &f.Min}, {"max", &f.Max}} {
+		v, ok := tag.Lookup(bound.key)
+		if !ok {
+			continue
+		}
+
+		// 문자열, 슬라이스, 맵은 길이로 비교한다
+		k := f.Kind
+		if k == kindString || k == kindSlice || k == kindMap {
+			k = kindNumber
+		}
+
+		if k != kindNumber && k != kindDuration {
+			return f, fmt.Errorf("%s: not supported for type %s", bound.key, typ)
+		}
+
+		if *bound.dst, err = literal(k, v); err != nil {
+			return f, fmt.Errorf("%s: %w", bound.key, err)
+		}
+	}
+
+	if err := checkBounds(f.Kind, tag); err != nil {
+		return f, err
+	}
+
+	return f, nil
+}
+
+// checkBounds 는 default 가 [min, max] 안에 있는지 본다 (문자열은 길이로)
+func checkBounds(k kind, tag reflect.StructTag) error {
+	value := func(key string) (float64, bool) {
+		v, ok := tag.Lookup(key)
+		if !ok {
+			return 0, false
+		}
+
+		switch {
+		case key == "default" && k == kindString:
+			return float64(len(v)), true
+		case k == kindDuration:
+			d, _ := time.ParseDuration(v)
+			return float64(d), true
+		}
+
+		n, _ := strconv.ParseFloat(v, 64)
+		return n, true
+	}
+
+	minV, hasMin := value("min")
+	maxV, hasMax := value("max")
+	if hasMin && hasMax && minV > maxV {
+		return fmt.Errorf("min %s is greater than max %s", tag.Get("min"), tag.Get("max"))
+	}
+
+	def, ok := value("default")
+	if !ok {
+		return nil
+	}
+
+	shown := tag.Get("default")
+	if k == kindString {
+		shown = strconv.Quote(shown)
+	}
+
+	if hasMin && def < minV {
+		return fmt.Errorf("default %s is less than min %s", shown, tag.Get("min"))
+	}
+	if hasMax && def > maxV {
+		return fmt.Errorf("default %s is greater than max %s", shown, tag.Get("max"))
+	}
+
+	return nil
+}
+
+func kindOf(typ string) kind {
+	switch {
+	case typ == "string":
+		return kindString
+	case typ == "bool":
+		return kindBool
+	case typ == "time.Duration":
+		return kindDuration
+	case strings.HasPrefix(typ, "[]"):
+		return kindSlice
+	case strings.HasPrefix(typ, "map["):
+		return kindMap
+	}
+
+	switch strings.TrimLeft(typ, "u") {
+	case "int", "int8", "int16", "int32", "int64", "float32", "float64":
+		return kindNumber
+	}
+
+	return kindOther
+}
+
+// literal 은 태그 값을 필드 타입에 맞는 Go 표현식으로 바꾼다
+func literal(k kind, v string) (string, error) {
+	switch k {
+	case kindString:
+		return strconv.Quote(v), nil
+	case kindBool:
+		if _, err := strconv.ParseBool(v); err != nil {
+			return "", err
+		}
+		return v, nil
+	case kindNumber:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return "", fmt.Errorf("%q is not a number", v)
+		}
+		return v, nil
+	case kindDuration:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return "", err
+		}
+		return durationLiteral(d), nil
+	}
+
+	return "", fmt.Errorf("not supported for this type")
+}
+
+// durationLiteral 은 3s -> 3 * time.Second 처럼 읽기 쉬운 표현식을 만든다
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"time.Hour", time.Hour},
+		{"time.Minute", time.Minute},
+		{"time.Second", time.Second},
+		{"time.Millisecond", time.Millisecond},
+		{"time.Microsecond", time.Microsecond},
+	}
+
+	if d == 0 {
+		return "0"
+	}
+
+	for _, u := range units {
+		if d%u.d == 0 {
+			return fmt.Sprintf("%d * %s", d/u.d, u.name)
+		}
+	}
+
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
+func upperFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// param 은 필드 이름을 파라미터 이름으로 쓴다 (키워드와 겹치면 v)
+func param(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToLower(r[0])
+
+	if p := string(r); !token.IsKeyword(p) {
+		return p
+	}
+
+	return "v"
+}
+
+// Generate 는 Builder 와 Functional Options 코드를 만든다
+func Generate(s *Struct) ([]byte, error) {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]any{
+		"S":     s,
+		"Lower": strings.ToLower(s.Name[:1]) + s.Name[1:],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("optgen: format generated code: %w\n%s", err, buf.Bytes())
+	}
+
+	return src, nil
+}
+
+// Run 은 dir 의 typeName 에 대한 코드를 <type>_options.go 로 쓴다
+func Run(dir, typeName, optionPrefix string) (string, error) {
+	s, err := Parse(dir, typeName)
+	if err != nil {
+		return "", err
+	}
+
+	if optionPrefix != "" {
+		s.OptionPrefix = optionPrefix
+	}
+
+	src, err := Generate(s)
+	if err != nil {
+		return "", err
+	}
+
+	out := filepath.Join(dir, strings.ToLower(typeName)+"_options.go")
+	return out, os.WriteFile(out, src, 0o644)
+}
+
+func (f Field) Length() bool {
+	return f.Kind == kindString || f.Kind == kindSlice || f.Kind == kindMap
+}
+
+func (f Field) Validated() bool {
+	return f.Min != "" || f.Max != ""
+}
+
+var tmpl = template.Must(template.New("optgen").Parse(`// Code generated by optgen; DO NOT EDIT.
+
+package {{.S.Package}}
+{{if .S.Imports}}
+import (
+{{- range .S.Imports}}
+	{{.}}
+{{- end}}
+)
+{{end}}
+// {{.S.Name}}Option 은 {{.S.Name}} 의 필드 하나를 지정한다
+type {{.S.Name}}Option func(o *{{.Lower}}Options) error
+
+// {{.Lower}}Options 는 지정된 필드만 기록한다 (nil 이면 지정하지 않음)
+type {{.Lower}}Options struct {
+{{- range .S.Fields}}
+	{{.Name}} *{{.Type}}
+{{- end}}
+}
+{{range .S.Fields}}
+func {{$.S.OptionPrefix}}{{.Method}}({{.Param}} {{.Type}}) {{$.S.Name}}Option {
+	return func(o *{{$.Lower}}Options) error {
+{{- if .Validated}}
+		if err := validate{{$.S.Name}}{{.Method}}({{.Param}}); err != nil {
+			return err
+		}
+{{end}}
+		o.{{.Name}} = &{{.Param}}
+		return nil
+	}
+}
+{{end}}
+// New{{.S.Name}} 는 옵션을 한번씩 적용하고, 지정하지 않은 필드는 기본값을 사용한다
+func New{{.S.Name}}(opts ...{{.S.Name}}Option) ({{.S.Name}}, error) {
+	o := &{{.Lower}}Options{}
+
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return {{.S.Name}}{}, err
+		}
+	}
+
+	var cfg {{.S.Name}}
+{{- range .S.Fields}}
+
+	if o.{{.Name}} != nil {
+		cfg.{{.Name}} = *o.{{.Name}}
+	}{{if .Required}} else {
+		return {{$.S.Name}}{}, fmt.Errorf("{{.Name}} is required")
+	}{{else if .Default}} else {
+		cfg.{{.Name}} = {{.Default}}
+	}{{end}}
+{{- end}}
+
+	return cfg, nil
+}
+
+// {{.S.Name}}Builder 는 New{{.S.Name}} 와 같은 검증을 Build 시점에 한다
+type {{.S.Name}}Builder struct {
+	opts []{{.S.Name}}Option
+}
+{{range .S.Fields}}
+func (b *{{$.S.Name}}Builder) {{.Method}}({{.Param}} {{.Type}}) *{{$.S.Name}}Builder {
+	b.opts = append(b.opts, {{$.S.OptionPrefix}}{{.Method}}({{.Param}}))
+	return b
+}
+{{end}}
+func (b *{{.S.Name}}Builder) Build() ({{.S.Name}}, error) {
+	return New{{.S.Name}}(b.opts...)
+}
+{{range .S.Fields}}{{if .Validated}}
+func validate{{$.S.Name}}{{.Method}}({{.Param}} {{.Type}}) error {
+{{- if .Min}}
+	if {{if .Length}}len({{.Param}}){{else}}{{.Param}}{{end}} < {{.Min}} {
+		return fmt.Errorf("{{.Name}}{{if .Length}} length{{end}} must be >= %v, got %v", {{.Min}}, {{if .Length}}len({{.Param}}){{else}}{{.Param}}{{end}})
+	}
+{{end}}
+{{- if .Max}}
+	if {{if .Length}}len({{.Param}}){{else}}{{.Param}}{{end}} > {{.Max}} {
+		return fmt.Errorf("{{.Name}}{{if .Length}} length{{end}} must be <= %v, got %v", {{.Max}}, {{if .Length}}len({{.Param}}){{else}}{{.Param}}{{end}})
+	}
+{{end}}
+	return nil
+}
+{{end}}{{end}}`))
@@ -0,0 +1,134 @@
+package optgen
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// builderDir 는 손으로 만든 ConfigBuilder 와 Config 가 있는 곳이다
+const builderDir = "../11.함수현_패턴"
+
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		dir, name, prefix string
+	}{
+		{builderDir, "Config", "With"},
+		{"testdata", "ServerConfig", "WithServer"},
+		{"testdata", "ClientConfig", "WithClient"},
+	}
+
+	for _, tt := range tests {
+		name := tt.name
+		t.Run(name, func(t *testing.T) {
+			s, err := Parse(tt.dir, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.OptionPrefix = tt.prefix
+
+			got, err := Generate(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", strings.ToLower(name)+"_options.go.golden")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("generated code differs from %s (run go test -update)\n%s", golden, got)
+			}
+		})
+	}
+}
+
+// 생성된 코드가 원래 구조체와 함께 컴파일되는지 확인한다
+func TestGoldenTypeChecks(t *testing.T) {
+	fset := token.NewFileSet()
+
+	var files []*ast.File
+	for _, path := range []string{
+		"testdata/config.go",
+		"testdata/config_options.go.golden",
+		"testdata/serverconfig_options.go.golden",
+		"testdata/clientconfig_options.go.golden",
+	} {
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+
+	// _builder.go 에는 손으로 만든 ConfigBuilder 도 있으므로 Config 선언만 가져온다
+	f, err := parser.ParseFile(fset, filepath.Join(builderDir, "_builder.go"), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Decls = slices.DeleteFunc(f.Decls, func(d ast.Decl) bool {
+		gd, ok := d.(*ast.GenDecl)
+		return !ok || gd.Tok != token.TYPE || gd.Specs[0].(*ast.TypeSpec).Name.Name != "Config"
+	})
+	f.Imports = nil
+	files = append(files, f)
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("main", fset, files, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		typ, tag, want string
+	}{
+		{"bool", `min:"1"`, "min: not supported for type bool"},
+		{"int", `default:"x"`, `default: "x" is not a number`},
+		{"time.Duration", `max:"soon"`, `max: time: invalid duration "soon"`},
+		{"*int", `default:"1"`, "default: not supported for this type"},
+		{"int", `default:"0" min:"1"`, "default 0 is less than min 1"},
+		{"int", `default:"70000" max:"65535"`, "default 70000 is greater than max 65535"},
+		{"int", `min:"10" max:"1"`, "min 10 is greater than max 1"},
+		{"time.Duration", `default:"2m" max:"1m"`, "default 2m is greater than max 1m"},
+		{"string", `default:"" min:"1"`, `default "" is less than min 1`},
+		{"url.URL", ``, "cannot find the import of package url"},
+	}
+
+	for _, tt := range tests {
+		src := "package p\nimport \"time\"\nvar _ time.Duration\ntype T struct { f " + tt.typ + " `" + tt.tag + "` }\n"
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "t.go"), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := Parse(dir, "T")
+		if err == nil || !strings.HasSuffix(err.Error(), tt.want) {
+			t.Errorf("%s %s: Parse() = %v, want %q", tt.typ, tt.tag, err, tt.want)
+		}
+	}
+
+	if _, err := Parse("testdata", "Missing"); err == nil {
+		t.Error("Parse(Missing) = nil error")
+	}
+}
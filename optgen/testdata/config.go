@@ -0,0 +1,25 @@
+package main
+
+import (
+	"net/http"
+	"time"
+)
+
+type ServerConfig struct {
+	host     string        `required:"true" min:"1"`
+	port     int           `default:"8080" min:"1" max:"65535"`
+	timeout  time.Duration `default:"3s" min:"0s" max:"1m"`
+	tags     []string      `max:"8"`
+	debug    bool          `default:"false"`
+	internal int           `opt:"-"`
+}
+
+// 태그가 없는 필드의 패키지도 import 하고, 생성된 코드의 변수와 같은 이름은 바꿔야 한다
+type ClientConfig struct {
+	client  *http.Client
+	timeout time.Duration
+	o       int    `min:"0"`
+	cfg     string `required:"true"`
+	opts    []string
+	b       bool
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"crypto/tls"
+	"log"
+	"net/http"
+	"time"
+)
+
+/*							두번째 방법 고전적인 Builder 패턴 적용방법 ✅ 								*/
+type ConfigBuilder struct {
+	s settings
+}
+
+func (b *ConfigBuilder) Port(port int) *ConfigBuilder {
+	b.s.port = &port
+	return b
+}
+
+func (b *ConfigBuilder) ReadTimeout(d time.Duration) *ConfigBuilder {
+	b.s.readTimeout = d
+	return b
+}
+
+func (b *ConfigBuilder) WriteTimeout(d time.Duration) *ConfigBuilder {
+	b.s.writeTimeout = d
+	return b
+}
+
+func (b *ConfigBuilder) IdleTimeout(d time.Duration) *ConfigBuilder {
+	b.s.idleTimeout = d
+	return b
+}
+
+func (b *ConfigBuilder) TLSConfig(cfg *tls.Config) *ConfigBuilder {
+	b.s.tlsConfig = cfg
+	return b
+}
+
+func (b *ConfigBuilder) MaxHeaderBytes(n int) *ConfigBuilder {
+	b.s.maxHeaderBytes = n
+	return b
+}
+
+func (b *ConfigBuilder) Handler(h http.Handler) *ConfigBuilder {
+	b.s.handler = h
+	return b
+}
+
+func (b *ConfigBuilder) Middleware(mw ...Middleware) *ConfigBuilder {
+	b.s.middleware = append(b.s.middleware, mw...)
+	return b
+}
+
+func (b *ConfigBuilder) ShutdownTimeout(d time.Duration) *ConfigBuilder {
+	b.s.shutdownTimeout = d
+	return b
+}
+
+func (b *ConfigBuilder) Logger(l *log.Logger) *ConfigBuilder {
+	b.s.logger = l
+	return b
+}
+
+// Build 는 포트를 지정하지 않았다면 DefaultPort 를, 0 이나 음수라면 에러를 돌려준다
+func (b *ConfigBuilder) Build() (Config, error) {
+	if err := b.s.validate(); err != nil {
+		return Config{}, err
+	}
+
+	port := DefaultPort
+	if b.s.port != nil {
+		port = *b.s.port
+	}
+
+	return Config{
+		Port:            port,
+		ReadTimeout:     b.s.readTimeout,
+		WriteTimeout:    b.s.writeTimeout,
+		IdleTimeout:     b.s.idleTimeout,
+		TLSConfig:       b.s.tlsConfig,
+		MaxHeaderBytes:  b.s.maxHeaderBytes,
+		Handler:         b.s.handler,
+		Middleware:      b.s.middleware,
+		ShutdownTimeout: b.s.shutdownTimeout,
+		Logger:          b.s.logger,
+	}, nil
+}
+
+func NewServerUseBuilder(addr string, builder *ConfigBuilder) (*Server, error) {
+	cfg, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewServerUseConfig(addr, cfg)
+}
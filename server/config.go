@@ -0,0 +1,51 @@
+package server
+
+import (
+	"crypto/tls"
+	"log"
+	"net/http"
+	"time"
+)
+
+/*							첫번째 방법 Struct 활용하는 방법 ✅ 								*/
+type Config struct {
+	Port            int // 0 이면 DefaultPort (지정하지 않은 것과 0 을 구분할 수 없다)
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	TLSConfig       *tls.Config
+	MaxHeaderBytes  int
+	Handler         http.Handler
+	Middleware      []Middleware
+	ShutdownTimeout time.Duration
+	Logger          *log.Logger
+}
+
+func (c Config) settings() *settings {
+	s := &settings{
+		readTimeout:     c.ReadTimeout,
+		writeTimeout:    c.WriteTimeout,
+		idleTimeout:     c.IdleTimeout,
+		tlsConfig:       c.TLSConfig,
+		maxHeaderBytes:  c.MaxHeaderBytes,
+		handler:         c.Handler,
+		middleware:      c.Middleware,
+		shutdownTimeout: c.ShutdownTimeout,
+		logger:          c.Logger,
+	}
+
+	if c.Port != 0 {
+		s.port = &c.Port
+	}
+
+	return s
+}
+
+func NewServerUseConfig(addr string, config Config) (*Server, error) {
+	s := config.settings()
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	return s.build(addr), nil
+}
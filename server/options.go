@@ -0,0 +1,116 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+/*							세번째 방법 Functional Options 패턴 ✅ 								*/
+type Option func(options *settings) error
+
+func WithPort(port int) Option {
+	return func(options *settings) error {
+		if err := validatePort(port); err != nil {
+			return err
+		}
+
+		options.port = &port
+		return nil
+	}
+}
+
+func WithReadTimeout(d time.Duration) Option {
+	return func(options *settings) error {
+		options.readTimeout = d
+		return nil
+	}
+}
+
+func WithWriteTimeout(d time.Duration) Option {
+	return func(options *settings) error {
+		options.writeTimeout = d
+		return nil
+	}
+}
+
+func WithIdleTimeout(d time.Duration) Option {
+	return func(options *settings) error {
+		options.idleTimeout = d
+		return nil
+	}
+}
+
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(options *settings) error {
+		if cfg == nil {
+			return errors.New("tls config must not be nil")
+		}
+
+		options.tlsConfig = cfg
+		return nil
+	}
+}
+
+func WithMaxHeaderBytes(n int) Option {
+	return func(options *settings) error {
+		options.maxHeaderBytes = n
+		return nil
+	}
+}
+
+func WithHandler(h http.Handler) Option {
+	return func(options *settings) error {
+		if h == nil {
+			return errors.New("handler must not be nil")
+		}
+
+		options.handler = h
+		return nil
+	}
+}
+
+// WithMiddleware 는 여러번 호출하면 호출한 순서대로 이어 붙인다
+func WithMiddleware(mw ...Middleware) Option {
+	return func(options *settings) error {
+		options.middleware = append(options.middleware, mw...)
+		return nil
+	}
+}
+
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(options *settings) error {
+		options.shutdownTimeout = d
+		return nil
+	}
+}
+
+func WithLogger(l *log.Logger) Option {
+	return func(options *settings) error {
+		if l == nil {
+			return errors.New("logger must not be nil")
+		}
+
+		options.logger = l
+		return nil
+	}
+}
+
+// NewServer 는 옵션을 한번씩만 적용하고, 모든 옵션을 적용한 뒤 전체 설정을 검증한다
+func NewServer(addr string, opts ...Option) (*Server, error) {
+	options := &settings{}
+
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	return options.build(addr), nil
+}
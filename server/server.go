@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+/*
+11.함수현_패턴 의 세가지 방법(Struct, Builder, Functional Options)을 실제로 동작하게 만든 패키지
+
+	srv, err := server.NewServer("localhost",
+		server.WithPort(8080),
+		server.WithReadTimeout(5*time.Second),
+		server.WithMiddleware(logging, recovery),
+	)
+
+요구사항 (_struct.go)
+- 포트를 지정했는가?    -> Default Port 사용
+  - 음수인가?           -> 음수 사용 불가 (에러 리턴)
+  - 0 인가?            -> 0 사용 불가 (에러 리턴)
+  - 지정한 포트 사용
+
+세가지 방법 모두 같은 설정이면 같은 서버를 만든다.
+*/
+
+const (
+	DefaultPort            = 8080
+	DefaultShutdownTimeout = 10 * time.Second
+)
+
+var (
+	ErrNegativePort = errors.New("port must be positive")
+	ErrZeroPort     = errors.New("port must be non-zero")
+)
+
+// Middleware 는 핸들러를 감싼다. 먼저 지정한 middleware 가 가장 바깥에서 실행된다.
+type Middleware func(http.Handler) http.Handler
+
+// Server 는 설정된 http.Server 와 graceful shutdown 제한시간이다
+type Server struct {
+	*http.Server
+
+	ShutdownTimeout time.Duration
+}
+
+// settings 는 세가지 방법이 공통으로 만드는 최종 설정이다
+type settings struct {
+	port            *int
+	readTimeout     time.Duration
+	writeTimeout    time.Duration
+	idleTimeout     time.Duration
+	tlsConfig       *tls.Config
+	maxHeaderBytes  int
+	handler         http.Handler
+	middleware      []Middleware
+	shutdownTimeout time.Duration
+	logger          *log.Logger
+}
+
+func (s *settings) validate() error {
+	var errs []error
+
+	if s.port != nil {
+		if err := validatePort(*s.port); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	timeouts := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"read timeout", s.readTimeout},
+		{"write timeout", s.writeTimeout},
+		{"idle timeout", s.idleTimeout},
+		{"shutdown timeout", s.shutdownTimeout},
+	}
+	for _, t := range timeouts {
+		if t.d < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative: %s", t.name, t.d))
+		}
+	}
+
+	if s.maxHeaderBytes < 0 {
+		errs = append(errs, fmt.Errorf("max header bytes must not be negative: %d", s.maxHeaderBytes))
+	}
+
+	if s.tlsConfig != nil && len(s.tlsConfig.Certificates) == 0 && s.tlsConfig.GetCertificate == nil {
+		errs = append(errs, errors.New("tls config needs Certificates or GetCertificate"))
+	}
+
+	for i, mw := range s.middleware {
+		if mw == nil {
+			errs = append(errs, fmt.Errorf("middleware %d is nil", i))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validatePort(port int) error {
+	if port < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativePort, port)
+	}
+
+	if port == 0 {
+		return ErrZeroPort
+	}
+
+	return nil
+}
+
+// build 는 검증된 설정으로 서버를 만든다
+func (s *settings) build(addr string) *Server {
+	port := DefaultPort
+	if s.port != nil {
+		port = *s.port
+	}
+
+	// nil 이면 http.Server 와 같이 DefaultServeMux 를 쓴다
+	handler := s.handler
+	if handler == nil {
+		handler = http.DefaultServeMux
+	}
+
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		handler = s.middleware[i](handler)
+	}
+
+	shutdownTimeout := s.shutdownTimeout
+	if shutdownTimeout == 0 {
+		shutdownTimeout = DefaultShutdownTimeout
+	}
+
+	return &Server{
+		Server: &http.Server{
+			Addr:           net.JoinHostPort(addr, strconv.Itoa(port)),
+			Handler:        handler,
+			TLSConfig:      s.tlsConfig,
+			ReadTimeout:    s.readTimeout,
+			WriteTimeout:   s.writeTimeout,
+			IdleTimeout:    s.idleTimeout,
+			MaxHeaderBytes: s.maxHeaderBytes,
+			ErrorLog:       s.logger,
+		},
+		ShutdownTimeout: shutdownTimeout,
+	}
+}
+
+// Run 은 서버를 시작하고, ctx 가 취소되면 ShutdownTimeout 안에서 graceful shutdown 한다
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		var err error
+		if s.TLSConfig != nil {
+			err = s.ListenAndServeTLS("", "")
+		} else {
+			err = s.ListenAndServe()
+		}
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func tag(name string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Chain", name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+var hello = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("hello"))
+})
+
+// sameServer 는 비교 가능한 필드와 핸들러 동작이 같은지 확인한다
+func sameServer(t *testing.T, name string, got, want *Server) {
+	t.Helper()
+
+	if got.Addr != want.Addr ||
+		got.ReadTimeout != want.ReadTimeout ||
+		got.WriteTimeout != want.WriteTimeout ||
+		got.IdleTimeout != want.IdleTimeout ||
+		got.MaxHeaderBytes != want.MaxHeaderBytes ||
+		got.TLSConfig != want.TLSConfig ||
+		got.ErrorLog != want.ErrorLog ||
+		got.ShutdownTimeout != want.ShutdownTimeout {
+		t.Errorf("%s: server = %+v, want %+v", name, got.Server, want.Server)
+	}
+
+	serve := func(s *Server) (string, string) {
+		rec := httptest.NewRecorder()
+		s.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return strings.Join(rec.Header().Values("X-Chain"), ","), rec.Body.String()
+	}
+
+	gotChain, gotBody := serve(got)
+	wantChain, wantBody := serve(want)
+	if gotChain != wantChain || gotBody != wantBody {
+		t.Errorf("%s: handler = (%q, %q), want (%q, %q)", name, gotChain, gotBody, wantChain, wantBody)
+	}
+}
+
+func TestVariantsProduceSameServer(t *testing.T) {
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{{}}}
+	logger := log.New(&bytes.Buffer{}, "", 0)
+
+	byConfig, err := NewServerUseConfig("localhost", Config{
+		Port:            9000,
+		ReadTimeout:     time.Second,
+		WriteTimeout:    2 * time.Second,
+		IdleTimeout:     3 * time.Second,
+		TLSConfig:       tlsConfig,
+		MaxHeaderBytes:  1 << 10,
+		Handler:         hello,
+		Middleware:      []Middleware{tag("a"), tag("b")},
+		ShutdownTimeout: 4 * time.Second,
+		Logger:          logger,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byBuilder, err := NewServerUseBuilder("localhost", new(ConfigBuilder).
+		Port(9000).
+		ReadTimeout(time.Second).
+		WriteTimeout(2*time.Second).
+		IdleTimeout(3*time.Second).
+		TLSConfig(tlsConfig).
+		MaxHeaderBytes(1<<10).
+		Handler(hello).
+		Middleware(tag("a"), tag("b")).
+		ShutdownTimeout(4*time.Second).
+		Logger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byOptions, err := NewServer("localhost",
+		WithPort(9000),
+		WithReadTimeout(time.Second),
+		WithWriteTimeout(2*time.Second),
+		WithIdleTimeout(3*time.Second),
+		WithTLSConfig(tlsConfig),
+		WithMaxHeaderBytes(1<<10),
+		WithHandler(hello),
+		WithMiddleware(tag("a")),
+		WithMiddleware(tag("b")),
+		WithShutdownTimeout(4*time.Second),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if byOptions.Addr != "localhost:9000" {
+		t.Errorf("Addr = %q, want localhost:9000", byOptions.Addr)
+	}
+
+	sameServer(t, "builder", byBuilder, byConfig)
+	sameServer(t, "options", byOptions, byConfig)
+
+	// 먼저 지정한 middleware 가 바깥에서 실행된다
+	rec := httptest.NewRecorder()
+	byOptions.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := strings.Join(rec.Header().Values("X-Chain"), ","); got != "a,b" {
+		t.Errorf("middleware order = %q, want a,b", got)
+	}
+}
+
+func TestDefaultPort(t *testing.T) {
+	byConfig, err := NewServerUseConfig("", Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byBuilder, err := NewServerUseBuilder("", &ConfigBuilder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byOptions, err := NewServer("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, s := range map[string]*Server{"config": byConfig, "builder": byBuilder, "options": byOptions} {
+		if s.Addr != ":8080" {
+			t.Errorf("%s: Addr = %q, want :8080", name, s.Addr)
+		}
+
+		if s.ShutdownTimeout != DefaultShutdownTimeout {
+			t.Errorf("%s: ShutdownTimeout = %s, want %s", name, s.ShutdownTimeout, DefaultShutdownTimeout)
+		}
+
+		if s.Handler != http.DefaultServeMux {
+			t.Errorf("%s: Handler = %v, want DefaultServeMux", name, s.Handler)
+		}
+	}
+}
+
+func TestInvalidPort(t *testing.T) {
+	tests := []struct {
+		port int
+		want error
+	}{
+		{-1, ErrNegativePort},
+		{0, ErrZeroPort},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewServer("", WithPort(tt.port)); !errors.Is(err, tt.want) {
+			t.Errorf("NewServer(WithPort(%d)) = %v, want %v", tt.port, err, tt.want)
+		}
+
+		if _, err := NewServerUseBuilder("", new(ConfigBuilder).Port(tt.port)); !errors.Is(err, tt.want) {
+			t.Errorf("Builder.Port(%d) = %v, want %v", tt.port, err, tt.want)
+		}
+	}
+
+	// Struct 방법은 0 을 "지정하지 않음" 으로 본다
+	if _, err := NewServerUseConfig("", Config{Port: -1}); !errors.Is(err, ErrNegativePort) {
+		t.Errorf("Config{Port: -1} = %v, want ErrNegativePort", err)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	_, err := NewServer("",
+		WithReadTimeout(-time.Second),
+		WithMaxHeaderBytes(-1),
+		WithTLSConfig(&tls.Config{}),
+		WithMiddleware(nil),
+	)
+
+	for _, want := range []string{
+		"read timeout must not be negative: -1s",
+		"max header bytes must not be negative: -1",
+		"tls config needs Certificates or GetCertificate",
+		"middleware 0 is nil",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("NewServer() error = %v, want it to contain %q", err, want)
+		}
+	}
+
+	for name, opt := range map[string]Option{
+		"handler": WithHandler(nil),
+		"logger":  WithLogger(nil),
+		"tls":     WithTLSConfig(nil),
+	} {
+		if _, err := NewServer("", opt); err == nil {
+			t.Errorf("%s: NewServer(nil) = nil error", name)
+		}
+	}
+}
+
+func TestRunGracefulShutdown(t *testing.T) {
+	srv, err := NewServer("127.0.0.1", WithPort(18080), WithHandler(hello), WithShutdownTimeout(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Run(ctx)
+	}()
+
+	var resp *http.Response
+	for i := 0; i < 100; i++ {
+		if resp, err = http.Get("http://" + srv.Addr); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("Run() = %v, want nil after shutdown", err)
+	}
+}
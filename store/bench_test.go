@@ -0,0 +1,126 @@
+package store
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+/*
+sync.Map, RWMutex 하나로 감싼 map 과 비교한다
+
+	go test ./store -bench . -benchmem -cpu 1,8
+
+- 읽기가 대부분이면 Store 의 Get 은 sync.Map 과 비슷하다 (shard 하나의 RLock, 만료 시각이 없으면 시계도 읽지 않는다)
+- 쓰기는 item 을 새로 만들므로 RWMutex map 보다 느리지만, sync.Map 보다 할당이 적다
+- index 가 있으면 쓰기마다 index 도 옮기므로 그만큼 느려진다. 그 대가로 Find, TTL, 복사를 얻는다
+- shard 의 효과는 코어가 많을 때 (-cpu 8 이상) 쓰기가 섞여야 보인다
+*/
+
+const benchKeys = 1 << 12
+
+// kv 는 비교할 저장소들의 공통 모양이다
+type kv interface {
+	Get(k string) (Customer, bool)
+	Set(k string, v Customer)
+}
+
+type syncMap struct{ m sync.Map }
+
+func (s *syncMap) Get(k string) (Customer, bool) {
+	v, ok := s.m.Load(k)
+	if !ok {
+		return Customer{}, false
+	}
+	return v.(Customer), true
+}
+
+func (s *syncMap) Set(k string, v Customer) { s.m.Store(k, v) }
+
+type mutexMap struct {
+	mu sync.RWMutex
+	m  map[string]Customer
+}
+
+func (s *mutexMap) Get(k string) (Customer, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.m[k]
+	return v, ok
+}
+
+func (s *mutexMap) Set(k string, v Customer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.m[k] = v
+}
+
+func benchStores() []struct {
+	name string
+	new  func() kv
+} {
+	return []struct {
+		name string
+		new  func() kv
+	}{
+		{"Store1", func() kv { return New[string, Customer](WithShards[Customer](1)) }},
+		{"Store16", func() kv { return New[string, Customer](WithShards[Customer](16)) }},
+		{"Store16Index", func() kv {
+			return New[string, Customer](WithShards[Customer](16), WithIndex("balance", balanceRange))
+		}},
+		{"SyncMap", func() kv { return &syncMap{} }},
+		{"MutexMap", func() kv { return &mutexMap{m: map[string]Customer{}} }},
+	}
+}
+
+func benchmarkMix(b *testing.B, writePercent int) {
+	ids := make([]string, benchKeys)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+
+	for _, bs := range benchStores() {
+		b.Run(bs.name, func(b *testing.B) {
+			s := bs.new()
+			for i, id := range ids {
+				s.Set(id, Customer{ID: id, Balance: float64(i)})
+			}
+
+			var seed sync.Mutex
+			next := int64(0)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				seed.Lock()
+				next++
+				r := rand.New(rand.NewSource(next))
+				seed.Unlock()
+
+				for pb.Next() {
+					id := ids[r.Intn(len(ids))]
+					if r.Intn(100) < writePercent {
+						s.Set(id, Customer{ID: id, Balance: float64(r.Intn(2000))})
+					} else {
+						s.Get(id)
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkReadHeavy(b *testing.B) {
+	benchmarkMix(b, 1)
+}
+
+func BenchmarkMixed(b *testing.B) {
+	benchmarkMix(b, 25)
+}
+
+func BenchmarkWriteHeavy(b *testing.B) {
+	benchmarkMix(b, 90)
+}
@@ -0,0 +1,479 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"sync"
+	"time"
+)
+
+/*
+22.go 의 Store 는 잠금 없는 map[string]*Customer 이고, 저장한 pointer 를 그대로 돌려준다.
+여러 goroutine 이 같이 써도 안전한 제네릭 메모리 저장소다.
+
+	s := store.New[string, Customer](
+		store.WithIndex("balance", balanceRange), // func(Customer) string
+		store.WithTTL[Customer](time.Hour),
+	)
+	defer s.Close()
+
+	s.Set(c.ID, c)
+	rich, _ := s.Find("balance", "1000+")
+
+- key 의 hash 로 고른 shard 마다 RWMutex 가 있다. 다른 shard 의 읽기/쓰기는 서로 기다리지 않는다 (WithShards(1) 이면 RWMutex 하나)
+- WithClone 을 주면 저장할 때와 돌려줄 때 값을 복사한다. 22.go 처럼 pointer 를 나눠 가져서 바깥에서 저장소를 바꾸는 일이 없다
+- 보조 index 는 값에서 key 를 만드는 함수다. Set, Delete 때 같이 바뀐다
+- Snapshot, All 은 모든 shard 를 잠깐 잠그고 한 시점을 복사한다. 순회하는 동안에는 잠그지 않는다
+- TTL 이 지난 값은 읽을 때 없는 것으로 보고, Evict (또는 WithEvictInterval 의 goroutine) 가 지운다
+*/
+
+var ErrUnknownIndex = errors.New("store: unknown index")
+
+type Store[K comparable, V any] struct {
+	shards []*shard[K, V]
+	seed   maphash.Seed
+
+	clone   func(V) V
+	indexes []Index[V]
+	ttl     time.Duration
+	now     func() time.Time
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Index 는 값에서 보조 index 의 key 를 만드는 함수다 (예: 잔액 구간)
+type Index[V any] struct {
+	Name string
+	Key  func(V) string
+}
+
+type shard[K comparable, V any] struct {
+	mu    sync.RWMutex
+	items map[K]*item[V]
+	// index[i][key] 는 indexes[i] 의 key 가 같은 항목들이다
+	index []map[string]map[K]struct{}
+}
+
+type item[V any] struct {
+	value   V
+	expires time.Time // zero 이면 만료되지 않는다
+	keys    []string  // indexes 순서대로의 index key
+}
+
+func (it *item[V]) expired(now time.Time) bool {
+	return !it.expires.IsZero() && !now.Before(it.expires)
+}
+
+// alive 는 하나만 볼 때 쓴다. 만료 시각이 없으면 시계를 읽지 않는다 (time.Now 가 Get 보다 비쌀 수 있다).
+func (s *Store[K, V]) alive(it *item[V]) bool {
+	return it.expires.IsZero() || s.now().Before(it.expires)
+}
+
+type options[V any] struct {
+	shards        int
+	clone         func(V) V
+	indexes       []Index[V]
+	ttl           time.Duration
+	evictInterval time.Duration
+	now           func() time.Time
+}
+
+type Option[V any] func(*options[V])
+
+// WithShards 는 shard 수다 (기본 16). 2 의 거듭제곱으로 올린다.
+func WithShards[V any](n int) Option[V] {
+	return func(o *options[V]) {
+		o.shards = n
+	}
+}
+
+// WithClone 은 값을 저장할 때와 돌려줄 때 부르는 복사 함수다 (기본은 그대로 대입)
+func WithClone[V any](clone func(V) V) Option[V] {
+	return func(o *options[V]) {
+		o.clone = clone
+	}
+}
+
+// WithIndex 는 Find 로 찾을 보조 index 를 더한다. 이름이 같으면 나중 것이 쓰인다.
+func WithIndex[V any](name string, key func(V) string) Option[V] {
+	return func(o *options[V]) {
+		for i, idx := range o.indexes {
+			if idx.Name == name {
+				o.indexes[i].Key = key
+				return
+			}
+		}
+		o.indexes = append(o.indexes, Index[V]{Name: name, Key: key})
+	}
+}
+
+// WithTTL 은 Set 의 기본 유효 기간이다 (기본 0, 만료되지 않는다)
+func WithTTL[V any](ttl time.Duration) Option[V] {
+	return func(o *options[V]) {
+		o.ttl = ttl
+	}
+}
+
+// WithEvictInterval 은 만료된 값을 지우는 goroutine 의 주기다. Close 로 멈춘다.
+func WithEvictInterval[V any](d time.Duration) Option[V] {
+	return func(o *options[V]) {
+		o.evictInterval = d
+	}
+}
+
+// WithClock 은 만료를 판단할 시각이다 (기본 time.Now)
+func WithClock[V any](now func() time.Time) Option[V] {
+	return func(o *options[V]) {
+		o.now = now
+	}
+}
+
+func New[K comparable, V any](opts ...Option[V]) *Store[K, V] {
+	o := options[V]{shards: 16, now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	n := 1
+	for n < o.shards {
+		n <<= 1
+	}
+
+	s := &Store[K, V]{
+		shards:  make([]*shard[K, V], n),
+		seed:    maphash.MakeSeed(),
+		clone:   o.clone,
+		indexes: o.indexes,
+		ttl:     o.ttl,
+		now:     o.now,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	for i := range s.shards {
+		sh := &shard[K, V]{items: map[K]*item[V]{}, index: make([]map[string]map[K]struct{}, len(o.indexes))}
+		for j := range sh.index {
+			sh.index[j] = map[string]map[K]struct{}{}
+		}
+		s.shards[i] = sh
+	}
+
+	if o.evictInterval > 0 {
+		go s.evictLoop(o.evictInterval)
+	} else {
+		close(s.done)
+	}
+
+	return s
+}
+
+// Close 는 WithEvictInterval 의 goroutine 을 멈춘다. 저장소는 계속 쓸 수 있다.
+func (s *Store[K, V]) Close() {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+}
+
+func (s *Store[K, V]) evictLoop(interval time.Duration) {
+	defer close(s.done)
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-t.C:
+			s.Evict()
+		}
+	}
+}
+
+func (s *Store[K, V]) shard(k K) *shard[K, V] {
+	return s.shards[maphash.Comparable(s.seed, k)&uint64(len(s.shards)-1)]
+}
+
+func (s *Store[K, V]) copy(v V) V {
+	if s.clone == nil {
+		return v
+	}
+
+	return s.clone(v)
+}
+
+// Set 은 k 에 v 를 저장한다. 기본 TTL 이 있으면 그 뒤에 만료된다.
+func (s *Store[K, V]) Set(k K, v V) {
+	s.SetTTL(k, v, s.ttl)
+}
+
+// SetTTL 은 ttl 뒤에 만료되는 값을 저장한다 (0 이면 만료되지 않는다)
+func (s *Store[K, V]) SetTTL(k K, v V, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = s.now().Add(ttl)
+	}
+	it := s.newItem(s.copy(v), expires)
+
+	sh := s.shard(k)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	sh.put(k, it)
+}
+
+// newItem 은 expires 에 만료되는 item 을 만든다 (zero 면 만료되지 않는다)
+func (s *Store[K, V]) newItem(v V, expires time.Time) *item[V] {
+	it := &item[V]{value: v, keys: make([]string, len(s.indexes)), expires: expires}
+	for i, idx := range s.indexes {
+		it.keys[i] = idx.Key(v)
+	}
+
+	return it
+}
+
+func (s *Store[K, V]) Get(k K) (V, bool) {
+	sh := s.shard(k)
+	sh.mu.RLock()
+	it, ok := sh.items[k]
+	sh.mu.RUnlock()
+
+	if !ok || !s.alive(it) {
+		var zero V
+		return zero, false
+	}
+
+	// item 은 바뀌지 않고 바뀔 때는 새로 만들어지므로 잠금 밖에서 복사해도 된다
+	return s.copy(it.value), true
+}
+
+/*
+Update 는 k 의 값을 읽고 바꾸는 것을 shard 를 잠근 채로 한번에 한다.
+f 는 지금 값 (없거나 만료됐으면 ok 가 false) 을 받아 새 값과 저장할지를 돌려준다.
+f 안에서 같은 Store 를 부르면 안 된다.
+*/
+func (s *Store[K, V]) Update(k K, f func(old V, ok bool) (V, bool)) (V, bool) {
+	sh := s.shard(k)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	var old V
+	it, ok := sh.items[k]
+	if ok && !s.alive(it) {
+		ok = false
+	}
+	if ok {
+		old = s.copy(it.value)
+	}
+
+	v, keep := f(old, ok)
+	if !keep {
+		return old, false
+	}
+
+	// 있던 값을 바꿀 때는 만료 시각을 그대로 둔다 (SetTTL(k, v, 0) 로 넣은 값은 계속 만료되지 않는다)
+	var expires time.Time
+	switch {
+	case ok:
+		expires = it.expires
+	case s.ttl > 0:
+		expires = s.now().Add(s.ttl)
+	}
+	next := s.newItem(s.copy(v), expires)
+	sh.put(k, next)
+
+	return s.copy(next.value), true
+}
+
+// Delete 는 k 를 지운다. 있었는지 (만료되지 않았는지) 를 돌려준다.
+func (s *Store[K, V]) Delete(k K) bool {
+	sh := s.shard(k)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	it, ok := sh.items[k]
+	if !ok {
+		return false
+	}
+
+	sh.remove(k, it)
+	return s.alive(it)
+}
+
+// Len 은 만료되지 않은 값의 수다
+func (s *Store[K, V]) Len() int {
+	now := s.now()
+
+	n := 0
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		for _, it := range sh.items {
+			if !it.expired(now) {
+				n++
+			}
+		}
+		sh.mu.RUnlock()
+	}
+
+	return n
+}
+
+// Find 는 index 의 key 가 key 인 값들이다. 순서는 정해져 있지 않다.
+func (s *Store[K, V]) Find(index, key string) (map[K]V, error) {
+	i, err := s.indexOf(index)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	out := map[K]V{}
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		for k := range sh.index[i][key] {
+			if it := sh.items[k]; !it.expired(now) {
+				out[k] = it.value
+			}
+		}
+		sh.mu.RUnlock()
+	}
+
+	for k, v := range out {
+		out[k] = s.copy(v)
+	}
+
+	return out, nil
+}
+
+// IndexKeys 는 index 에 있는 key 와 그 key 의 값 수다
+func (s *Store[K, V]) IndexKeys(index string) (map[string]int, error) {
+	i, err := s.indexOf(index)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	out := map[string]int{}
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		for key, ks := range sh.index[i] {
+			for k := range ks {
+				if !sh.items[k].expired(now) {
+					out[key]++
+				}
+			}
+		}
+		sh.mu.RUnlock()
+	}
+
+	return out, nil
+}
+
+func (s *Store[K, V]) indexOf(name string) (int, error) {
+	for i, idx := range s.indexes {
+		if idx.Name == name {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrUnknownIndex, name)
+}
+
+/*
+Snapshot 은 한 시점의 만료되지 않은 모든 값을 복사한다.
+모든 shard 를 순서대로 읽기 잠금한 뒤에 복사하므로, 다른 shard 에 걸친 쓰기의 중간 상태는 보이지 않는다.
+*/
+func (s *Store[K, V]) Snapshot() map[K]V {
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+	}
+
+	now := s.now()
+	out := make(map[K]V, s.lenLocked())
+	for _, sh := range s.shards {
+		for k, it := range sh.items {
+			if !it.expired(now) {
+				out[k] = it.value
+			}
+		}
+	}
+
+	for _, sh := range s.shards {
+		sh.mu.RUnlock()
+	}
+
+	for k, v := range out {
+		out[k] = s.copy(v)
+	}
+
+	return out
+}
+
+func (s *Store[K, V]) lenLocked() int {
+	n := 0
+	for _, sh := range s.shards {
+		n += len(sh.items)
+	}
+
+	return n
+}
+
+// All 은 Snapshot 을 순회한다. 순회하는 동안 Store 를 잠그지 않으므로 안에서 Set, Delete 를 불러도 된다.
+func (s *Store[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s.Snapshot() {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Evict 는 만료된 값을 지우고 지운 수를 돌려준다
+func (s *Store[K, V]) Evict() int {
+	n := 0
+	for _, sh := range s.shards {
+		sh.mu.Lock()
+		now := s.now()
+		for k, it := range sh.items {
+			if it.expired(now) {
+				sh.remove(k, it)
+				n++
+			}
+		}
+		sh.mu.Unlock()
+	}
+
+	return n
+}
+
+// put 과 remove 는 shard 를 잠근 채로 불러야 한다
+func (sh *shard[K, V]) put(k K, it *item[V]) {
+	if old, ok := sh.items[k]; ok {
+		sh.remove(k, old)
+	}
+
+	sh.items[k] = it
+	for i, key := range it.keys {
+		ks, ok := sh.index[i][key]
+		if !ok {
+			ks = map[K]struct{}{}
+			sh.index[i][key] = ks
+		}
+		ks[k] = struct{}{}
+	}
+}
+
+func (sh *shard[K, V]) remove(k K, it *item[V]) {
+	delete(sh.items, k)
+	for i, key := range it.keys {
+		ks := sh.index[i][key]
+		delete(ks, k)
+		if len(ks) == 0 {
+			delete(sh.index[i], key)
+		}
+	}
+}
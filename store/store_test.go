@@ -0,0 +1,456 @@
+package store
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 22.go 의 Customer 와 같은 모양
+type Customer struct {
+	ID      string
+	Balance float64
+	Tags    []string
+}
+
+func balanceRange(c Customer) string {
+	switch {
+	case c.Balance < 100:
+		return "0-99"
+	case c.Balance < 1000:
+		return "100-999"
+	default:
+		return "1000+"
+	}
+}
+
+func cloneCustomer(c *Customer) *Customer {
+	cc := *c
+	cc.Tags = slices.Clone(c.Tags)
+	return &cc
+}
+
+// clock 은 테스트에서 손으로 움직이는 시계다
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func keys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
+func TestSetGetDelete(t *testing.T) {
+	for _, shards := range []int{1, 3, 16} {
+		t.Run(fmt.Sprint(shards), func(t *testing.T) {
+			s := New[string, Customer](WithShards[Customer](shards))
+			defer s.Close()
+
+			s.Set("1", Customer{ID: "1", Balance: 10})
+			s.Set("2", Customer{ID: "2", Balance: 20})
+			s.Set("1", Customer{ID: "1", Balance: 15})
+
+			if c, ok := s.Get("1"); !ok || c.Balance != 15 {
+				t.Errorf("Get(1) = %+v, %v, want balance 15", c, ok)
+			}
+			if _, ok := s.Get("3"); ok {
+				t.Error("Get(3) found a value")
+			}
+			if n := s.Len(); n != 2 {
+				t.Errorf("Len = %d, want 2", n)
+			}
+
+			if !s.Delete("1") {
+				t.Error("Delete(1) = false")
+			}
+			if s.Delete("1") {
+				t.Error("second Delete(1) = true")
+			}
+			if got := keys(s.Snapshot()); !slices.Equal(got, []string{"2"}) {
+				t.Errorf("keys = %v, want [2]", got)
+			}
+		})
+	}
+}
+
+// 22.go 의 storeCustomers 처럼 pointer 를 저장해도, WithClone 이면 바깥에서 저장소를 바꿀 수 없다
+func TestCopyOnRead(t *testing.T) {
+	s := New[string, *Customer](WithClone(cloneCustomer))
+	defer s.Close()
+
+	c := &Customer{ID: "1", Balance: 10, Tags: []string{"vip"}}
+	s.Set(c.ID, c)
+
+	c.Balance = 99
+	c.Tags[0] = "changed"
+
+	got, _ := s.Get("1")
+	if got.Balance != 10 || got.Tags[0] != "vip" {
+		t.Fatalf("stored value changed through the caller's pointer: %+v", got)
+	}
+
+	got.Balance = 42
+	got.Tags[0] = "changed"
+	for _, read := range []*Customer{mustGet(t, s, "1"), s.Snapshot()["1"]} {
+		if read.Balance != 10 || read.Tags[0] != "vip" {
+			t.Fatalf("stored value changed through a returned pointer: %+v", read)
+		}
+	}
+
+	found, err := s.Find("unknown", "x")
+	if !errors.Is(err, ErrUnknownIndex) || found != nil {
+		t.Errorf("Find on an unknown index = %v, %v, want %v", found, err, ErrUnknownIndex)
+	}
+}
+
+func mustGet[K comparable, V any](t *testing.T, s *Store[K, V], k K) V {
+	t.Helper()
+
+	v, ok := s.Get(k)
+	if !ok {
+		t.Fatalf("Get(%v) found nothing", k)
+	}
+	return v
+}
+
+func TestIndex(t *testing.T) {
+	s := New[string, Customer](WithIndex("balance", balanceRange), WithIndex("first", func(c Customer) string {
+		return c.ID[:1]
+	}))
+	defer s.Close()
+
+	s.Set("a1", Customer{ID: "a1", Balance: 50})
+	s.Set("a2", Customer{ID: "a2", Balance: 500})
+	s.Set("b1", Customer{ID: "b1", Balance: 5000})
+	s.Set("b2", Customer{ID: "b2", Balance: 70})
+
+	find := func(index, key string) []string {
+		t.Helper()
+
+		found, err := s.Find(index, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return keys(found)
+	}
+
+	if got := find("balance", "0-99"); !slices.Equal(got, []string{"a1", "b2"}) {
+		t.Errorf("balance 0-99 = %v, want [a1 b2]", got)
+	}
+	if got := find("first", "b"); !slices.Equal(got, []string{"b1", "b2"}) {
+		t.Errorf("first b = %v, want [b1 b2]", got)
+	}
+
+	// 값이 바뀌면 index 도 옮겨간다
+	s.Set("a1", Customer{ID: "a1", Balance: 2000})
+	s.Update("b2", func(c Customer, ok bool) (Customer, bool) {
+		c.Balance += 1000
+		return c, ok
+	})
+	s.Delete("b1")
+
+	if got := find("balance", "0-99"); len(got) != 0 {
+		t.Errorf("balance 0-99 = %v, want none", got)
+	}
+	if got := find("balance", "1000+"); !slices.Equal(got, []string{"a1", "b2"}) {
+		t.Errorf("balance 1000+ = %v, want [a1 b2]", got)
+	}
+
+	counts, err := s.IndexKeys("balance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int{"100-999": 1, "1000+": 2}; !maps.Equal(counts, want) {
+		t.Errorf("IndexKeys = %v, want %v", counts, want)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s := New[string, int]()
+	defer s.Close()
+
+	add := func(n int) func(int, bool) (int, bool) {
+		return func(old int, _ bool) (int, bool) { return old + n, true }
+	}
+
+	if v, ok := s.Update("x", add(5)); !ok || v != 5 {
+		t.Errorf("Update on a missing key = %d, %v, want 5", v, ok)
+	}
+	if v, _ := s.Update("x", add(2)); v != 7 {
+		t.Errorf("Update = %d, want 7", v)
+	}
+
+	// 저장하지 않으면 그대로다
+	s.Update("x", func(old int, ok bool) (int, bool) { return 0, false })
+	s.Update("y", func(old int, ok bool) (int, bool) { return 1, false })
+	if got := s.Snapshot(); !maps.Equal(got, map[string]int{"x": 7}) {
+		t.Errorf("Snapshot = %v, want map[x:7]", got)
+	}
+}
+
+func TestTTL(t *testing.T) {
+	c := newClock()
+	s := New[string, Customer](
+		WithTTL[Customer](time.Minute),
+		WithClock[Customer](c.Now),
+		WithIndex("balance", balanceRange),
+	)
+	defer s.Close()
+
+	s.Set("short", Customer{ID: "short"})
+	s.SetTTL("long", Customer{ID: "long"}, time.Hour)
+	s.SetTTL("forever", Customer{ID: "forever"}, 0)
+
+	c.Advance(30 * time.Second)
+	s.Update("short", func(c Customer, ok bool) (Customer, bool) {
+		c.Balance = 1
+		return c, ok
+	})
+
+	// 만료되지 않는 값은 Update 뒤에도 기본 TTL 을 받지 않는다
+	s.Update("forever", func(c Customer, ok bool) (Customer, bool) {
+		c.Balance = 2
+		return c, ok
+	})
+
+	c.Advance(30 * time.Second)
+	if _, ok := s.Get("short"); ok {
+		t.Error("short is visible after its TTL (Update must keep the remaining TTL)")
+	}
+	if got := keys(s.Snapshot()); !slices.Equal(got, []string{"forever", "long"}) {
+		t.Errorf("Snapshot keys = %v, want [forever long]", got)
+	}
+	if found, _ := s.Find("balance", "0-99"); len(found) != 2 {
+		t.Errorf("Find returned %v, want 2 live values", keys(found))
+	}
+	if n := s.Len(); n != 2 {
+		t.Errorf("Len = %d, want 2", n)
+	}
+
+	// 만료된 값은 Evict 전까지 남아 있지만 보이지 않는다
+	if n := s.Evict(); n != 1 {
+		t.Errorf("Evict = %d, want 1", n)
+	}
+	if s.Delete("short") {
+		t.Error("Delete(short) = true after eviction")
+	}
+
+	c.Advance(time.Hour)
+	if n := s.Evict(); n != 1 {
+		t.Errorf("Evict = %d, want 1", n)
+	}
+	if got := keys(s.Snapshot()); !slices.Equal(got, []string{"forever"}) {
+		t.Errorf("Snapshot keys = %v, want [forever]", got)
+	}
+}
+
+// 시계가 계속 흐르더라도 Update 는 만료 시각을 뒤로 미루지 않는다
+func TestUpdateKeepsExpiry(t *testing.T) {
+	c := newClock()
+	tick := func() time.Time {
+		c.Advance(time.Millisecond)
+		return c.Now()
+	}
+	s := New[string, int](WithClock[int](tick))
+	defer s.Close()
+
+	s.SetTTL("x", 0, time.Hour)
+	want := s.shard("x").items["x"].expires
+
+	for range 100 {
+		s.Update("x", func(n int, ok bool) (int, bool) { return n + 1, ok })
+	}
+
+	if got := s.shard("x").items["x"].expires; !got.Equal(want) {
+		t.Errorf("expires = %v after 100 updates, want %v", got, want)
+	}
+}
+
+func TestEvictInterval(t *testing.T) {
+	c := newClock()
+	s := New[string, int](WithClock[int](c.Now), WithEvictInterval[int](time.Millisecond))
+
+	s.SetTTL("x", 1, time.Second)
+	c.Advance(time.Second)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for s.lenAll() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expired value was not evicted")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	s.Close()
+	s.Close()
+}
+
+// lenAll 은 만료됐지만 아직 지우지 않은 값도 센다
+func (s *Store[K, V]) lenAll() int {
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		defer sh.mu.RUnlock()
+	}
+
+	return s.lenLocked()
+}
+
+// All 은 Snapshot 을 돌기 때문에 순회 중에 Store 를 바꿔도 된다
+func TestAllWhileWriting(t *testing.T) {
+	s := New[int, int]()
+	defer s.Close()
+
+	for i := range 100 {
+		s.Set(i, i)
+	}
+
+	seen := 0
+	for k, v := range s.All() {
+		if k != v {
+			t.Fatalf("All yielded %d: %d", k, v)
+		}
+		s.Delete(k)
+		s.Set(k+1000, k)
+		seen++
+	}
+
+	if seen != 100 {
+		t.Errorf("All yielded %d values, want 100", seen)
+	}
+	if n := s.Len(); n != 100 {
+		t.Errorf("Len = %d, want 100", n)
+	}
+}
+
+/*
+여러 goroutine 이 계좌 사이로 잔액을 옮기면서 (Update) 읽고, index 를 찾고, Snapshot 을 찍는다.
+go test -race 로 돌리면 잠금 없이 쓰는 곳이 드러난다.
+Update 는 shard 하나만 잠그므로 중간의 합은 달라질 수 있지만 (ledger 와 다르다), 끝난 뒤의 합과 각 값, index 는 맞아야 한다.
+*/
+func TestConcurrent(t *testing.T) {
+	const (
+		accounts = 64
+		workers  = 16
+	)
+	ops := 5000
+	if testing.Short() {
+		ops = 500
+	}
+
+	s := New[string, *Customer](
+		WithClone(cloneCustomer),
+		WithIndex("balance", func(c *Customer) string { return balanceRange(*c) }),
+		WithShards[*Customer](8),
+	)
+	defer s.Close()
+
+	for i := range accounts {
+		id := strconv.Itoa(i)
+		s.Set(id, &Customer{ID: id, Balance: 500})
+	}
+
+	var moved atomic.Int64
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			r := rand.New(rand.NewSource(int64(w)))
+			for range ops {
+				id := strconv.Itoa(r.Intn(accounts))
+
+				switch r.Intn(10) {
+				case 0:
+					for _, c := range s.Snapshot() {
+						if c.Balance < 0 {
+							t.Errorf("%s has negative balance %v", c.ID, c.Balance)
+						}
+					}
+				case 1:
+					found, err := s.Find("balance", "1000+")
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					for _, c := range found {
+						if c.Balance < 1000 {
+							t.Errorf("index 1000+ has %s with balance %v", c.ID, c.Balance)
+						}
+					}
+				case 2, 3:
+					c, ok := s.Get(id)
+					if !ok {
+						t.Errorf("Get(%s) found nothing", id)
+						return
+					}
+					c.Balance = -1 // 복사본이므로 저장소는 바뀌지 않는다
+				default:
+					amount := float64(r.Intn(100))
+					_, withdrew := s.Update(id, func(c *Customer, ok bool) (*Customer, bool) {
+						if !ok || c.Balance < amount {
+							return c, false
+						}
+						c.Balance -= amount
+						return c, true
+					})
+					if !withdrew {
+						continue
+					}
+					s.Update(strconv.Itoa(r.Intn(accounts)), func(c *Customer, ok bool) (*Customer, bool) {
+						c.Balance += amount
+						return c, ok
+					})
+					moved.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	var total float64
+	for _, c := range s.Snapshot() {
+		total += c.Balance
+	}
+	if want := float64(accounts * 500); total != want {
+		t.Errorf("total %v after %d transfers, want %v", total, moved.Load(), want)
+	}
+
+	counts, err := s.IndexKeys("balance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	if n != accounts {
+		t.Errorf("index has %d values, want %d", n, accounts)
+	}
+}
@@ -14,7 +14,7 @@ func PrintMemory(msg string) {
 	fmt.Printf("TotalAlloc = %v MiB\t", bToMb(m.TotalAlloc))
 	fmt.Printf("Sys = %v MiB\t", bToMb(m.Sys))
 	fmt.Printf("NumGC = %v\t", m.NumGC)
-	fmt.Println("\n")
+	fmt.Print("\n\n")
 }
 
 func bToMb(b uint64) uint64 {